@@ -1,9 +1,8 @@
 package algorithm
 
-import (
-	"cmp"
-	"gocpp/utility"
-)
+//go:generate go run variants_gen.go
+
+import "cmp"
 
 // Returns an iterator to the beginning of the sequence represented by c.
 func Begin[T any](c []T) int {
@@ -18,391 +17,31 @@ func End[T any](c []T) int {
 // Checks if unary predicate p returns true for all elements in the range
 // r[first, last).
 func AllOf[T any](r []T, first, last int, p func(T) bool) bool {
+	if debug {
+		checkRange("AllOf", "r[first, last)", len(r), first, last)
+	}
+
 	return FindIfNot(r, first, last, p) == last
 }
 
 // Checks if unary predicate p returns true for at least one element in the
 // range r[first, last).
 func AnyOf[T any](r []T, first, last int, p func(T) bool) bool {
+	if debug {
+		checkRange("AnyOf", "r[first, last)", len(r), first, last)
+	}
+
 	return FindIf(r, first, last, p) != last
 }
 
 // Checks if unary predicate p returns true for no elements in the range
 // r[first, last).
 func NoneOf[T any](r []T, first, last int, p func(T) bool) bool {
-	return FindIf(r, first, last, p) == last
-}
-
-// Searches for an element equal to value (using operator==).
-func Find[T comparable](r []T, first, last int, value T) int {
-	for ; first != last; first++ {
-		if r[first] == value {
-			return first
-		}
-	}
-	return last
-}
-
-// Searches for an element for which predicate p returns true.
-func FindIf[T any](r []T, first, last int, p func(T) bool) int {
-	for ; first != last; first++ {
-		if p(r[first]) {
-			return first
-		}
-	}
-	return last
-}
-
-// Searches for an element for which predicate q returns false.
-func FindIfNot[T any](r []T, first, last int, q func(T) bool) int {
-	for ; first != last; first++ {
-		if !q(r[first]) {
-			return first
-		}
-	}
-	return last
-}
-
-// Returns an iterator pointing to the first element in the range r[first, last)
-// such that element >= value, or last if no such element is found.
-func LowerBound[T cmp.Ordered](r []T, first, last int, value T) int {
-	for first < last-1 {
-		if it := first + (last-first)/2; r[it] < value {
-			first = it
-		} else {
-			last = it
-		}
-	}
-	return last
-}
-
-// Returns an iterator pointing to the first element in the range r[first, last)
-// such that comp(element, value) is false, or last if no such element is found.
-func LowerBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) int {
-	for first < last-1 {
-		if it := first + (last-first)/2; comp(r[it], value) {
-			first = it
-		} else {
-			last = it
-		}
-	}
-	return last
-}
-
-// Returns an iterator pointing to the first element in the range r[first, last)
-// such that value < element, or last if no such element is found.
-func UpperBound[T cmp.Ordered](r []T, first, last int, value T) int {
-	for first < last-1 {
-		if it := first + (last-first)/2; r[it] <= value {
-			first = it
-		} else {
-			last = it
-		}
-	}
-	return last
-}
-
-// Returns an iterator pointing to the first element in the range r[first, last)
-// such that comp(element, value) is true, or last if no such element is found.
-func UpperBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) int {
-	for first < last-1 {
-		if it := first + (last-first)/2; !comp(value, r[it]) {
-			first = it
-		} else {
-			last = it
-		}
-	}
-	return last
-}
-
-// Searches the range r[first, last) for two consecutive equal elements. Returns
-// an iterator to the first of the first pair of identical elements if found,
-// that is, the first iterator it such that r[it] == r[it + 1]; last otherwise.
-func AdjacentFind[T comparable](r []T, first, last int) int {
-	if first == last {
-		return last
-	}
-
-	for next := first + 1; next != last; {
-		if r[first] == r[next] {
-			return first
-		}
-		next++
-		first++
-	}
-
-	return last
-}
-
-// Searches the range r[first, last) for two consecutive equal elements. Returns
-// an iterator to the first of the first pair of identical elements if found,
-// that is, the first iterator it such that p(*it, *(it + 1)) != false; last
-// otherwise.
-func AdjacentFindFunc[T any](r []T, first, last int, p func(T, T) bool) int {
-	if first == last {
-		return last
-	}
-
-	for next := first + 1; next != last; {
-		if p(r[first], r[next]) {
-			return first
-		}
-		next++
-		first++
-	}
-
-	return last
-}
-
-// Returns the number of elements in the range r[first, last) satisfying
-// specific criteria. Counts the elements that are equal to value
-// (using operator==).
-func Count[T comparable](r []T, first, last int, value T) int {
-	ret := int(0)
-	for ; first != last; first++ {
-		if r[first] == value {
-			ret++
-		}
-	}
-	return ret
-}
-
-// Returns the number of elements in the range r[first, last) satisfying specific
-// criteria. Counts elements for which predicate p returns true.
-func CountIf[T any](r []T, first, last int, p func(T) bool) int {
-	ret := int(0)
-	for ; first != last; first++ {
-		if p(r[first]) {
-			ret++
-		}
-	}
-	return ret
-}
-
-// Returns the first mismatching pair of elements from two ranges: one defined
-// by r1[first1, last1) and another defined by r2[first2, first2 + last1 - first1).
-// Elements are compared using operator==.
-func Mismatch[T comparable](r1, r2 []T, first1, last1, first2 int) utility.Pair[int, int] {
-	for first1 != last1 && r1[first1] == r1[first2] {
-		first1++
-		first2++
-	}
-
-	return utility.MakePair(first1, first2)
-}
-
-// Returns the first mismatching pair of elements from two ranges: one defined
-// by r1[first1, last1) and another defined by r2[first2, first2 + last1 - first1).
-// Elements are compared using the given binary predicate p.
-func MismatchFunc[T any](r1, r2 []T, first1, last1, first2 int, p func(T, T) bool) utility.Pair[int, int] {
-	for first1 != last1 && p(r1[first1], r2[first2]) {
-		first1++
-		first2++
-	}
-
-	return utility.MakePair(first1, first2)
-}
-
-// Returns the first mismatching pair of elements from two ranges: one defined
-// by r1[first1, last1) and another defined by r2[first2, last2). Elements are
-// compared using operator==.
-func Mismatch2[T comparable](r1, r2 []T, first1, last1, first2, last2 int) utility.Pair[int, int] {
-	for first1 != last1 && first2 != last2 && r1[first1] == r1[first2] {
-		first1++
-		first2++
-	}
-
-	return utility.MakePair(first1, first2)
-}
-
-// Returns the first mismatching pair of elements from two ranges: one defined
-// by r1[first1, last1) and another defined by r2[first2, last2). Elements are
-// compared using the given binary predicate p.
-func MismatchFunc2[T any](r1, r2 []T, first1, last1, first2, last2 int, p func(T, T) bool) utility.Pair[int, int] {
-	for first1 != last1 && first2 != last2 && p(r1[first1], r2[first2]) {
-		first1++
-		first2++
-	}
-
-	return utility.MakePair(first1, first2)
-}
-
-// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
-// first2 + (last1 - first1)), and false otherwise. Two ranges are considered
-// equal if they have the same number of elements and, for every iterator i in
-// the range [first1, last1), *i equals *(first2 + (i - first1)). Uses
-// operator== to determine if two elements are equal.
-func Equal[T comparable](r1, r2 []T, first1, last1, first2 int) bool {
-	for first1 != last1 {
-		if r1[first1] != r2[first2] {
-			return false
-		}
-		first1++
-		first2++
-	}
-
-	return true
-}
-
-// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
-// first2 + (last1 - first1)), and false otherwise. Two ranges are considered
-// equal if they have the same number of elements and, for every iterator i in
-// the range [first1, last1), *i equals *(first2 + (i - first1)). Uses given
-// binary predicate p to determine if two elements are equal.
-func EqualFunc[T any](r1, r2 []T, first1, last1, first2 int, p func(T, T) bool) bool {
-	for first1 != last1 {
-		if !p(r1[first1], r2[first2]) {
-			return false
-		}
-		first1++
-		first2++
-	}
-
-	return true
-}
-
-// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
-// last2), and false otherwise. Two ranges are considered equal if they have
-// the same number of elements and, for every iterator i in the range [first1,
-// last1), *i equals *(first2 + (i - first1)). Uses operator== to determine if
-// two elements are equal.
-func Equal2[T comparable](r1, r2 []T, first1, last1, first2, last2 int) bool {
-	for first1 != last1 && first2 != last2 {
-		if r1[first1] != r2[first2] {
-			return false
-		}
-		first1++
-		first2++
-	}
-
-	return true
-}
-
-// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
-// last2), and false otherwise. Two ranges are considered equal if they have
-// the same number of elements and, for every iterator i in the range [first1,
-// last1), *i equals *(first2 + (i - first1)). Uses given binary predicate p to
-// determine if two elements are equal.
-func EqualFunc2[T any](r1, r2 []T, first1, last1, first2, last2 int, p func(T, T) bool) bool {
-	for first1 != last1 && first2 != last2 {
-		if !p(r1[first1], r2[first2]) {
-			return false
-		}
-		first1++
-		first2++
-	}
-
-	return true
-}
-
-// Searches for the first occurrence of the sequence of elements r2[s_first,
-// s_last) in the range r1[first, last). Elements are compared using operator==.
-func Search[T comparable](r1, r2 []T, first, last, s_first, s_last int) int {
-	for {
-		it := first
-		for s_it := s_first; ; {
-			if s_it == s_last {
-				return first
-			}
-			if it == last {
-				return last
-			}
-			if r1[it] != r2[s_it] {
-				break
-			}
-			it++
-			s_it++
-		}
-		first++
-	}
-}
-
-// Searches for the first occurrence of the sequence of elements r2[s_first,
-// s_last) in the range r1[first, last). Elements are compared using the given
-// binary predicate p.
-func SearchFunc[T any](r1, r2 []T, first, last, s_first, s_last int, p func(T, T) bool) int {
-	for {
-		it := first
-		for s_it := s_first; ; {
-			if s_it == s_last {
-				return first
-			}
-			if it == last {
-				return last
-			}
-			if !p(r1[it], r2[s_it]) {
-				break
-			}
-			it++
-			s_it++
-		}
-		first++
+	if debug {
+		checkRange("NoneOf", "r[first, last)", len(r), first, last)
 	}
-}
-
-// Searches the range r[first, last) for the first sequence of count identical
-// elements, each equal to the given value. Elements are compared using
-// operator==.
-func SearchN[T comparable](r []T, first, last, count int, value T) int {
-	if count <= 0 {
-		return first
-	}
-
-	for ; first != last; first++ {
-		if r[first] != value {
-			continue
-		}
-
-		candidate := first
-
-		for cur_count := 1; ; cur_count++ {
-			if cur_count >= count {
-				return candidate
-			}
-
-			first++
-			if first == last {
-				return last
-			}
-
-			if r[first] != value {
-				break
-			}
-		}
-	}
-	return last
-}
-
-// Searches the range r[first, last) for the first sequence of count identical
-// elements, each equal to the given value. Elements are compared using the
-// given binary predicate p.
-func SearchNFunc[T any](r []T, first, last, count int, value T, p func(T, T) bool) int {
-	if count <= 0 {
-		return first
-	}
-
-	for ; first != last; first++ {
-		if !p(r[first], value) {
-			continue
-		}
 
-		candidate := first
-
-		for cur_count := 1; ; cur_count++ {
-			if cur_count >= count {
-				return candidate
-			}
-
-			if first++; first == last {
-				return last
-			}
-
-			if !p(r[first], value) {
-				break
-			}
-		}
-	}
-	return last
+	return FindIf(r, first, last, p) == last
 }
 
 // Copies the elements in the range, defined by r[first, last), to another range
@@ -410,13 +49,13 @@ func SearchNFunc[T any](r []T, first, last, count int, value T, p func(T, T) boo
 // range r[first, last) starting from first and proceeding to last. If d_first
 // is in r[first, last), the behavior is undefined.
 func Copy[T any](r1, r2 []T, first, last, d_first int) int {
-	for first != last {
-		r2[d_first] = r1[first]
-		first++
-		d_first++
+	if debug {
+		checkRange("Copy", "r1[first, last)", len(r1), first, last)
+		checkRoom("Copy", "destination r2", len(r2), d_first, last-first)
+		checkNotWithin("Copy", "d_first", r1, first, last, r2, d_first, false)
 	}
 
-	return d_first
+	return d_first + copy(r2[d_first:d_first+last-first:len(r2)], r1[first:last:len(r1)])
 }
 
 // Copies the elements in the range, defined by r[first, last), to another range
@@ -425,8 +64,15 @@ func Copy[T any](r1, r2 []T, first, last, d_first int) int {
 // relative order of the elements that are copied is preserved. If [first, last)
 // and the copy destination range overlaps, the behavior is undefined.
 func CopyIf[T any](r1, r2 []T, first, last, d_first int, pred func(T) bool) int {
+	if debug {
+		checkRange("CopyIf", "r1[first, last)", len(r1), first, last)
+	}
+
 	for first != last {
 		if pred(r1[first]) {
+			if debug {
+				checkWrite("CopyIf", "r2", r2, d_first, r1[first:last])
+			}
 			r2[d_first] = r1[first]
 			d_first++
 		}
@@ -441,44 +87,60 @@ func CopyIf[T any](r1, r2 []T, first, last, d_first int, pred func(T) bool) int
 // *(result + i) = *(first + i). Overlap of ranges is formally permitted, but
 // leads to unpredictable ordering of the results.
 func CopyN[T any](r1, r2 []T, first, count, result int) int {
-	if count > 0 {
-		r2[result] = r1[first]
-		first++
-		result++
-		for i := 1; i != count; i++ {
-			r2[result] = r1[first]
-			first++
-			result++
+	if debug {
+		if count > 0 {
+			checkRoom("CopyN", "r1", len(r1), first, count)
+			checkRoom("CopyN", "destination r2", len(r2), result, count)
 		}
 	}
 
+	if count > 0 {
+		result += copy(r2[result:result+count:len(r2)], r1[first:first+count:len(r1)])
+	}
+
 	return result
 }
 
 // Copies the elements from the range r1[first, last) to another range ending at
 // r2[d_last]. The elements are copied in reverse order (the last element is
 // copied first), but their relative order is preserved. The behavior is
-// undefined if d_last is within (first, last).
+// undefined if d_last is within (first, last].
 func CopyBackward[T any](r1, r2 []T, first, last, d_last int) int {
-	for first != last {
-		d_last--
-		last--
-		r2[d_last] = r1[last]
+	if debug {
+		checkRange("CopyBackward", "r1[first, last)", len(r1), first, last)
+		checkRoom("CopyBackward", "destination r2", len(r2), d_last-(last-first), last-first)
+		checkNotWithin("CopyBackward", "d_last", r1, first, last, r2, d_last, true)
 	}
+
+	d_last -= last - first
+	copy(r2[d_last:d_last+last-first:len(r2)], r1[first:last:len(r1)])
 	return d_last
 }
 
 // Moves the elements in the range r1[first, last), to another range beginning
 // at r2[d_first], starting from r1[first] and proceeding to r1[last - 1].
 func Move[T any](r1, r2 []T, first, last, d_first int) int {
+	if debug {
+		checkRange("Move", "r1[first, last)", len(r1), first, last)
+		checkRoom("Move", "destination r2", len(r2), d_first, last-first)
+		checkNotWithin("Move", "d_first", r1, first, last, r2, d_first, false)
+	}
+
 	return Copy(r1, r2, first, last, d_first)
 }
 
 // Moves the elements from the range r1[first, last), to another range ending at
 // r2[d_last]. The elements are moved in reverse order (the last element is
-// moved first), but their relative order is preserved.
-func MoveBackward[T any](r1, r2 []T, first, last, d_first int) int {
-	return CopyBackward(r1, r2, first, last, d_first)
+// moved first), but their relative order is preserved. The behavior is
+// undefined if d_last is within (first, last].
+func MoveBackward[T any](r1, r2 []T, first, last, d_last int) int {
+	if debug {
+		checkRange("MoveBackward", "r1[first, last)", len(r1), first, last)
+		checkRoom("MoveBackward", "destination r2", len(r2), d_last-(last-first), last-first)
+		checkNotWithin("MoveBackward", "d_last", r1, first, last, r2, d_last, true)
+	}
+
+	return CopyBackward(r1, r2, first, last, d_last)
 }
 
 // Swaps the values a and b.
@@ -491,6 +153,12 @@ func Swap[T any](a, b *T) {
 // last2) do not overlap,
 // where r2[last2] = r2[Next(first2, distance(first1, last1))].
 func SwapRanges[T any](r1, r2 []T, first1, last1, first2 int) int {
+	if debug {
+		checkRange("SwapRanges", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("SwapRanges", "r2", len(r2), first2, last1-first1)
+		checkNoOverlap("SwapRanges", r1[first1:last1], r2[first2:first2+last1-first1])
+	}
+
 	for first1 != last1 {
 		IterSwap(&r1[first1], &r2[first2])
 		first1++
@@ -499,8 +167,15 @@ func SwapRanges[T any](r1, r2 []T, first1, last1, first2 int) int {
 	return first2
 }
 
+// onSwap, if not nil, is called by IterSwap. The tests set it to count the
+// swaps an algorithm makes, which Go cannot otherwise observe.
+var onSwap func()
+
 // Swaps the values of the elements the given iterators are pointing to.
 func IterSwap[T any](a, b *T) {
+	if onSwap != nil {
+		onSwap()
+	}
 	*a, *b = *b, *a
 }
 
@@ -508,6 +183,11 @@ func IterSwap[T any](a, b *T) {
 // keeping the original elements order and beginning at r2[d_first]. The unary
 // operation unary_op is applied to the range defined by r1[first1, last1).
 func Transform[T1, T2 any](r1 []T1, r2 []T2, first1, last1, d_first int, unary_op func(T1) T2) int {
+	if debug {
+		checkRange("Transform", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("Transform", "destination r2", len(r2), d_first, last1-first1)
+	}
+
 	for first1 != last1 {
 		r2[d_first] = unary_op(r1[first1])
 		first1++
@@ -521,6 +201,12 @@ func Transform[T1, T2 any](r1 []T1, r2 []T2, first1, last1, d_first int, unary_o
 // operation binary_op is applied to pairs of elements from two ranges: one
 // defined by [first1, last1) and the other beginning at first2.
 func Transform2[T1, T2, T3 any](r1 []T1, r2 []T2, r3 []T3, first1, last1, first2, d_first int, binary_op func(T1, T2) T3) int {
+	if debug {
+		checkRange("Transform2", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("Transform2", "r2", len(r2), first2, last1-first1)
+		checkRoom("Transform2", "destination r3", len(r3), d_first, last1-first1)
+	}
+
 	for first1 != last1 {
 		r3[d_first] = binary_op(r1[first1], r2[first2])
 		first1++
@@ -530,72 +216,38 @@ func Transform2[T1, T2, T3 any](r1 []T1, r2 []T2, r3 []T3, first1, last1, first2
 	return d_first
 }
 
-// Replaces all elements satisfying specific criteria with new_value in the
-// range r[first, last). Replaces all elements that are equal to old_value
-// (using operator==).
-func Replace[T comparable](r []T, first, last int, old_value, new_value T) {
-	for ; first != last; first++ {
-		if r[first] == old_value {
-			r[first] = new_value
-		}
-	}
-}
-
-// Replaces all elements satisfying specific criteria with new_value in the
-// range r[first, last). Replaces all elements for which predicate p returns
-// true.
-func ReplaceIf[T any](r []T, first, last int, p func(T) bool, new_value T) {
-	for ; first != last; first++ {
-		if p(r[first]) {
-			r[first] = new_value
-		}
-	}
-}
-
-// Copies the elements from the range r1[first, last) to another range beginning
-// at r2[d_first], while replacing all elements satisfying specific criteria
-// with new_value. If the source and destination ranges overlap, the behavior is
-// undefined. Replaces all elements that are equal to old_value
-// (using operator==).
-func ReplaceCopy[T comparable](r1, r2 []T, first, last, d_first int, old_value, new_value T) int {
-	for ; first != last; first++ {
-		r2[d_first] = r1[first]
-		if r2[d_first] == old_value {
-			r2[d_first] = new_value
-		}
-		d_first++
+// Assigns the given value to the elements in the range r[first, last).
+func Fill[T any](r []T, first, last int, value T) {
+	if debug {
+		checkRange("Fill", "r[first, last)", len(r), first, last)
 	}
-	return d_first
-}
 
-// Copies the elements from the range r1[first, last) to another range beginning
-// at r2[d_first], while replacing all elements satisfying specific criteria
-// with new_value. If the source and destination ranges overlap, the behavior is
-// undefined. Replaces all elements for which predicate p returns true.
-func ReplaceCopyIf[T any](r1, r2 []T, first, last, d_first int, p func(T) bool, new_value T) int {
-	for ; first != last; first++ {
-		r2[d_first] = r1[first]
-		if p(r2[d_first]) {
-			r2[d_first] = new_value
+	if last-first < 32 {
+		for ; first != last; first++ {
+			r[first] = value
 		}
-		d_first++
+		return
 	}
-	return d_first
-}
 
-// Assigns the given value to the elements in the range r[first, last).
-func Fill[T any](r []T, first, last int, value T) {
-	for ; first != last; first++ {
-		r[first] = value
+	// Doubling copies let the runtime's memmove do the work.
+	r[first] = value
+	for filled := first + 1; filled != last; {
+		filled += copy(r[filled:last:len(r)], r[first:filled])
 	}
 }
 
 // Assigns the given value to the first count elements in the range beginning at
 // first if count > 0. Does nothing otherwise.
 func FillN[T any](r []T, first, count int, value T) int {
-	for i := 0; i < count; i++ {
-		r[first] = value
-		first++
+	if debug {
+		if count > 0 {
+			checkRoom("FillN", "r", len(r), first, count)
+		}
+	}
+
+	if count > 0 {
+		Fill(r, first, first+count, value)
+		first += count
 	}
 	return first
 }
@@ -603,6 +255,10 @@ func FillN[T any](r []T, first, count int, value T) int {
 // Assigns each element in range r[first, last) a value generated by the given
 // function object g.
 func Generate[T any](r []T, first, last int, g func() T) {
+	if debug {
+		checkRange("Generate", "r[first, last)", len(r), first, last)
+	}
+
 	for ; first != last; first++ {
 		r[first] = g()
 	}
@@ -612,6 +268,12 @@ func Generate[T any](r []T, first, last int, g func() T) {
 // elements in the range beginning at first, if count > 0. Does nothing
 // otherwise.
 func GenerateN[T any](r []T, first, count int, g func() T) int {
+	if debug {
+		if count > 0 {
+			checkRoom("GenerateN", "r", len(r), first, count)
+		}
+	}
+
 	for i := 0; i < count; i++ {
 		r[first] = g()
 		first++
@@ -620,159 +282,14 @@ func GenerateN[T any](r []T, first, count int, g func() T) int {
 	return first
 }
 
-// Removes all elements satisfying specific criteria from the range [first,
-// last) and returns a past-the-end iterator for the new end of the range.
-// Removes all elements that are equal to value (using operator==).
-func Remove[T comparable](r []T, first, last int, value T) int {
-	first = Find(r, first, last, value)
-	if first != last {
-		for i := first + 1; i != last; i++ {
-			if r[i] == value {
-				r[first] = r[i]
-				first++
-			}
-		}
-	}
-	return first
-}
-
-// Removes all elements satisfying specific criteria from the range [first,
-// last) and returns a past-the-end iterator for the new end of the range.
-// Removes all elements for which predicate p returns true.
-func RemoveIf[T any](r []T, first, last int, p func(T) bool) int {
-	first = FindIf(r, first, last, p)
-	if first != last {
-		for i := first + 1; i != last; i++ {
-			if !p(r[i]) {
-				r[first] = r[i]
-				first++
-			}
-		}
-	}
-	return first
-}
-
-// Copies elements from the range r1[first, last), to another range beginning at
-// r2[d_first], omitting the elements which satisfy specific criteria. Ignores
-// all elements that are equal to value.
-func RemoveCopy[T comparable](r1, r2 []T, first, last, d_first int, value T) int {
-	for ; first != last; first++ {
-		if r1[first] != value {
-			r2[d_first] = r1[first]
-			d_first++
-		}
-	}
-	return d_first
-}
-
-// Copies elements from the range r1[first, last), to another range beginning at
-// r2[d_first], omitting the elements which satisfy specific criteria. Ignores
-// all elements for which predicate p returns true.
-func RemoveCopyIf[T any](r1, r2 []T, first, last, d_first int, p func(T) bool) int {
-	for ; first != last; first++ {
-		if !p(r1[first]) {
-			r2[d_first] = r1[first]
-			d_first++
-		}
-	}
-	return d_first
-}
-
-// Eliminates all except the first element from every consecutive group of
-// equivalent elements from the range r[first, last) and returns a past-the-end
-// iterator for the new logical end of the range. Removing is done by shifting
-// the elements in the range in such a way that elements to be erased are
-// overwritten. Elements are compared using operator==. The behavior is
-// undefined if it is not an equivalence relation.
-func Unique[T comparable](r []T, first, last int) int {
-	if first == last {
-		return last
-	}
-
-	result := first
-	for first++; first != last; first++ {
-		if r[result] != r[first] {
-			result++
-			// if result != first {
-			r[result] = r[first]
-			// }
-		}
-	}
-	return result + 1
-}
-
-// Eliminates all except the first element from every consecutive group of
-// equivalent elements from the range r[first, last) and returns a past-the-end
-// iterator for the new logical end of the range. Removing is done by shifting
-// the elements in the range in such a way that elements to be erased are
-// overwritten. Elements are compared using the given binary predicate p. The
-// behavior is undefined if it is not an equivalence relation.
-func UniqueFunc[T any](r []T, first, last int, p func(T, T) bool) int {
-	if first == last {
-		return last
-	}
-
-	result := first
-	for first++; first != last; first++ {
-		if !p(r[result], r[first]) {
-			result++
-			// if result != first {
-			r[result] = r[first]
-			// }
-		}
-	}
-
-	return result + 1
-}
-
-// Copies the elements from the range r[first, last), to another range beginning
-// at d_first in such a way that there are no consecutive equal elements. Only
-// the first element of each group of equal elements is copied. Elements are
-// compared using operator==. The behavior is undefined if it is not an
-// equivalence relation.
-func UniqueCopy[T comparable](r1, r2 []T, first, last, d_first int) int {
-	if first == last {
-		return d_first
-	}
-
-	r2[d_first] = r1[first]
-
-	for first++; first != last; first++ {
-		if !(r1[first] == r2[d_first]) {
-			d_first++
-			r2[d_first] = r1[first]
-		}
-	}
-
-	return d_first + 1
-}
-
-// Copies the elements from the range r[first, last), to another range beginning
-// at d_first in such a way that there are no consecutive equal elements. Only
-// the first element of each group of equal elements is copied. Elements are
-// compared using the given binary predicate p. The behavior is undefined if it
-// is not an equivalence relation.
-func UniqueCopyFunc[T any](r1, r2 []T, first, last, d_first int, p func(T, T) bool) int {
-	if first == last {
-		return d_first
-	}
-
-	r2[d_first] = r1[first]
-
-	for first++; first != last; first++ {
-		if !p(r1[first], r2[d_first]) {
-			d_first++
-			r2[d_first] = r1[first]
-		}
-	}
-
-	return d_first + 1
-}
-
 // Reverses the order of the elements in the range r[first, last). Behaves as if
 // applying IterSwap to every pair of iterators first + i and (last - i) - 1 for
 // each integer i in [​0​, Distance(first, last) / 2).
 func Reverse[T any](r []T, first, last int) {
+	if debug {
+		checkRange("Reverse", "r[first, last)", len(r), first, last)
+	}
+
 	for last--; first < last; {
 		IterSwap(&r[first], &r[last])
 		first++
@@ -787,6 +304,12 @@ func Reverse[T any](r []T, first, last int) {
 // i) = *(first + i) once for each integer i in [​0​, N). If [first, last) and
 // the destination range overlap, the behavior is undefined.
 func ReverseCopy[T any](r1, r2 []T, first, last, d_first int) int {
+	if debug {
+		checkRange("ReverseCopy", "r1[first, last)", len(r1), first, last)
+		checkRoom("ReverseCopy", "destination r2", len(r2), d_first, last-first)
+		checkNoOverlap("ReverseCopy", r1[first:last], r2[d_first:d_first+last-first])
+	}
+
 	for ; first != last; d_first++ {
 		last--
 		r2[d_first] = r1[last]
@@ -800,6 +323,11 @@ func ReverseCopy[T any](r1, r2 []T, first, last, d_first int) int {
 // orders of the elements in both ranges are preserved. If r[first, middle) or
 // r[middle, last) is not a valid range, the behavior is undefined.
 func Rotate[T any](r []T, first, middle, last int) int {
+	if debug {
+		checkRange("Rotate", "r[first, last)", len(r), first, last)
+		checkMiddle("Rotate", "middle", first, middle, last)
+	}
+
 	if first == middle {
 		return last
 	}
@@ -829,6 +357,13 @@ func Rotate[T any](r []T, first, middle, last int) int {
 // element. The behavior is undefined if either [first, n_first) or [n_first,
 // last) is not a valid range, or the source and destination ranges overlap.
 func RotateCopy[T any](r1, r2 []T, first, n_first, last, d_first int) int {
+	if debug {
+		checkRange("RotateCopy", "r1[first, last)", len(r1), first, last)
+		checkMiddle("RotateCopy", "n_first", first, n_first, last)
+		checkRoom("RotateCopy", "destination r2", len(r2), d_first, last-first)
+		checkNoOverlap("RotateCopy", r1[first:last], r2[d_first:d_first+last-first])
+	}
+
 	return Copy(r1, r2, first, n_first, Copy(r1, r2, n_first, last, d_first))
 }
 
@@ -836,8 +371,15 @@ func RotateCopy[T any](r1, r2 []T, first, n_first, last, d_first int) int {
 // n >= last - first, there are no effects. If n < 0, the behavior is
 // undefined. Otherwise, for every integer i in [​0​, last - first - n), moves
 // the element originally at position first + n + i to position first + i.
-// The moves are performed in increasing order of i starting from ​0​.
+// The moves are performed in increasing order of i starting from ​0​. Returns
+// the end of the resulting range: last - n, or last if n == 0, or first if
+// there are no effects otherwise.
 func ShiftLeft[T any](r []T, first, last, n int) int {
+	if debug {
+		checkRange("ShiftLeft", "r[first, last)", len(r), first, last)
+		checkNonNegative("ShiftLeft", "n", n)
+	}
+
 	if n == 0 {
 		return last
 	}
@@ -852,19 +394,224 @@ func ShiftLeft[T any](r []T, first, last, n int) int {
 // Shifts the elements towards the end of the range. If n == 0 || n >= last -
 // first, there are no effects. If n < 0, the behavior is undefined. Otherwise,
 // for every integer i in [​0​, last - first - n), moves the element originally
-// at position first + i to position first + n + i.
+// at position first + i to position first + n + i. Returns the beginning of
+// the resulting range: first + n, or first if n == 0, or last if there are no
+// effects otherwise.
 func ShiftRight[T any](r []T, first, last, n int) int {
+	if debug {
+		checkRange("ShiftRight", "r[first, last)", len(r), first, last)
+		checkNonNegative("ShiftRight", "n", n)
+	}
+
 	if n == 0 {
-		return last
+		return first
 	}
 
 	if n >= last-first {
-		return first
+		return last
+	}
+
+	copy(r[first+n:last:len(r)], r[first:last-n])
+
+	return first + n
+}
+
+// Inserts the element at the position last - 1 into the max heap defined by
+// r[first, last - 1). Elements are compared using operator<.
+func PushHeap[T cmp.Ordered](r []T, first, last int) {
+	if debug {
+		checkRange("PushHeap", "r[first, last)", len(r), first, last)
+		checkNonEmpty("PushHeap", "r[first, last)", first, last)
+		checkHeap("PushHeap", "r[first, last - 1)", r, first, last-1, cmp.Less[T])
+	}
+
+	PushHeapFunc(r, first, last, cmp.Less[T])
+}
+
+// Inserts the element at the position last - 1 into the max heap defined by
+// r[first, last - 1). Elements are compared using the given comparison
+// function comp.
+func PushHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) {
+	if debug {
+		checkRange("PushHeapFunc", "r[first, last)", len(r), first, last)
+		checkNonEmpty("PushHeapFunc", "r[first, last)", first, last)
+		checkHeap("PushHeapFunc", "r[first, last - 1)", r, first, last-1, comp)
+	}
+
+	if last-first > 1 {
+		pushHeap(r, first, last-1-first, 0, r[last-1], comp)
+	}
+}
+
+// pushHeap moves value up from the hole at r[first + hole] towards the top,
+// stopping at r[first + top].
+func pushHeap[T any](r []T, first, hole, top int, value T, comp func(T, T) bool) {
+	for parent := (hole - 1) / 2; hole > top && comp(r[first+parent], value); parent = (hole - 1) / 2 {
+		r[first+hole] = r[first+parent]
+		hole = parent
+	}
+	r[first+hole] = value
+}
+
+// adjustHeap moves the hole at r[first + hole] down to a leaf of the heap of
+// length n, always following the larger child, and then places value by
+// moving it back up.
+func adjustHeap[T any](r []T, first, hole, n int, value T, comp func(T, T) bool) {
+	top := hole
+	child := hole
+	for child < (n-1)/2 {
+		child = 2 * (child + 1)
+		if comp(r[first+child], r[first+child-1]) {
+			child--
+		}
+		r[first+hole] = r[first+child]
+		hole = child
+	}
+	if n%2 == 0 && child == (n-2)/2 {
+		child = 2 * (child + 1)
+		r[first+hole] = r[first+child-1]
+		hole = child - 1
+	}
+	pushHeap(r, first, hole, top, value, comp)
+}
+
+// Swaps the value in the position first and the value in the position last - 1
+// and makes the subrange r[first, last - 1) into a heap. This has the effect of
+// removing the first element from the heap defined by r[first, last). Elements
+// are compared using operator<.
+func PopHeap[T cmp.Ordered](r []T, first, last int) {
+	if debug {
+		checkRange("PopHeap", "r[first, last)", len(r), first, last)
+		checkNonEmpty("PopHeap", "r[first, last)", first, last)
+		checkHeap("PopHeap", "r[first, last)", r, first, last, cmp.Less[T])
+	}
+
+	PopHeapFunc(r, first, last, cmp.Less[T])
+}
+
+// Swaps the value in the position first and the value in the position last - 1
+// and makes the subrange r[first, last - 1) into a heap. This has the effect of
+// removing the first element from the heap defined by r[first, last). Elements
+// are compared using the given comparison function comp.
+func PopHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) {
+	if debug {
+		checkRange("PopHeapFunc", "r[first, last)", len(r), first, last)
+		checkNonEmpty("PopHeapFunc", "r[first, last)", first, last)
+		checkHeap("PopHeapFunc", "r[first, last)", r, first, last, comp)
+	}
+
+	popHeap(r, first, last, comp)
+}
+
+// popHeap implements PopHeapFunc without checking its preconditions, so that
+// SortHeapFunc checks the heap only once.
+func popHeap[T any](r []T, first, last int, comp func(T, T) bool) {
+	if last-first > 1 {
+		value := r[last-1]
+		r[last-1] = r[first]
+		adjustHeap(r, first, 0, last-1-first, value, comp)
+	}
+}
+
+// Constructs a max heap in the range r[first, last). Elements are compared
+// using operator<.
+func MakeHeap[T cmp.Ordered](r []T, first, last int) {
+	if debug {
+		checkRange("MakeHeap", "r[first, last)", len(r), first, last)
+	}
+
+	MakeHeapFunc(r, first, last, cmp.Less[T])
+}
+
+// Constructs a max heap in the range r[first, last). Elements are compared
+// using the given comparison function comp. At most 3 * Distance(first, last)
+// comparisons are made.
+func MakeHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) {
+	if debug {
+		checkRange("MakeHeapFunc", "r[first, last)", len(r), first, last)
+	}
+
+	n := last - first
+	if n < 2 {
+		return
+	}
+	for parent := (n - 2) / 2; ; parent-- {
+		adjustHeap(r, first, parent, n, r[first+parent], comp)
+		if parent == 0 {
+			return
+		}
+	}
+}
+
+// Converts the max heap r[first, last) into a sorted range in ascending order.
+// The resulting range no longer has the heap property. Elements are compared
+// using operator<.
+func SortHeap[T cmp.Ordered](r []T, first, last int) {
+	if debug {
+		checkRange("SortHeap", "r[first, last)", len(r), first, last)
+		checkHeap("SortHeap", "r[first, last)", r, first, last, cmp.Less[T])
+	}
+
+	SortHeapFunc(r, first, last, cmp.Less[T])
+}
+
+// Converts the max heap r[first, last) into a sorted range. The resulting range
+// no longer has the heap property. Elements are compared using the given
+// comparison function comp.
+func SortHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) {
+	if debug {
+		checkRange("SortHeapFunc", "r[first, last)", len(r), first, last)
+		checkHeap("SortHeapFunc", "r[first, last)", r, first, last, comp)
+	}
+
+	for ; last-first > 1; last-- {
+		popHeap(r, first, last, comp)
+	}
+}
+
+// Examines the range r[first, last) and finds the largest range beginning at
+// first which is a max heap. Elements are compared using operator<.
+func IsHeapUntil[T cmp.Ordered](r []T, first, last int) int {
+	if debug {
+		checkRange("IsHeapUntil", "r[first, last)", len(r), first, last)
 	}
 
-	for i := last - 1; i >= first+n; i-- {
-		r[i] = r[i-n]
+	return IsHeapUntilFunc(r, first, last, cmp.Less[T])
+}
+
+// Examines the range r[first, last) and finds the largest range beginning at
+// first which is a max heap. Elements are compared using the given comparison
+// function comp.
+func IsHeapUntilFunc[T any](r []T, first, last int, comp func(T, T) bool) int {
+	if debug {
+		checkRange("IsHeapUntilFunc", "r[first, last)", len(r), first, last)
 	}
 
+	n := last - first
+	for child := 1; child < n; child++ {
+		if comp(r[first+(child-1)/2], r[first+child]) {
+			return first + child
+		}
+	}
 	return last
 }
+
+// Checks whether r[first, last) is a max heap. Elements are compared using
+// operator<.
+func IsHeap[T cmp.Ordered](r []T, first, last int) bool {
+	if debug {
+		checkRange("IsHeap", "r[first, last)", len(r), first, last)
+	}
+
+	return IsHeapUntil(r, first, last) == last
+}
+
+// Checks whether r[first, last) is a max heap. Elements are compared using the
+// given comparison function comp.
+func IsHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) bool {
+	if debug {
+		checkRange("IsHeapFunc", "r[first, last)", len(r), first, last)
+	}
+
+	return IsHeapUntilFunc(r, first, last, comp) == last
+}
@@ -0,0 +1,965 @@
+package algorithm
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"gocpp/instrument"
+	"gocpp/utility"
+)
+
+// The tests in this file check each algorithm against its specification in
+// the C++ standard: the returned iterator, the state of the ranges afterwards,
+// and the number of applications of the predicate or comparator allowed by
+// the "Complexity" clause.
+
+// atMost reports whether n <= bound. The precondition checks of debug builds
+// call the comparator themselves, so the bound is only checked otherwise.
+func atMost(n, bound int) bool {
+	return debug || n <= bound
+}
+
+// log2 returns floor(log2(n)) + 2, the bound on the comparisons of the
+// binary searches, log2(n) + O(1).
+func log2(n int) int {
+	return instrument.Log2(n) + 2
+}
+
+func TestBeginEnd(t *testing.T) {
+	r := []int{1, 2, 3}
+	if Begin(r) != 0 || End(r) != 3 || End([]int(nil)) != 0 {
+		t.Errorf("Begin, End = %d, %d", Begin(r), End(r))
+	}
+}
+
+func TestAllAnyNoneOf(t *testing.T) {
+	tests := []struct {
+		r                 []int
+		first, last       int
+		all, any, none    bool
+		maxAll, maxAnyNon int
+	}{
+		{nil, 0, 0, true, false, true, 0, 0},
+		{[]int{2, 4, 6}, 0, 3, true, true, false, 3, 1},
+		{[]int{1, 3, 5}, 0, 3, false, false, true, 1, 3},
+		{[]int{2, 3, 4}, 0, 3, false, true, false, 2, 1},
+		{[]int{1, 2, 4, 1}, 1, 3, true, true, false, 2, 1},
+	}
+	for _, tt := range tests {
+		calls := instrument.New()
+		if got := AllOf(tt.r, tt.first, tt.last, instrument.Pred(calls, even)); got != tt.all || calls.Total() > tt.maxAll {
+			t.Errorf("AllOf(%v, %d, %d) = %v with %d calls, want %v", tt.r, tt.first, tt.last, got, calls.Total(), tt.all)
+		}
+		calls.Reset()
+		if got := AnyOf(tt.r, tt.first, tt.last, instrument.Pred(calls, even)); got != tt.any || calls.Total() > tt.last-tt.first {
+			t.Errorf("AnyOf(%v, %d, %d) = %v with %d calls, want %v", tt.r, tt.first, tt.last, got, calls.Total(), tt.any)
+		}
+		calls.Reset()
+		if got := NoneOf(tt.r, tt.first, tt.last, instrument.Pred(calls, even)); got != tt.none || calls.Total() > tt.last-tt.first {
+			t.Errorf("NoneOf(%v, %d, %d) = %v with %d calls, want %v", tt.r, tt.first, tt.last, got, calls.Total(), tt.none)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		r           []int
+		first, last int
+		value       int
+		want        int
+	}{
+		{nil, 0, 0, 1, 0},
+		{[]int{1}, 0, 1, 1, 0},
+		{[]int{1}, 0, 1, 2, 1},
+		{[]int{1, 2, 3, 2}, 0, 4, 2, 1},
+		{[]int{1, 2, 3, 2}, 2, 4, 2, 3},
+		{[]int{1, 2, 3, 2}, 2, 3, 2, 3},
+		{[]int{2, 2, 2}, 1, 3, 2, 1},
+	}
+	for _, tt := range tests {
+		if got := Find(tt.r, tt.first, tt.last, tt.value); got != tt.want {
+			t.Errorf("Find(%v, %d, %d, %d) = %d, want %d", tt.r, tt.first, tt.last, tt.value, got, tt.want)
+		}
+		if got := FindOpt(tt.r, tt.first, tt.last, tt.value); got.ValueOr(tt.last) != tt.want || got.HasValue() != (tt.want != tt.last) {
+			t.Errorf("FindOpt(%v, %d, %d, %d) = %v, want %d", tt.r, tt.first, tt.last, tt.value, got, tt.want)
+		}
+
+		// At most last - first applications of the predicate.
+		calls := instrument.New()
+		eq := func(x int) bool { return x == tt.value }
+		if got := FindIf(tt.r, tt.first, tt.last, instrument.Pred(calls, eq)); got != tt.want || calls.Total() > tt.last-tt.first {
+			t.Errorf("FindIf(%v, %d, %d) = %d with %d calls, want %d", tt.r, tt.first, tt.last, got, calls.Total(), tt.want)
+		}
+		if got := FindIfOpt(tt.r, tt.first, tt.last, eq); got.ValueOr(tt.last) != tt.want {
+			t.Errorf("FindIfOpt(%v, %d, %d) = %v, want %d", tt.r, tt.first, tt.last, got, tt.want)
+		}
+		calls.Reset()
+		ne := func(x int) bool { return x != tt.value }
+		if got := FindIfNot(tt.r, tt.first, tt.last, instrument.Pred(calls, ne)); got != tt.want || calls.Total() > tt.last-tt.first {
+			t.Errorf("FindIfNot(%v, %d, %d) = %d with %d calls, want %d", tt.r, tt.first, tt.last, got, calls.Total(), tt.want)
+		}
+		if got := FindIfNotOpt(tt.r, tt.first, tt.last, ne); got.ValueOr(tt.last) != tt.want {
+			t.Errorf("FindIfNotOpt(%v, %d, %d) = %v, want %d", tt.r, tt.first, tt.last, got, tt.want)
+		}
+	}
+}
+
+func TestFindBytesAndRunes(t *testing.T) {
+	b := []byte("the quick brown fox")
+	if got := Find(b, 0, len(b), 'q'); got != 4 {
+		t.Errorf("Find(bytes, 'q') = %d, want 4", got)
+	}
+	if got := Find(b, 5, len(b), 'q'); got != len(b) {
+		t.Errorf("Find(bytes[5:], 'q') = %d, want %d", got, len(b))
+	}
+	if got := Count(b, 0, len(b), 'o'); got != 2 {
+		t.Errorf("Count(bytes, 'o') = %d, want 2", got)
+	}
+	if got := Search(b, []byte("brown"), 0, len(b), 0, 5); got != 10 {
+		t.Errorf("Search(bytes, brown) = %d, want 10", got)
+	}
+
+	// Runes whose bytes occur at unaligned offsets must not match.
+	r := make([]rune, 100)
+	for i := range r {
+		r[i] = 0x01000000
+	}
+	r[70], r[71] = 0x00000001, 0x00000002
+	if got := Find(r, 0, len(r), 0x00000100); got != len(r) {
+		t.Errorf("Find(runes, 0x100) = %d, want %d", got, len(r))
+	}
+	if got := Find(r, 0, len(r), 2); got != 71 {
+		t.Errorf("Find(runes, 2) = %d, want 71", got)
+	}
+	if got := Search(r, []rune{1, 2}, 0, len(r), 0, 2); got != 70 {
+		t.Errorf("Search(runes, {1, 2}) = %d, want 70", got)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		r            []int
+		first, last  int
+		value        int
+		lower, upper int
+	}{
+		{nil, 0, 0, 1, 0, 0},
+		{[]int{5}, 0, 1, 4, 0, 0},
+		{[]int{5}, 0, 1, 5, 0, 1},
+		{[]int{5}, 0, 1, 6, 1, 1},
+		{[]int{1, 1, 2, 3}, 0, 4, 1, 0, 2},
+		{[]int{1, 2, 2, 2, 3}, 0, 5, 2, 1, 4},
+		{[]int{1, 2, 2, 2, 3}, 0, 5, 3, 4, 5},
+		{[]int{1, 2, 2, 2, 3}, 0, 5, 4, 5, 5},
+		{[]int{1, 2, 2, 2, 3}, 0, 5, 0, 0, 0},
+		{[]int{9, 1, 2, 3, 0}, 1, 4, 1, 1, 2},
+		{[]int{9, 1, 2, 3, 0}, 1, 4, 3, 3, 4},
+	}
+	for _, tt := range tests {
+		if got := LowerBound(tt.r, tt.first, tt.last, tt.value); got != tt.lower {
+			t.Errorf("LowerBound(%v, %d, %d, %d) = %d, want %d", tt.r, tt.first, tt.last, tt.value, got, tt.lower)
+		}
+		if got := UpperBound(tt.r, tt.first, tt.last, tt.value); got != tt.upper {
+			t.Errorf("UpperBound(%v, %d, %d, %d) = %d, want %d", tt.r, tt.first, tt.last, tt.value, got, tt.upper)
+		}
+
+		// At most log2(last - first) + O(1) comparisons.
+		calls := instrument.New()
+		if got := LowerBoundFunc(tt.r, tt.first, tt.last, tt.value, instrument.Compare(calls, cmp.Less[int])); got != tt.lower || !atMost(calls.Total(), log2(tt.last-tt.first)) {
+			t.Errorf("LowerBoundFunc(%v, %d, %d, %d) = %d with %d calls, want %d", tt.r, tt.first, tt.last, tt.value, got, calls.Total(), tt.lower)
+		}
+		calls.Reset()
+		if got := UpperBoundFunc(tt.r, tt.first, tt.last, tt.value, instrument.Compare(calls, cmp.Less[int])); got != tt.upper || !atMost(calls.Total(), log2(tt.last-tt.first)) {
+			t.Errorf("UpperBoundFunc(%v, %d, %d, %d) = %d with %d calls, want %d", tt.r, tt.first, tt.last, tt.value, got, calls.Total(), tt.upper)
+		}
+	}
+}
+
+func TestBoundsExhaustive(t *testing.T) {
+	// Every sorted sequence of length up to 6 over {0, 1, 2}, searched for
+	// every value in [-1, 3].
+	var gen func(r []int, lo int)
+	gen = func(r []int, lo int) {
+		for v := -1; v <= 3; v++ {
+			lower := slices.IndexFunc(r, func(x int) bool { return x >= v })
+			if lower < 0 {
+				lower = len(r)
+			}
+			upper := slices.IndexFunc(r, func(x int) bool { return x > v })
+			if upper < 0 {
+				upper = len(r)
+			}
+			if got := LowerBound(r, 0, len(r), v); got != lower {
+				t.Fatalf("LowerBound(%v, %d) = %d, want %d", r, v, got, lower)
+			}
+			if got := UpperBound(r, 0, len(r), v); got != upper {
+				t.Fatalf("UpperBound(%v, %d) = %d, want %d", r, v, got, upper)
+			}
+		}
+		if len(r) == 6 {
+			return
+		}
+		for x := lo; x <= 2; x++ {
+			gen(append(r, x), x)
+		}
+	}
+	gen(nil, 0)
+}
+
+func TestAdjacentFind(t *testing.T) {
+	tests := []struct {
+		r           []int
+		first, last int
+		want        int
+	}{
+		{nil, 0, 0, 0},
+		{[]int{1}, 0, 1, 1},
+		{[]int{1, 1}, 0, 2, 0},
+		{[]int{1, 2, 3, 3, 4, 4}, 0, 6, 2},
+		{[]int{1, 2, 3, 3, 4, 4}, 3, 6, 4},
+		{[]int{1, 2, 3, 4}, 0, 4, 4},
+	}
+	for _, tt := range tests {
+		if got := AdjacentFind(tt.r, tt.first, tt.last); got != tt.want {
+			t.Errorf("AdjacentFind(%v, %d, %d) = %d, want %d", tt.r, tt.first, tt.last, got, tt.want)
+		}
+
+		// Exactly min((result - first) + 1, (last - first) - 1) applications.
+		calls := instrument.New()
+		got := AdjacentFindFunc(tt.r, tt.first, tt.last, instrument.BinaryPred(calls, equal))
+		if want := max(0, min(tt.want-tt.first+1, tt.last-tt.first-1)); got != tt.want || calls.Total() != want {
+			t.Errorf("AdjacentFindFunc(%v, %d, %d) = %d with %d calls, want %d with %d", tt.r, tt.first, tt.last, got, calls.Total(), tt.want, want)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		r           []int
+		first, last int
+		value       int
+		want, evens int
+	}{
+		{nil, 0, 0, 1, 0, 0},
+		{[]int{1, 2, 1, 4, 1}, 0, 5, 1, 3, 2},
+		{[]int{1, 2, 1, 4, 1}, 1, 4, 1, 1, 2},
+		{[]int{1, 2, 1, 4, 1}, 0, 5, 3, 0, 2},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.r, tt.first, tt.last, tt.value); got != tt.want {
+			t.Errorf("Count(%v, %d, %d, %d) = %d, want %d", tt.r, tt.first, tt.last, tt.value, got, tt.want)
+		}
+
+		// Exactly last - first applications.
+		calls := instrument.New()
+		if got := CountIf(tt.r, tt.first, tt.last, instrument.Pred(calls, even)); got != tt.evens || calls.Total() != tt.last-tt.first {
+			t.Errorf("CountIf(%v, %d, %d) = %d with %d calls, want %d", tt.r, tt.first, tt.last, got, calls.Total(), tt.evens)
+		}
+	}
+}
+
+func TestMismatch(t *testing.T) {
+	tests := []struct {
+		r1, r2         []int
+		first1, last1  int
+		first2, last2  int
+		want1, want2   int
+		equal1, equal2 bool
+	}{
+		{nil, nil, 0, 0, 0, 0, 0, 0, true, true},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, 0, 3, 0, 3, 3, 3, true, true},
+		{[]int{1, 2, 3}, []int{1, 2, 4}, 0, 3, 0, 3, 2, 2, false, false},
+		{[]int{1, 2, 3}, []int{9, 2, 3}, 0, 3, 0, 3, 0, 0, false, false},
+		// The ranges are at different offsets.
+		{[]int{0, 0, 5, 6, 7}, []int{5, 6, 8}, 2, 5, 0, 3, 4, 2, false, false},
+		// The second range is longer; only Mismatch2 and Equal2 see it.
+		{[]int{1, 2}, []int{1, 2, 3}, 0, 2, 0, 3, 2, 2, true, false},
+		// The second range is shorter.
+		{[]int{1, 2, 3}, []int{1, 2, 3}, 0, 3, 0, 2, 2, 2, true, false},
+	}
+	for _, tt := range tests {
+		var want utility.Pair[int, int]
+		if tt.last2-tt.first2 >= tt.last1-tt.first1 {
+			want = utility.MakePair(tt.want1, tt.want2)
+			if got := Mismatch(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2); got != want {
+				t.Errorf("Mismatch(%v, %v) = %v, want %v", tt.r1, tt.r2, got, want)
+			}
+			calls := instrument.New()
+			if got := MismatchFunc(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2, instrument.BinaryPred(calls, equal)); got != want || calls.Total() > tt.last1-tt.first1 {
+				t.Errorf("MismatchFunc(%v, %v) = %v with %d calls, want %v", tt.r1, tt.r2, got, calls.Total(), want)
+			}
+			if got := Equal(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2); got != tt.equal1 {
+				t.Errorf("Equal(%v, %v) = %v, want %v", tt.r1, tt.r2, got, tt.equal1)
+			}
+			calls.Reset()
+			if got := EqualFunc(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2, instrument.BinaryPred(calls, equal)); got != tt.equal1 || calls.Total() > tt.last1-tt.first1 {
+				t.Errorf("EqualFunc(%v, %v) = %v with %d calls, want %v", tt.r1, tt.r2, got, calls.Total(), tt.equal1)
+			}
+		}
+
+		want = utility.MakePair(tt.want1, tt.want2)
+		if got := Mismatch2(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2, tt.last2); got != want {
+			t.Errorf("Mismatch2(%v, %v) = %v, want %v", tt.r1, tt.r2, got, want)
+		}
+		limit := min(tt.last1-tt.first1, tt.last2-tt.first2)
+		calls := instrument.New()
+		if got := MismatchFunc2(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2, tt.last2, instrument.BinaryPred(calls, equal)); got != want || calls.Total() > limit {
+			t.Errorf("MismatchFunc2(%v, %v) = %v with %d calls, want %v", tt.r1, tt.r2, got, calls.Total(), want)
+		}
+		if got := Equal2(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2, tt.last2); got != tt.equal2 {
+			t.Errorf("Equal2(%v, %v) = %v, want %v", tt.r1, tt.r2, got, tt.equal2)
+		}
+
+		// No applications at all if the lengths differ.
+		if tt.last1-tt.first1 != tt.last2-tt.first2 {
+			limit = 0
+		}
+		calls.Reset()
+		if got := EqualFunc2(tt.r1, tt.r2, tt.first1, tt.last1, tt.first2, tt.last2, instrument.BinaryPred(calls, equal)); got != tt.equal2 || calls.Total() > limit {
+			t.Errorf("EqualFunc2(%v, %v) = %v with %d calls, want %v", tt.r1, tt.r2, got, calls.Total(), tt.equal2)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		r1, r2          []int
+		first, last     int
+		s_first, s_last int
+		want            int
+	}{
+		{nil, nil, 0, 0, 0, 0, 0},
+		{[]int{1, 2, 3}, nil, 1, 3, 0, 0, 1},
+		{[]int{1, 2, 3}, []int{2, 3}, 0, 3, 0, 2, 1},
+		{[]int{1, 2, 1, 2, 3}, []int{1, 2, 3}, 0, 5, 0, 3, 2},
+		{[]int{1, 2, 1, 2, 3}, []int{1, 2, 3}, 0, 4, 0, 3, 4},
+		{[]int{1, 2}, []int{1, 2, 3}, 0, 2, 0, 3, 2},
+		{[]int{1, 2, 3}, []int{0, 3, 0}, 0, 3, 1, 2, 2},
+	}
+	for _, tt := range tests {
+		if got := Search(tt.r1, tt.r2, tt.first, tt.last, tt.s_first, tt.s_last); got != tt.want {
+			t.Errorf("Search(%v, %v) = %d, want %d", tt.r1, tt.r2, got, tt.want)
+		}
+
+		// At most S * N applications.
+		calls := instrument.New()
+		got := SearchFunc(tt.r1, tt.r2, tt.first, tt.last, tt.s_first, tt.s_last, instrument.BinaryPred(calls, equal))
+		if got != tt.want || calls.Total() > (tt.last-tt.first)*(tt.s_last-tt.s_first) {
+			t.Errorf("SearchFunc(%v, %v) = %d with %d calls, want %d", tt.r1, tt.r2, got, calls.Total(), tt.want)
+		}
+	}
+}
+
+func TestSearchN(t *testing.T) {
+	tests := []struct {
+		r           []int
+		first, last int
+		count       int
+		value       int
+		want        int
+	}{
+		{nil, 0, 0, 1, 1, 0},
+		{[]int{1, 2, 3}, 0, 3, 0, 9, 0},
+		{[]int{1, 2, 3}, 1, 3, -1, 9, 1},
+		{[]int{1, 2, 2, 3, 2, 2, 2}, 0, 7, 3, 2, 4},
+		{[]int{1, 2, 2, 3, 2, 2, 2}, 0, 7, 2, 2, 1},
+		{[]int{1, 2, 2, 3, 2, 2, 2}, 0, 6, 3, 2, 6},
+		{[]int{2}, 0, 1, 1, 2, 0},
+	}
+	for _, tt := range tests {
+		if got := SearchN(tt.r, tt.first, tt.last, tt.count, tt.value); got != tt.want {
+			t.Errorf("SearchN(%v, %d, %d, %d, %d) = %d, want %d", tt.r, tt.first, tt.last, tt.count, tt.value, got, tt.want)
+		}
+
+		// At most last - first applications.
+		calls := instrument.New()
+		if got := SearchNFunc(tt.r, tt.first, tt.last, tt.count, tt.value, instrument.BinaryPred(calls, equal)); got != tt.want || calls.Total() > tt.last-tt.first {
+			t.Errorf("SearchNFunc(%v, %d, %d, %d, %d) = %d with %d calls, want %d", tt.r, tt.first, tt.last, tt.count, tt.value, got, calls.Total(), tt.want)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(r1, r2 []int) int
+		r1   []int
+		r2   []int
+		want int
+		post []int
+	}{
+		{"Copy", func(r1, r2 []int) int { return Copy(r1, r2, 1, 3, 2) }, []int{1, 2, 3}, make([]int, 4), 4, []int{0, 0, 2, 3}},
+		{"CopyEmpty", func(r1, r2 []int) int { return Copy(r1, r2, 1, 1, 2) }, []int{1, 2, 3}, make([]int, 2), 2, []int{0, 0}},
+		{"Move", func(r1, r2 []int) int { return Move(r1, r2, 0, 3, 0) }, []int{1, 2, 3}, make([]int, 3), 3, []int{1, 2, 3}},
+		{"CopyN", func(r1, r2 []int) int { return CopyN(r1, r2, 1, 2, 0) }, []int{1, 2, 3}, make([]int, 3), 2, []int{2, 3, 0}},
+		{"CopyNZero", func(r1, r2 []int) int { return CopyN(r1, r2, 1, 0, 1) }, []int{1, 2, 3}, make([]int, 3), 1, []int{0, 0, 0}},
+		{"CopyNNegative", func(r1, r2 []int) int { return CopyN(r1, r2, 1, -2, 1) }, []int{1, 2, 3}, make([]int, 3), 1, []int{0, 0, 0}},
+		{"CopyBackward", func(r1, r2 []int) int { return CopyBackward(r1, r2, 0, 2, 3) }, []int{1, 2, 3}, make([]int, 3), 1, []int{0, 1, 2}},
+		{"MoveBackward", func(r1, r2 []int) int { return MoveBackward(r1, r2, 0, 3, 3) }, []int{1, 2, 3}, make([]int, 3), 0, []int{1, 2, 3}},
+		{"CopyIf", func(r1, r2 []int) int { return CopyIf(r1, r2, 0, 5, 1, even) }, []int{1, 2, 3, 4, 6}, make([]int, 4), 4, []int{0, 2, 4, 6}},
+	}
+	for _, tt := range tests {
+		if got := tt.f(tt.r1, tt.r2); got != tt.want || !slices.Equal(tt.r2, tt.post) {
+			t.Errorf("%s = %d, %v, want %d, %v", tt.name, got, tt.r2, tt.want, tt.post)
+		}
+	}
+}
+
+func TestCopyOverlapping(t *testing.T) {
+	// Copy allows the destination to start before the source, and
+	// CopyBackward allows it to end after the source.
+	r := []int{1, 2, 3, 4, 5}
+	if got := Copy(r, r, 1, 5, 0); got != 4 || !slices.Equal(r, []int{2, 3, 4, 5, 5}) {
+		t.Errorf("Copy to the left = %d, %v", got, r)
+	}
+	r = []int{1, 2, 3, 4, 5}
+	if got := CopyBackward(r, r, 0, 4, 5); got != 1 || !slices.Equal(r, []int{1, 1, 2, 3, 4}) {
+		t.Errorf("CopyBackward to the right = %d, %v", got, r)
+	}
+
+	// Exactly last - first applications of the predicate.
+	calls := instrument.New()
+	CopyIf(r, make([]int, 5), 1, 4, 0, instrument.Pred(calls, even))
+	if calls.Total() != 3 {
+		t.Errorf("CopyIf made %d calls, want 3", calls.Total())
+	}
+}
+
+func TestSwap(t *testing.T) {
+	a, b := 1, 2
+	Swap(&a, &b)
+	if a != 2 || b != 1 {
+		t.Errorf("Swap = %d, %d", a, b)
+	}
+	IterSwap(&a, &b)
+	if a != 1 || b != 2 {
+		t.Errorf("IterSwap = %d, %d", a, b)
+	}
+
+	r1, r2 := []int{1, 2, 3, 4}, []int{5, 6, 7}
+	if got := SwapRanges(r1, r2, 1, 3, 1); got != 3 || !slices.Equal(r1, []int{1, 6, 7, 4}) || !slices.Equal(r2, []int{5, 2, 3}) {
+		t.Errorf("SwapRanges = %d, %v, %v", got, r1, r2)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	calls := instrument.New()
+	square := instrument.UnaryOp(calls, func(x int) string { return string(rune('a' + x*x)) })
+	r2 := make([]string, 4)
+	if got := Transform([]int{1, 2, 3}, r2, 0, 3, 1, square); got != 4 || !slices.Equal(r2, []string{"", "b", "e", "j"}) || calls.Total() != 3 {
+		t.Errorf("Transform = %d, %v with %v", got, r2, calls)
+	}
+
+	// Transform may write to its input range.
+	r := []int{1, 2, 3}
+	if got := Transform(r, r, 0, 3, 0, func(x int) int { return -x }); got != 3 || !slices.Equal(r, []int{-1, -2, -3}) {
+		t.Errorf("Transform in place = %d, %v", got, r)
+	}
+
+	calls.Reset()
+	add := instrument.BinaryOp(calls, func(x int, y float64) float64 { return float64(x) + y })
+	r3 := make([]float64, 2)
+	if got := Transform2([]int{1, 2, 3}, []float64{0.5, 0.25}, r3, 1, 3, 0, 0, add); got != 2 || !slices.Equal(r3, []float64{2.5, 3.25}) || calls.Total() != 2 {
+		t.Errorf("Transform2 = %d, %v with %v", got, r3, calls)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	r := []int{1, 2, 1, 3, 1}
+	Replace(r, 1, 5, 1, 9)
+	if !slices.Equal(r, []int{1, 2, 9, 3, 9}) {
+		t.Errorf("Replace = %v", r)
+	}
+
+	calls := instrument.New()
+	r = []int{1, 2, 3, 4}
+	ReplaceIf(r, 0, 4, instrument.Pred(calls, even), 0)
+	if !slices.Equal(r, []int{1, 0, 3, 0}) || calls.Total() != 4 {
+		t.Errorf("ReplaceIf = %v with %d calls", r, calls.Total())
+	}
+
+	src := []int{1, 2, 1, 3}
+	d := make([]int, 5)
+	if got := ReplaceCopy(src, d, 0, 4, 1, 1, 7); got != 5 || !slices.Equal(d, []int{0, 7, 2, 7, 3}) || !slices.Equal(src, []int{1, 2, 1, 3}) {
+		t.Errorf("ReplaceCopy = %d, %v", got, d)
+	}
+
+	calls.Reset()
+	d = make([]int, 3)
+	if got := ReplaceCopyIf(src, d, 1, 4, 0, instrument.Pred(calls, even), -1); got != 3 || !slices.Equal(d, []int{-1, 1, 3}) || calls.Total() != 3 {
+		t.Errorf("ReplaceCopyIf = %d, %v with %d calls", got, d, calls.Total())
+	}
+}
+
+func TestFillGenerate(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 31, 32, 33, 100} {
+		r := make([]int, size+2)
+		Fill(r, 1, size+1, 7)
+		for i, x := range r {
+			if want := 7; i == 0 || i == size+1 {
+				want = 0
+				if x != want {
+					t.Fatalf("Fill(%d) = %v", size, r)
+				}
+			} else if x != want {
+				t.Fatalf("Fill(%d) = %v", size, r)
+			}
+		}
+	}
+
+	r := make([]int, 4)
+	if got := FillN(r, 1, 2, 5); got != 3 || !slices.Equal(r, []int{0, 5, 5, 0}) {
+		t.Errorf("FillN = %d, %v", got, r)
+	}
+	if got := FillN(r, 1, -1, 9); got != 1 || !slices.Equal(r, []int{0, 5, 5, 0}) {
+		t.Errorf("FillN(-1) = %d, %v", got, r)
+	}
+
+	// Exactly last - first invocations of g, in order.
+	next := 0
+	g := func() int {
+		next++
+		return next
+	}
+	r = make([]int, 4)
+	Generate(r, 1, 4, g)
+	if !slices.Equal(r, []int{0, 1, 2, 3}) || next != 3 {
+		t.Errorf("Generate = %v with %d calls", r, next)
+	}
+	next = 0
+	if got := GenerateN(r, 0, 2, g); got != 2 || !slices.Equal(r, []int{1, 2, 2, 3}) || next != 2 {
+		t.Errorf("GenerateN = %d, %v with %d calls", got, r, next)
+	}
+	if got := GenerateN(r, 3, 0, g); got != 3 || next != 2 {
+		t.Errorf("GenerateN(0) = %d with %d calls", got, next)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		r           []int
+		first, last int
+		value       int
+		want        int
+		post        []int
+	}{
+		{nil, 0, 0, 1, 0, nil},
+		{[]int{1, 2, 1, 3, 1, 4}, 0, 6, 1, 3, []int{2, 3, 4}},
+		{[]int{1, 2, 1, 3, 1, 4}, 1, 5, 1, 3, []int{2, 3}},
+		{[]int{1, 1}, 0, 2, 1, 0, nil},
+		{[]int{2, 3}, 0, 2, 1, 2, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		r := slices.Clone(tt.r)
+		if got := Remove(r, tt.first, tt.last, tt.value); got != tt.want || !slices.Equal(r[tt.first:got], tt.post) {
+			t.Errorf("Remove(%v, %d, %d, %d) = %d, %v, want %d, %v", tt.r, tt.first, tt.last, tt.value, got, r, tt.want, tt.post)
+		}
+
+		// Exactly last - first applications.
+		calls := instrument.New()
+		r = slices.Clone(tt.r)
+		eq := func(x int) bool { return x == tt.value }
+		if got := RemoveIf(r, tt.first, tt.last, instrument.Pred(calls, eq)); got != tt.want || !slices.Equal(r[tt.first:got], tt.post) || calls.Total() != tt.last-tt.first {
+			t.Errorf("RemoveIf(%v, %d, %d) = %d, %v with %d calls, want %d, %v", tt.r, tt.first, tt.last, got, r, calls.Total(), tt.want, tt.post)
+		}
+
+		d := make([]int, len(tt.r)+1)
+		if got := RemoveCopy(tt.r, d, tt.first, tt.last, 1, tt.value); got != 1+len(tt.post) || !slices.Equal(d[1:got], tt.post) {
+			t.Errorf("RemoveCopy(%v, %d, %d) = %d, %v", tt.r, tt.first, tt.last, got, d)
+		}
+		calls.Reset()
+		d = make([]int, len(tt.r))
+		if got := RemoveCopyIf(tt.r, d, tt.first, tt.last, 0, instrument.Pred(calls, eq)); got != len(tt.post) || !slices.Equal(d[:got], tt.post) || calls.Total() != tt.last-tt.first {
+			t.Errorf("RemoveCopyIf(%v, %d, %d) = %d, %v with %d calls", tt.r, tt.first, tt.last, got, d, calls.Total())
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		r           []int
+		first, last int
+		post        []int
+	}{
+		{nil, 0, 0, nil},
+		{[]int{1}, 0, 1, []int{1}},
+		{[]int{1, 1, 2, 2, 2, 3, 1, 1}, 0, 8, []int{1, 2, 3, 1}},
+		{[]int{1, 1, 2, 2, 2, 3, 1, 1}, 1, 4, []int{1, 2}},
+		{[]int{1, 2, 3}, 0, 3, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		want := tt.first + len(tt.post)
+		applications := max(0, tt.last-tt.first-1)
+
+		r := slices.Clone(tt.r)
+		if got := Unique(r, tt.first, tt.last); got != want || !slices.Equal(r[tt.first:got], tt.post) {
+			t.Errorf("Unique(%v, %d, %d) = %d, %v, want %v", tt.r, tt.first, tt.last, got, r, tt.post)
+		}
+		calls := instrument.New()
+		r = slices.Clone(tt.r)
+		if got := UniqueFunc(r, tt.first, tt.last, instrument.BinaryPred(calls, equal)); got != want || !slices.Equal(r[tt.first:got], tt.post) || calls.Total() != applications {
+			t.Errorf("UniqueFunc(%v, %d, %d) = %d, %v with %d calls, want %v", tt.r, tt.first, tt.last, got, r, calls.Total(), tt.post)
+		}
+
+		d := make([]int, len(tt.r)+1)
+		if got := UniqueCopy(tt.r, d, tt.first, tt.last, 1); got != 1+len(tt.post) || !slices.Equal(d[1:got], tt.post) {
+			t.Errorf("UniqueCopy(%v, %d, %d) = %d, %v, want %v", tt.r, tt.first, tt.last, got, d, tt.post)
+		}
+		calls.Reset()
+		d = make([]int, len(tt.r))
+		if got := UniqueCopyFunc(tt.r, d, tt.first, tt.last, 0, instrument.BinaryPred(calls, equal)); got != len(tt.post) || !slices.Equal(d[:got], tt.post) || calls.Total() != applications {
+			t.Errorf("UniqueCopyFunc(%v, %d, %d) = %d, %v with %d calls, want %v", tt.r, tt.first, tt.last, got, d, calls.Total(), tt.post)
+		}
+	}
+
+	// The predicate compares the last kept element with the current one, so
+	// a non-transitive predicate removes a run relative to its first element.
+	close := func(a, b int) bool { return b-a <= 1 }
+	r := []int{1, 2, 3, 4, 5}
+	if got := UniqueFunc(r, 0, 5, close); !slices.Equal(r[:got], []int{1, 3, 5}) {
+		t.Errorf("UniqueFunc(close) = %v, want [1 3 5]", r[:got])
+	}
+	d := make([]int, 5)
+	if got := UniqueCopyFunc([]int{1, 2, 3, 4, 5}, d, 0, 5, 0, close); !slices.Equal(d[:got], []int{1, 3, 5}) {
+		t.Errorf("UniqueCopyFunc(close) = %v, want [1 3 5]", d[:got])
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, tt := range []struct {
+		r           []int
+		first, last int
+		post        []int
+	}{
+		{nil, 0, 0, nil},
+		{[]int{1}, 0, 1, []int{1}},
+		{[]int{1, 2, 3, 4}, 0, 4, []int{4, 3, 2, 1}},
+		{[]int{1, 2, 3, 4, 5}, 1, 4, []int{1, 4, 3, 2, 5}},
+	} {
+		r := slices.Clone(tt.r)
+		Reverse(r, tt.first, tt.last)
+		if !slices.Equal(r, tt.post) {
+			t.Errorf("Reverse(%v, %d, %d) = %v, want %v", tt.r, tt.first, tt.last, r, tt.post)
+		}
+
+		d := make([]int, tt.last-tt.first+1)
+		if got := ReverseCopy(tt.r, d, tt.first, tt.last, 1); got != len(d) || !slices.Equal(d[1:], tt.post[tt.first:tt.last]) {
+			t.Errorf("ReverseCopy(%v, %d, %d) = %d, %v", tt.r, tt.first, tt.last, got, d)
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for _, tt := range []struct {
+		r                   []int
+		first, middle, last int
+		want                int
+		post                []int
+	}{
+		{nil, 0, 0, 0, 0, nil},
+		{[]int{1, 2, 3}, 0, 0, 3, 3, []int{1, 2, 3}},
+		{[]int{1, 2, 3}, 0, 3, 3, 0, []int{1, 2, 3}},
+		{[]int{1, 2, 3, 4, 5}, 0, 2, 5, 3, []int{3, 4, 5, 1, 2}},
+		{[]int{1, 2, 3, 4, 5}, 0, 3, 5, 2, []int{4, 5, 1, 2, 3}},
+		{[]int{1, 2, 3, 4, 5, 6, 7}, 1, 4, 6, 3, []int{1, 5, 6, 2, 3, 4, 7}},
+	} {
+		r := slices.Clone(tt.r)
+		if got := Rotate(r, tt.first, tt.middle, tt.last); got != tt.want || !slices.Equal(r, tt.post) {
+			t.Errorf("Rotate(%v, %d, %d, %d) = %d, %v, want %d, %v", tt.r, tt.first, tt.middle, tt.last, got, r, tt.want, tt.post)
+		}
+
+		d := make([]int, tt.last-tt.first)
+		if got := RotateCopy(tt.r, d, tt.first, tt.middle, tt.last, 0); got != len(d) || !slices.Equal(d, tt.post[tt.first:tt.last]) {
+			t.Errorf("RotateCopy(%v, %d, %d, %d) = %d, %v", tt.r, tt.first, tt.middle, tt.last, got, d)
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	for _, tt := range []struct {
+		r                 []int
+		first, last, n    int
+		left, right       int
+		postLeft, postRgt []int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 0, 5, 0, 5, 0, []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}},
+		{[]int{1, 2, 3, 4, 5}, 0, 5, 2, 3, 2, []int{3, 4, 5, 4, 5}, []int{1, 2, 1, 2, 3}},
+		{[]int{1, 2, 3, 4, 5}, 1, 4, 1, 3, 2, []int{1, 3, 4, 4, 5}, []int{1, 2, 2, 3, 5}},
+		{[]int{1, 2, 3, 4, 5}, 0, 5, 5, 0, 5, []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}},
+		{[]int{1, 2, 3, 4, 5}, 1, 3, 7, 1, 3, []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}},
+	} {
+		r := slices.Clone(tt.r)
+		if got := ShiftLeft(r, tt.first, tt.last, tt.n); got != tt.left || !slices.Equal(r, tt.postLeft) {
+			t.Errorf("ShiftLeft(%v, %d, %d, %d) = %d, %v, want %d, %v", tt.r, tt.first, tt.last, tt.n, got, r, tt.left, tt.postLeft)
+		}
+		r = slices.Clone(tt.r)
+		if got := ShiftRight(r, tt.first, tt.last, tt.n); got != tt.right || !slices.Equal(r, tt.postRgt) {
+			t.Errorf("ShiftRight(%v, %d, %d, %d) = %d, %v, want %d, %v", tt.r, tt.first, tt.last, tt.n, got, r, tt.right, tt.postRgt)
+		}
+	}
+}
+
+// TestRangeBeyondLen checks that the algorithms with a fast path panic, as
+// their generic loops would, when a range extends past the length of its slice
+// into its capacity, and that they write nothing there.
+func TestRangeBeyondLen(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5}
+	dst := make([]int, 2, 10)
+	r := make([]int, 1, 64)
+	b := make([]byte, 3, 100)
+	b[:100][50] = 'x'
+	runes := make([]rune, 3, 200)
+	runes[:200][150] = 'x'
+
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"Copy", func() { Copy(src, dst, 0, 5, 0) }},
+		{"CopyN", func() { CopyN(src, dst, 0, 5, 0) }},
+		{"CopyBackward", func() { CopyBackward(src, dst, 0, 5, 5) }},
+		{"Fill", func() { Fill(r, 0, 40, 7) }},
+		{"ShiftRight", func() { ShiftRight(r, 0, 40, 1) }},
+		{"FindBytes", func() { Find(b, 0, 60, 'x') }},
+		{"FindRunes", func() { Find(runes, 0, 160, 'x') }},
+		{"CountBytes", func() { Count(b, 0, 60, 'x') }},
+		{"SearchBytes", func() { Search(b, []byte("x"), 0, 60, 0, 1) }},
+		{"SearchRunes", func() { Search(runes, []rune("x"), 0, 160, 0, 1) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: a range beyond the length did not panic", tt.name)
+				}
+			}()
+			tt.call()
+		}()
+	}
+
+	if got := dst[:10]; !slices.Equal(got, make([]int, 10)) {
+		t.Errorf("Copy wrote past the length of the destination: %v", got)
+	}
+	if got := r[1:64]; !slices.Equal(got, make([]int, 63)) {
+		t.Errorf("Fill wrote past the length of the range: %v", got)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	for _, tt := range []struct {
+		r1, r2                      []int
+		includes                    bool
+		union, inter, diff, symdiff []int
+	}{
+		{nil, nil, true, nil, nil, nil, nil},
+		{[]int{1, 2, 3}, nil, true, []int{1, 2, 3}, nil, []int{1, 2, 3}, []int{1, 2, 3}},
+		{nil, []int{1, 2}, false, []int{1, 2}, nil, nil, []int{1, 2}},
+		{[]int{1, 2, 3, 4, 5}, []int{2, 4}, true, []int{1, 2, 3, 4, 5}, []int{2, 4}, []int{1, 3, 5}, []int{1, 3, 5}},
+		{[]int{1, 2, 3}, []int{2, 6}, false, []int{1, 2, 3, 6}, []int{2}, []int{1, 3}, []int{1, 3, 6}},
+		// Multisets: m copies in r1 and n in r2.
+		{[]int{1, 1, 1, 2}, []int{1, 1, 2, 2}, false, []int{1, 1, 1, 2, 2}, []int{1, 1, 2}, []int{1}, []int{1, 2}},
+		{[]int{1, 1, 2, 2}, []int{1, 2}, true, []int{1, 1, 2, 2}, []int{1, 2}, []int{1, 2}, []int{1, 2}},
+	} {
+		n1, n2 := len(tt.r1), len(tt.r2)
+		bound := max(0, 2*(n1+n2)-1)
+
+		calls := instrument.New()
+		if got := Includes(tt.r1, tt.r2, 0, n1, 0, n2); got != tt.includes {
+			t.Errorf("Includes(%v, %v) = %v, want %v", tt.r1, tt.r2, got, tt.includes)
+		}
+		if got := IncludesFunc(tt.r1, tt.r2, 0, n1, 0, n2, instrument.Compare(calls, cmp.Less[int])); got != tt.includes || !atMost(calls.Total(), bound) {
+			t.Errorf("IncludesFunc(%v, %v) = %v with %d calls, want %v", tt.r1, tt.r2, got, calls.Total(), tt.includes)
+		}
+
+		for _, op := range []struct {
+			name    string
+			ordered func(r1, r2, r3 []int, first1, last1, first2, last2, d_first int) int
+			fn      func(r1, r2, r3 []int, first1, last1, first2, last2, d_first int, comp func(int, int) bool) int
+			want    []int
+		}{
+			{"SetUnion", SetUnion[int], SetUnionFunc[int], tt.union},
+			{"SetIntersection", SetIntersection[int], SetIntersectionFunc[int], tt.inter},
+			{"SetDifference", SetDifference[int], SetDifferenceFunc[int], tt.diff},
+			{"SetSymmetricDifference", SetSymmetricDifference[int], SetSymmetricDifferenceFunc[int], tt.symdiff},
+		} {
+			d := make([]int, n1+n2+1)
+			if got := op.ordered(tt.r1, tt.r2, d, 0, n1, 0, n2, 1); got != 1+len(op.want) || !slices.Equal(d[1:got], op.want) {
+				t.Errorf("%s(%v, %v) = %v, want %v", op.name, tt.r1, tt.r2, d[1:got], op.want)
+			}
+			calls.Reset()
+			d = make([]int, n1+n2)
+			if got := op.fn(tt.r1, tt.r2, d, 0, n1, 0, n2, 0, instrument.Compare(calls, cmp.Less[int])); got != len(op.want) || !slices.Equal(d[:got], op.want) || !atMost(calls.Total(), bound) {
+				t.Errorf("%sFunc(%v, %v) = %v with %d calls, want %v", op.name, tt.r1, tt.r2, d[:got], calls.Total(), op.want)
+			}
+		}
+	}
+}
+
+func TestSetOperationsStability(t *testing.T) {
+	// Elements are copied from r1 when equivalent elements are in both
+	// ranges. Compare pairs by their first element only.
+	type kv struct {
+		key   int
+		value string
+	}
+	byKey := func(a, b kv) bool { return a.key < b.key }
+	r1 := []kv{{1, "a"}, {2, "a"}, {2, "b"}}
+	r2 := []kv{{2, "x"}, {2, "y"}, {2, "z"}, {3, "x"}}
+
+	d := make([]kv, 7)
+	got := d[:SetUnionFunc(r1, r2, d, 0, 3, 0, 4, 0, byKey)]
+	if want := []kv{{1, "a"}, {2, "a"}, {2, "b"}, {2, "z"}, {3, "x"}}; !slices.Equal(got, want) {
+		t.Errorf("SetUnionFunc = %v, want %v", got, want)
+	}
+	got = d[:SetIntersectionFunc(r1, r2, d, 0, 3, 0, 4, 0, byKey)]
+	if want := []kv{{2, "a"}, {2, "b"}}; !slices.Equal(got, want) {
+		t.Errorf("SetIntersectionFunc = %v, want %v", got, want)
+	}
+	got = d[:SetSymmetricDifferenceFunc(r1, r2, d, 0, 3, 0, 4, 0, byKey)]
+	if want := []kv{{1, "a"}, {2, "z"}, {3, "x"}}; !slices.Equal(got, want) {
+		t.Errorf("SetSymmetricDifferenceFunc = %v, want %v", got, want)
+	}
+}
+
+func TestHeap(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 7, 8, 100} {
+		r := make([]int, size)
+		for i := range r {
+			r[i] = (i * 37) % 11
+		}
+		sorted := slices.Clone(r)
+		slices.Sort(sorted)
+
+		// MakeHeap makes at most 3 * N comparisons.
+		calls := instrument.New()
+		MakeHeapFunc(r, 0, size, instrument.Compare(calls, cmp.Less[int]))
+		if !IsHeap(r, 0, size) || !atMost(calls.Total(), 3*size) {
+			t.Errorf("MakeHeapFunc(%d) = %v with %d calls", size, r, calls.Total())
+		}
+		if got := IsHeapUntil(r, 0, size); got != size {
+			t.Errorf("IsHeapUntil(%d) = %d", size, got)
+		}
+
+		// PopHeap moves the largest element to the end and makes at most
+		// 2 * log2(N) comparisons.
+		h := slices.Clone(r)
+		for last := size; last > 0; last-- {
+			calls.Reset()
+			PopHeapFunc(h, 0, last, instrument.Compare(calls, cmp.Less[int]))
+			if h[last-1] != sorted[last-1] || !IsHeapFunc(h, 0, last-1, cmp.Less[int]) || !atMost(calls.Total(), 2*log2(last)) {
+				t.Fatalf("PopHeapFunc(%d) = %v with %d calls", last, h, calls.Total())
+			}
+		}
+		if !slices.Equal(h, sorted) {
+			t.Errorf("PopHeap repeatedly = %v, want %v", h, sorted)
+		}
+
+		// PushHeap makes at most log2(N) comparisons.
+		for last := 1; last <= size; last++ {
+			calls.Reset()
+			PushHeapFunc(h, 0, last, instrument.Compare(calls, cmp.Less[int]))
+			if !IsHeap(h, 0, last) || !atMost(calls.Total(), log2(last)) {
+				t.Fatalf("PushHeapFunc(%d) = %v with %d calls", last, h, calls.Total())
+			}
+		}
+
+		// SortHeap makes at most 2 * N * log2(N) comparisons.
+		calls.Reset()
+		SortHeapFunc(h, 0, size, instrument.Compare(calls, cmp.Less[int]))
+		if !slices.Equal(h, sorted) || !atMost(calls.Total(), 2*size*log2(size)) {
+			t.Errorf("SortHeapFunc(%d) = %v with %d calls", size, h, calls.Total())
+		}
+
+		MakeHeap(r, 0, size)
+		SortHeap(r, 0, size)
+		if !slices.Equal(r, sorted) {
+			t.Errorf("SortHeap(%d) = %v", size, r)
+		}
+	}
+
+	r := []int{1, 9, 5, 3, 4}
+	PushHeap(r, 1, 5)
+	if !slices.Equal(r, []int{1, 9, 5, 3, 4}) {
+		t.Errorf("PushHeap on a subrange = %v", r)
+	}
+	PopHeap(r, 1, 5)
+	if !slices.Equal(r, []int{1, 5, 4, 3, 9}) {
+		t.Errorf("PopHeap on a subrange = %v", r)
+	}
+	if got := IsHeapUntil([]int{9, 5, 4, 6, 1}, 0, 5); got != 3 {
+		t.Errorf("IsHeapUntil = %d, want 3", got)
+	}
+	if got := IsHeapUntilFunc([]int{1, 2, 3}, 0, 3, func(a, b int) bool { return a > b }); got != 3 {
+		t.Errorf("IsHeapUntilFunc(min heap) = %d, want 3", got)
+	}
+	if IsHeap([]int{1, 2}, 0, 2) || !IsHeap([]int{1, 2}, 1, 2) {
+		t.Errorf("IsHeap")
+	}
+}
+
+func TestComplexityLarge(t *testing.T) {
+	if debug {
+		t.Skip("the precondition checks of debug builds call the comparator")
+	}
+	const n = 1 << 12
+	r := make([]int, n)
+	for i := range r {
+		r[i] = i * 7 % n
+	}
+	sorted := slices.Clone(r)
+	slices.Sort(sorted)
+
+	// A recording Recorder shows which comparisons were made if a bound is
+	// exceeded.
+	calls := instrument.NewRecording()
+	comp := instrument.Compare(calls, cmp.Less[int])
+	for _, value := range []int{-1, 0, n / 3, n - 1, n} {
+		calls.Reset()
+		LowerBoundFunc(sorted, 0, n, value, comp)
+		if calls.Count(instrument.Comparison) > instrument.Log2(n)+2 {
+			t.Errorf("LowerBoundFunc(%d) made %v", value, calls)
+		}
+		calls.Reset()
+		UpperBoundFunc(sorted, 0, n, value, comp)
+		if calls.Count(instrument.Comparison) > instrument.Log2(n)+2 {
+			t.Errorf("UpperBoundFunc(%d) made %v", value, calls)
+		}
+	}
+
+	calls = instrument.New()
+	comp = instrument.Compare(calls, cmp.Less[int])
+	h := slices.Clone(r)
+	MakeHeapFunc(h, 0, n, comp)
+	if got := calls.Count(instrument.Comparison); got > 3*n {
+		t.Errorf("MakeHeapFunc made %d comparisons, want at most %d", got, 3*n)
+	}
+	calls.Reset()
+	SortHeapFunc(h, 0, n, comp)
+	if got, want := calls.Count(instrument.Comparison), 2*n*instrument.Log2(n); got > want {
+		t.Errorf("SortHeapFunc made %d comparisons, want at most %d", got, want)
+	}
+
+	// Rotate makes at most n swaps, and rotating distinct elements moves
+	// every one of them.
+	swaps := 0
+	onSwap = func() { swaps++ }
+	rot := slices.Clone(sorted)
+	Rotate(rot, 0, n/3, n)
+	onSwap = nil
+	if swaps > n || instrument.Assignments(sorted, rot) != n {
+		t.Errorf("Rotate made %d swaps and changed %d elements, want at most %d and %d", swaps, instrument.Assignments(sorted, rot), n, n)
+	}
+
+	calls.Reset()
+	pred := instrument.Pred(calls, even)
+	RemoveIf(slices.Clone(r), 0, n, pred)
+	if got := calls.Count(instrument.Predicate); got != n {
+		t.Errorf("RemoveIf made %d applications, want exactly %d", got, n)
+	}
+	calls.Reset()
+	Generate(h, 0, n, instrument.Gen(calls, func() int { return 0 }))
+	if got := calls.Count(instrument.Generator); got != n {
+		t.Errorf("Generate made %d calls, want exactly %d", got, n)
+	}
+}
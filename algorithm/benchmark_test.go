@@ -0,0 +1,209 @@
+package algorithm
+
+import (
+	"fmt"
+	"testing"
+
+	"gocpp/instrument"
+)
+
+// The benchmarks in this file compare the specialized implementations with
+// the element-by-element loops they replaced, which are reproduced below as
+// the "loop" sub-benchmarks.
+
+var benchSizes = []int{16, 1 << 10, 1 << 16}
+
+func loopFind[T comparable](r []T, first, last int, value T) int {
+	for ; first != last; first++ {
+		if r[first] == value {
+			return first
+		}
+	}
+	return last
+}
+
+func loopCount[T comparable](r []T, first, last int, value T) int {
+	ret := 0
+	for ; first != last; first++ {
+		if r[first] == value {
+			ret++
+		}
+	}
+	return ret
+}
+
+func loopSearch[T comparable](r1, r2 []T, first, last, s_first, s_last int) int {
+	for {
+		it := first
+		for s_it := s_first; ; {
+			if s_it == s_last {
+				return first
+			}
+			if it == last {
+				return last
+			}
+			if r1[it] != r2[s_it] {
+				break
+			}
+			it++
+			s_it++
+		}
+		first++
+	}
+}
+
+func loopCopy[T any](r1, r2 []T, first, last, d_first int) int {
+	for first != last {
+		r2[d_first] = r1[first]
+		first++
+		d_first++
+	}
+	return d_first
+}
+
+func loopFill[T any](r []T, first, last int, value T) {
+	for ; first != last; first++ {
+		r[first] = value
+	}
+}
+
+// haystack returns n elements of printable text without the value 'z', so
+// searches for 'z' scan the whole range.
+func haystack[T byte | rune](n int) []T {
+	r := make([]T, n)
+	for i := range r {
+		r[i] = T('a' + i%25)
+	}
+	return r
+}
+
+func benchSearch[T byte | rune](b *testing.B) {
+	for _, n := range benchSizes {
+		r := haystack[T](n)
+		needle := []T{'x', 'y', 'z'}
+		b.Run(fmt.Sprintf("Find/%d/loop", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loopFind(r, 0, n, 'z')
+			}
+		})
+		b.Run(fmt.Sprintf("Find/%d/algorithm", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Find(r, 0, n, 'z')
+			}
+		})
+		b.Run(fmt.Sprintf("Count/%d/loop", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loopCount(r, 0, n, 'a')
+			}
+		})
+		b.Run(fmt.Sprintf("Count/%d/algorithm", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Count(r, 0, n, 'a')
+			}
+		})
+		b.Run(fmt.Sprintf("Search/%d/loop", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loopSearch(r, needle, 0, n, 0, len(needle))
+			}
+		})
+		b.Run(fmt.Sprintf("Search/%d/algorithm", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Search(r, needle, 0, n, 0, len(needle))
+			}
+		})
+	}
+}
+
+func BenchmarkBytes(b *testing.B) {
+	benchSearch[byte](b)
+}
+
+func BenchmarkRunes(b *testing.B) {
+	benchSearch[rune](b)
+}
+
+func BenchmarkCopy(b *testing.B) {
+	for _, n := range benchSizes {
+		src, dst := make([]int, n), make([]int, n)
+		b.Run(fmt.Sprintf("%d/loop", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loopCopy(src, dst, 0, n, 0)
+			}
+		})
+		b.Run(fmt.Sprintf("%d/algorithm", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Copy(src, dst, 0, n, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkShiftRight(b *testing.B) {
+	for _, n := range benchSizes {
+		r := make([]int, n)
+		b.Run(fmt.Sprintf("%d/loop", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := n - 1; j >= 1; j-- {
+					r[j] = r[j-1]
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%d/algorithm", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ShiftRight(r, 0, n, 1)
+			}
+		})
+	}
+}
+
+func BenchmarkFill(b *testing.B) {
+	type point struct{ x, y, z float64 }
+	for _, n := range benchSizes {
+		r := make([]point, n)
+		b.Run(fmt.Sprintf("%d/loop", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loopFill(r, 0, n, point{1, 2, 3})
+			}
+		})
+		b.Run(fmt.Sprintf("%d/algorithm", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Fill(r, 0, n, point{1, 2, 3})
+			}
+		})
+	}
+}
+
+// BenchmarkComparisons reports the comparisons per operation of the
+// algorithms with logarithmic or linearithmic complexity, next to their
+// time.
+func BenchmarkComparisons(b *testing.B) {
+	for _, n := range benchSizes {
+		r := make([]int, n)
+		for i := range r {
+			r[i] = i * 7 % n
+		}
+		b.Run(fmt.Sprintf("LowerBound/%d", n), func(b *testing.B) {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = i
+			}
+			calls := instrument.New()
+			comp := instrument.Compare(calls, less[int])
+			for i := 0; i < b.N; i++ {
+				LowerBoundFunc(s, 0, n, i%n, comp)
+			}
+			calls.ReportMetrics(b, b.N)
+		})
+		b.Run(fmt.Sprintf("HeapSort/%d", n), func(b *testing.B) {
+			h := make([]int, n)
+			calls := instrument.New()
+			comp := instrument.Compare(calls, less[int])
+			for i := 0; i < b.N; i++ {
+				copy(h, r)
+				MakeHeapFunc(h, 0, n, comp)
+				SortHeapFunc(h, 0, n, comp)
+			}
+			calls.ReportMetrics(b, b.N)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package algorithm
+
+import (
+	"cmp"
+	"fmt"
+
+	"gocpp/internal/alias"
+)
+
+// PreconditionError is the panic value of an algorithm whose documented
+// precondition does not hold. Preconditions are only checked in debug mode,
+// which is enabled by building with the gocppdebug build tag:
+//
+//	go test -tags gocppdebug ./...
+//
+// In debug mode every algorithm validates its ranges, destination sizes,
+// overlap and sortedness requirements before doing any work, in the spirit of
+// _GLIBCXX_DEBUG. The checks can make algorithms asymptotically slower; for
+// example, binary searches verify that their input is partitioned. Without the
+// build tag the checks compile away entirely.
+type PreconditionError struct {
+	Algorithm   string
+	Requirement string
+}
+
+func (e *PreconditionError) Error() string {
+	return "algorithm: " + e.Algorithm + ": precondition violated: " + e.Requirement
+}
+
+func violated(algo, format string, args ...any) {
+	panic(&PreconditionError{algo, fmt.Sprintf(format, args...)})
+}
+
+func less[T cmp.Ordered](a, b T) bool {
+	return a < b
+}
+
+// checkRange requires [first, last) to be a valid range of a slice of length
+// n. name describes the range, as in "r[first, last)".
+func checkRange(algo, name string, n, first, last int) {
+	if first < 0 || first > last || last > n {
+		violated(algo, "%s = [%d, %d) is not a valid range of a slice of length %d", name, first, last, n)
+	}
+}
+
+// checkMiddle requires middle to be within [first, last].
+func checkMiddle(algo, name string, first, middle, last int) {
+	if middle < first || middle > last {
+		violated(algo, "%s = %d is not within [%d, %d]", name, middle, first, last)
+	}
+}
+
+// checkNonEmpty requires the range [first, last) to hold an element.
+func checkNonEmpty(algo, name string, first, last int) {
+	if first == last {
+		violated(algo, "%s must not be empty", name)
+	}
+}
+
+// checkRoom requires a slice of length n to hold count elements starting at
+// index first. name describes the range.
+func checkRoom(algo, name string, n, first, count int) {
+	if first < 0 || first > n || count > n-first {
+		violated(algo, "%s needs %d elements starting at index %d, but the slice has length %d", name, count, first, n)
+	}
+}
+
+// checkNonNegative requires the count n not to be negative.
+func checkNonNegative(algo, name string, n int) {
+	if n < 0 {
+		violated(algo, "%s = %d must not be negative", name, n)
+	}
+}
+
+// checkNoOverlap requires the subslices src and dst not to share any element.
+func checkNoOverlap[T any](algo string, src, dst []T) {
+	if alias.Overlap(src, dst) {
+		violated(algo, "the source and destination ranges overlap")
+	}
+}
+
+// checkNotWithin requires the element r2[pos] not to be one of the elements
+// of r1[first, last). If open is true, the interval (first, last] is checked
+// instead, as for the d_last argument of the backward algorithms.
+func checkNotWithin[T any](algo, name string, r1 []T, first, last int, r2 []T, pos int, open bool) {
+	interval := "[first, last)"
+	if open {
+		pos, interval = pos-1, "(first, last]"
+	}
+	if first < last && alias.Overlap(r1[first:last], r2[pos:pos+1]) {
+		violated(algo, "%s is within the source range %s", name, interval)
+	}
+}
+
+// checkWrite is called by algorithms whose output size depends on the input
+// before they assign dst[d]. It requires dst[d] to exist and not to be one of
+// the elements of srcs, the parts of the input ranges that remain to be read.
+func checkWrite[T any](algo, name string, dst []T, d int, srcs ...[]T) {
+	if d < 0 || d >= len(dst) {
+		violated(algo, "destination %s is too short: writing index %d of a slice of length %d", name, d, len(dst))
+	}
+	for _, src := range srcs {
+		if alias.Overlap(src, dst[d:d+1]) {
+			violated(algo, "destination %s[%d] overlaps an input range that has not been read yet", name, d)
+		}
+	}
+}
+
+// checkSorted requires r[first, last) to be sorted with respect to comp, and
+// comp to be irreflexive on its elements.
+func checkSorted[T any](algo, name string, r []T, first, last int, comp func(T, T) bool) {
+	for i := first; i != last; i++ {
+		if comp(r[i], r[i]) {
+			violated(algo, "comparator is not a strict weak ordering: comp(x, x) is true for element %d of %s", i, name)
+		}
+		if i+1 != last && comp(r[i+1], r[i]) {
+			violated(algo, "%s is not sorted: element %d is ordered before element %d", name, i+1, i)
+		}
+	}
+}
+
+// checkPartitioned requires every element of r[first, last) for which p holds
+// to precede every element for which it does not.
+func checkPartitioned[T any](algo, requirement string, r []T, first, last int, p func(T) bool) {
+	for first != last && p(r[first]) {
+		first++
+	}
+	for ; first != last; first++ {
+		if p(r[first]) {
+			violated(algo, "r[first, last) is not partitioned with respect to %s at index %d", requirement, first)
+		}
+	}
+}
+
+// checkHeap requires r[first, last) to be a heap with respect to comp.
+func checkHeap[T any](algo, name string, r []T, first, last int, comp func(T, T) bool) {
+	if it := IsHeapUntilFunc(r, first, last, comp); it != last {
+		violated(algo, "%s is not a heap: r[%d] is greater than its parent", name, it)
+	}
+}
+
+// checkSetInputs checks the two input ranges of a set operation, which must be
+// valid and sorted with respect to comp.
+func checkSetInputs[T any](algo string, r1, r2 []T, first1, last1, first2, last2 int, comp func(T, T) bool) {
+	checkRange(algo, "r1[first1, last1)", len(r1), first1, last1)
+	checkRange(algo, "r2[first2, last2)", len(r2), first2, last2)
+	checkSorted(algo, "r1[first1, last1)", r1, first1, last1, comp)
+	checkSorted(algo, "r2[first2, last2)", r2, first2, last2, comp)
+}
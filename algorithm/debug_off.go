@@ -0,0 +1,6 @@
+//go:build !gocppdebug
+
+package algorithm
+
+// debug enables precondition checks. See PreconditionError.
+const debug = false
@@ -0,0 +1,68 @@
+//go:build gocppdebug
+
+package algorithm
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPreconditions checks that each family of precondition checks panics
+// with a PreconditionError naming the algorithm and the violated requirement.
+func TestPreconditions(t *testing.T) {
+	r := []int{1, 2, 3, 4, 5}
+	unsorted := []int{3, 1, 2}
+	lessEqual := func(a, b int) bool { return a <= b }
+
+	tests := []struct {
+		name        string
+		call        func()
+		algorithm   string
+		requirement string
+	}{
+		{"Range", func() { Find(r, 2, 1, 0) },
+			"Find", "r[first, last) = [2, 1) is not a valid range of a slice of length 5"},
+		{"RangeBeyondLen", func() { Find(make([]int, 3, 100), 0, 60, 0) },
+			"Find", "r[first, last) = [0, 60) is not a valid range of a slice of length 3"},
+		{"Room", func() { Copy(r, make([]int, 2), 0, 5, 0) },
+			"Copy", "destination r2 needs 5 elements starting at index 0, but the slice has length 2"},
+		{"Within", func() { Copy(r, r, 0, 3, 1) },
+			"Copy", "d_first is within the source range [first, last)"},
+		{"WithinBackward", func() { MoveBackward(r, r, 1, 3, 3) },
+			"MoveBackward", "d_last is within the source range (first, last]"},
+		{"Overlap", func() { SwapRanges(r, r, 0, 3, 1) },
+			"SwapRanges", "the source and destination ranges overlap"},
+		{"Write", func() { CopyIf(r, make([]int, 1), 0, 5, 0, func(int) bool { return true }) },
+			"CopyIf", "destination r2 is too short: writing index 1 of a slice of length 1"},
+		{"Sorted", func() { Includes(unsorted, r, 0, 3, 0, 5) },
+			"Includes", "r1[first1, last1) is not sorted: element 1 is ordered before element 0"},
+		{"Irreflexive", func() { IncludesFunc(r, r, 0, 5, 0, 5, lessEqual) },
+			"IncludesFunc", "comparator is not a strict weak ordering: comp(x, x) is true for element 0"},
+		{"Partitioned", func() { LowerBound(unsorted, 0, 3, 2) },
+			"LowerBound", "r[first, last) is not partitioned with respect to element < value at index 1"},
+		{"Heap", func() { PopHeap(r, 0, 5) },
+			"PopHeap", "r[first, last) is not a heap"},
+		{"NonEmpty", func() { PopHeap(r, 2, 2) },
+			"PopHeap", "r[first, last) must not be empty"},
+		{"Middle", func() { Rotate(r, 0, 4, 3) },
+			"Rotate", "middle = 4 is not within [0, 3]"},
+		{"NonNegative", func() { ShiftLeft(r, 0, 5, -1) },
+			"ShiftLeft", "n = -1 must not be negative"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				err, ok := recover().(*PreconditionError)
+				switch {
+				case !ok:
+					t.Errorf("%s: did not panic with a *PreconditionError", tt.name)
+				case err.Algorithm != tt.algorithm || !strings.HasPrefix(err.Requirement, tt.requirement):
+					t.Errorf("%s: panic %q, want algorithm %s and requirement %q", tt.name, err, tt.algorithm, tt.requirement)
+				case !strings.HasPrefix(err.Error(), "algorithm: "+tt.algorithm+": precondition violated: "):
+					t.Errorf("%s: Error() = %q", tt.name, err.Error())
+				}
+			}()
+			tt.call()
+		}()
+	}
+}
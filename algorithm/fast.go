@@ -0,0 +1,96 @@
+package algorithm
+
+import (
+	"bytes"
+	"unsafe"
+)
+
+// The functions in this file are specialized implementations for element
+// types whose ranges can be searched with the vectorized routines of package
+// bytes. Each reports false if it does not apply to T, in which case the
+// caller falls back to its generic loop. The dispatch is a type switch on a
+// nil *T, which does not allocate. Subslices are bounded by the length of
+// their slice, not its capacity, so that a range beyond the length panics as
+// the generic loop would.
+
+// minRunes is the length below which searching a []rune byte by byte with
+// package bytes is slower than the generic loop, because candidate matches at
+// unaligned offsets must be skipped one call at a time.
+const minRunes = 64
+
+// as reinterprets a slice of T as a slice of U, where T and U are the same
+// type.
+func as[U, T any](s []T) []U {
+	return *(*[]U)(unsafe.Pointer(&s))
+}
+
+// asBytes returns the memory of s as a byte slice.
+func asBytes[T any](s []T) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(s))), len(s)*int(unsafe.Sizeof(*new(T))))
+}
+
+// indexAligned returns the index of the first element of the []T whose memory
+// is hay at which the memory of the []T needle begins, or -1. size is the
+// size of T. Only types whose equality is bitwise equality may be searched
+// this way.
+func indexAligned(hay, needle []byte, size int) int {
+	for off := 0; ; {
+		i := bytes.Index(hay[off:], needle)
+		if i < 0 {
+			return -1
+		}
+		if off += i; off%size == 0 {
+			return off / size
+		}
+		off++
+	}
+}
+
+// findFast implements Find for []byte and []rune.
+func findFast[T comparable](r []T, first, last int, value T) (int, bool) {
+	var i int
+	switch any((*T)(nil)).(type) {
+	case *byte:
+		i = bytes.IndexByte(as[byte](r[first:last:len(r)]), *(*byte)(unsafe.Pointer(&value)))
+	case *rune:
+		if last-first < minRunes {
+			return 0, false
+		}
+		i = indexAligned(asBytes(r[first:last:len(r)]), asBytes(unsafe.Slice(&value, 1)), 4)
+	default:
+		return 0, false
+	}
+	if i < 0 {
+		return last, true
+	}
+	return first + i, true
+}
+
+// countFast implements Count for []byte. Counting a []rune this way is not
+// faster than the generic loop unless matches are rare.
+func countFast[T comparable](r []T, first, last int, value T) (int, bool) {
+	if _, ok := any((*T)(nil)).(*byte); ok {
+		return bytes.Count(as[byte](r[first:last:len(r)]), asBytes(unsafe.Slice(&value, 1))), true
+	}
+	return 0, false
+}
+
+// searchFast implements Search for []byte and []rune.
+func searchFast[T comparable](r1, r2 []T, first, last, s_first, s_last int) (int, bool) {
+	var i int
+	switch any((*T)(nil)).(type) {
+	case *byte:
+		i = bytes.Index(as[byte](r1[first:last:len(r1)]), as[byte](r2[s_first:s_last:len(r2)]))
+	case *rune:
+		if last-first < minRunes {
+			return 0, false
+		}
+		i = indexAligned(asBytes(r1[first:last:len(r1)]), asBytes(r2[s_first:s_last:len(r2)]), 4)
+	default:
+		return 0, false
+	}
+	if i < 0 {
+		return last, true
+	}
+	return first + i, true
+}
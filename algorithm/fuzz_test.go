@@ -0,0 +1,533 @@
+package algorithm
+
+import (
+	"slices"
+	"testing"
+
+	"gocpp/internal/proptest"
+)
+
+// The fuzz targets in this file compare the algorithms with reference models:
+// short implementations that work on the elements of the range as a plain
+// slice and are obviously correct. Run them beyond their seed corpus with,
+// for example,
+//
+//	go test -fuzz FuzzModifying ./algorithm
+
+// The reference models. Each takes the elements of the range and returns the
+// result relative to the start of the range.
+
+func refIndex(s []int, p func(int) bool) int {
+	for i, x := range s {
+		if p(x) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func refCount(s []int, p func(int) bool) int {
+	n := 0
+	for _, x := range s {
+		if p(x) {
+			n++
+		}
+	}
+	return n
+}
+
+func refAdjacentFind(s []int) int {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == s[i+1] {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func refSearch(s, sub []int) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func refSearchN(s []int, count, value int) int {
+	if count <= 0 {
+		return 0
+	}
+	return refSearch(s, refRepeat(value, count))
+}
+
+func refRepeat(value, count int) []int {
+	out := make([]int, count)
+	for i := range out {
+		out[i] = value
+	}
+	return out
+}
+
+func refMismatch(s1, s2 []int) int {
+	i := 0
+	for i < len(s1) && i < len(s2) && s1[i] == s2[i] {
+		i++
+	}
+	return i
+}
+
+func refFilter(s []int, keep func(int) bool) []int {
+	var out []int
+	for _, x := range s {
+		if keep(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+func refUnique(s []int) []int {
+	var out []int
+	for _, x := range s {
+		if len(out) == 0 || out[len(out)-1] != x {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+func refReplace(s []int, p func(int) bool, value int) []int {
+	out := make([]int, len(s))
+	for i, x := range s {
+		if p(x) {
+			x = value
+		}
+		out[i] = x
+	}
+	return out
+}
+
+func refReverse(s []int) []int {
+	out := make([]int, 0, len(s))
+	for i := len(s) - 1; i >= 0; i-- {
+		out = append(out, s[i])
+	}
+	return out
+}
+
+func refRotate(s []int, middle int) []int {
+	return append(slices.Clone(s[middle:]), s[:middle]...)
+}
+
+// refSetOperation returns the sorted multiset whose count of each value is
+// f(m, n), for m and n its counts in s1 and s2.
+func refSetOperation(s1, s2 []int, f func(m, n int) int) []int {
+	counts1, counts2 := make(map[int]int), make(map[int]int)
+	var values []int
+	for _, x := range s1 {
+		counts1[x]++
+		values = append(values, x)
+	}
+	for _, x := range s2 {
+		counts2[x]++
+		values = append(values, x)
+	}
+	slices.Sort(values)
+	var out []int
+	for _, x := range slices.Compact(values) {
+		for i := f(counts1[x], counts2[x]); i > 0; i-- {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+func refIncludes(s1, s2 []int) bool {
+	return len(refSetOperation(s2, s1, func(m, n int) int { return max(m-n, 0) })) == 0
+}
+
+func refIsHeapUntil(s []int) int {
+	for i := 1; i < len(s); i++ {
+		if s[(i-1)/2] < s[i] {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// checkResult reports a failure of the algorithm name on the range r if err
+// is not nil.
+func checkResult(t *testing.T, name string, r proptest.Range[int], err error) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s(%v): %v", name, r, err)
+	}
+}
+
+// checkIndex reports a failure of the algorithm name on the range r if got is
+// not want.
+func checkIndex(t *testing.T, name string, r proptest.Range[int], got, want int) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s(%v) = %d, want %d", name, r, got, want)
+	}
+}
+
+func FuzzNonModifying(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 1})
+	f.Add([]byte{8, 1, 2, 2, 3, 0, 1, 2, 3, 1, 8, 2, 2})
+	f.Add([]byte{16, 1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3, 0, 0, 0, 0, 2, 14, 3, 3})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d := proptest.NewDecoder(data)
+		r := d.Range(32, 4)
+		value, count := d.Intn(5), d.Intn(6)-1
+		before := slices.Clone(r.R)
+		s := r.Elems()
+		eq := func(x int) bool { return x == value }
+		ne := func(x int) bool { return x != value }
+
+		want := r.First + refIndex(s, eq)
+		checkIndex(t, "Find", r, Find(r.R, r.First, r.Last, value), want)
+		checkIndex(t, "FindIf", r, FindIf(r.R, r.First, r.Last, eq), want)
+		checkIndex(t, "FindOpt", r, FindOpt(r.R, r.First, r.Last, value).ValueOr(r.Last), want)
+		checkIndex(t, "FindIfOpt", r, FindIfOpt(r.R, r.First, r.Last, eq).ValueOr(r.Last), want)
+		checkIndex(t, "FindIfNot", r, FindIfNot(r.R, r.First, r.Last, ne), want)
+		checkIndex(t, "FindIfNotOpt", r, FindIfNotOpt(r.R, r.First, r.Last, ne).ValueOr(r.Last), want)
+
+		checkIndex(t, "Count", r, Count(r.R, r.First, r.Last, value), refCount(s, eq))
+		checkIndex(t, "CountIf", r, CountIf(r.R, r.First, r.Last, even), refCount(s, even))
+		evens := refCount(s, even)
+		if AllOf(r.R, r.First, r.Last, even) != (evens == len(s)) ||
+			AnyOf(r.R, r.First, r.Last, even) != (evens > 0) ||
+			NoneOf(r.R, r.First, r.Last, even) != (evens == 0) {
+			t.Errorf("AllOf, AnyOf, NoneOf(%v) disagree with %d of %d even elements", r, evens, len(s))
+		}
+
+		want = r.First + refAdjacentFind(s)
+		checkIndex(t, "AdjacentFind", r, AdjacentFind(r.R, r.First, r.Last), want)
+		checkIndex(t, "AdjacentFindFunc", r, AdjacentFindFunc(r.R, r.First, r.Last, equal), want)
+
+		want = r.First + refSearchN(s, count, value)
+		checkIndex(t, "SearchN", r, SearchN(r.R, r.First, r.Last, count, value), want)
+		checkIndex(t, "SearchNFunc", r, SearchNFunc(r.R, r.First, r.Last, count, value, equal), want)
+
+		checkIndex(t, "IsHeapUntil", r, IsHeapUntil(r.R, r.First, r.Last), r.First+refIsHeapUntil(s))
+		checkResult(t, "non-modifying algorithms", r, proptest.Equal(r.R, before))
+	})
+}
+
+func FuzzBounds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 5, 0, 1, 5})
+	f.Add([]byte{10, 0, 1, 1, 2, 2, 2, 3, 5, 5, 7, 2, 6, 3})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d := proptest.NewDecoder(data)
+		r := d.SortedRange(64, 8)
+		value := d.Intn(10) - 1
+		s := r.Elems()
+
+		lower := r.First + refCount(s, func(x int) bool { return x < value })
+		upper := r.First + refCount(s, func(x int) bool { return x <= value })
+		checkIndex(t, "LowerBound", r, LowerBound(r.R, r.First, r.Last, value), lower)
+		checkIndex(t, "LowerBoundFunc", r, LowerBoundFunc(r.R, r.First, r.Last, value, less[int]), lower)
+		checkIndex(t, "UpperBound", r, UpperBound(r.R, r.First, r.Last, value), upper)
+		checkIndex(t, "UpperBoundFunc", r, UpperBoundFunc(r.R, r.First, r.Last, value, less[int]), upper)
+	})
+}
+
+func FuzzTwoRanges(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{3, 1, 2, 0, 0, 3, 3, 1, 2, 0, 0, 3})
+	f.Add([]byte{6, 1, 2, 1, 2, 0, 1, 1, 5, 2, 1, 2, 0, 2})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d := proptest.NewDecoder(data)
+		r1, r2 := d.Range(16, 3), d.Range(16, 3)
+		s1, s2 := r1.Elems(), r2.Elems()
+
+		i := refMismatch(s1, s2)
+		want := [2]int{r1.First + i, r2.First + i}
+		if got := Mismatch2(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last); [2]int{got.First, got.Second} != want {
+			t.Errorf("Mismatch2(%v, %v) = %v, want %v", r1, r2, got, want)
+		}
+		if got := MismatchFunc2(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last, equal); [2]int{got.First, got.Second} != want {
+			t.Errorf("MismatchFunc2(%v, %v) = %v, want %v", r1, r2, got, want)
+		}
+		if got := Equal2(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last); got != slices.Equal(s1, s2) {
+			t.Errorf("Equal2(%v, %v) = %v", r1, r2, got)
+		}
+		if got := EqualFunc2(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last, equal); got != slices.Equal(s1, s2) {
+			t.Errorf("EqualFunc2(%v, %v) = %v", r1, r2, got)
+		}
+
+		// The algorithms that take the start of the second range only need
+		// it to be at least as long as the first.
+		if len(s2) >= len(s1) {
+			if got := Mismatch(r1.R, r2.R, r1.First, r1.Last, r2.First); [2]int{got.First, got.Second} != want {
+				t.Errorf("Mismatch(%v, %v) = %v, want %v", r1, r2, got, want)
+			}
+			if got := Equal(r1.R, r2.R, r1.First, r1.Last, r2.First); got != slices.Equal(s1, s2[:len(s1)]) {
+				t.Errorf("Equal(%v, %v) = %v", r1, r2, got)
+			}
+			d := make([]int, len(s1))
+			Transform2(r1.R, r2.R, d, r1.First, r1.Last, r2.First, 0, func(x, y int) int { return x*10 + y })
+			for i := range d {
+				if d[i] != s1[i]*10+s2[i] {
+					t.Errorf("Transform2(%v, %v) = %v", r1, r2, d)
+					break
+				}
+			}
+		}
+
+		want1 := r1.First + refSearch(s1, s2)
+		checkIndex(t, "Search", r1, Search(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last), want1)
+		checkIndex(t, "SearchFunc", r1, SearchFunc(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last, equal), want1)
+	})
+}
+
+func FuzzSetOperations(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{4, 1, 1, 2, 3, 0, 4, 3, 1, 2, 2, 0, 3})
+	f.Add([]byte{8, 0, 0, 0, 1, 1, 2, 3, 3, 1, 6, 5, 1, 1, 2, 3, 3, 0, 5})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d := proptest.NewDecoder(data)
+		r1, r2 := d.SortedRange(16, 4), d.SortedRange(16, 4)
+		s1, s2 := r1.Elems(), r2.Elems()
+
+		if got := Includes(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last); got != refIncludes(s1, s2) {
+			t.Errorf("Includes(%v, %v) = %v", r1, r2, got)
+		}
+		if got := IncludesFunc(r1.R, r2.R, r1.First, r1.Last, r2.First, r2.Last, less[int]); got != refIncludes(s1, s2) {
+			t.Errorf("IncludesFunc(%v, %v) = %v", r1, r2, got)
+		}
+
+		for _, op := range []struct {
+			name    string
+			ordered func(r1, r2, r3 []int, first1, last1, first2, last2, d_first int) int
+			fn      func(r1, r2, r3 []int, first1, last1, first2, last2, d_first int, comp func(int, int) bool) int
+			count   func(m, n int) int
+		}{
+			{"SetUnion", SetUnion[int], SetUnionFunc[int], func(m, n int) int { return max(m, n) }},
+			{"SetIntersection", SetIntersection[int], SetIntersectionFunc[int], func(m, n int) int { return min(m, n) }},
+			{"SetDifference", SetDifference[int], SetDifferenceFunc[int], func(m, n int) int { return max(m-n, 0) }},
+			{"SetSymmetricDifference", SetSymmetricDifference[int], SetSymmetricDifferenceFunc[int], func(m, n int) int { return max(m-n, n-m) }},
+		} {
+			want := refSetOperation(s1, s2, op.count)
+			out := make([]int, len(s1)+len(s2)+1)
+			got := out[1:op.ordered(r1.R, r2.R, out, r1.First, r1.Last, r2.First, r2.Last, 1)]
+			if err := proptest.Equal(got, want); err != nil {
+				t.Errorf("%s(%v, %v): %v", op.name, r1, r2, err)
+			}
+			got = out[:op.fn(r1.R, r2.R, out, r1.First, r1.Last, r2.First, r2.Last, 0, less[int])]
+			if err := proptest.Equal(got, want); err != nil {
+				t.Errorf("%sFunc(%v, %v): %v", op.name, r1, r2, err)
+			}
+		}
+	})
+}
+
+func FuzzModifying(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 0, 0})
+	f.Add([]byte{8, 1, 1, 2, 3, 1, 3, 3, 0, 1, 6, 1, 2, 3})
+	f.Add([]byte{20, 3, 3, 1, 0, 0, 2, 1, 1, 1, 3, 2, 0, 1, 2, 3, 3, 3, 0, 1, 2, 0, 21, 2, 7, 9})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d := proptest.NewDecoder(data)
+		r := d.Range(32, 4)
+		value, middle, n := d.Intn(5), d.Intn(r.Len()+1), d.Intn(40)
+		s := r.Elems()
+		eq := func(x int) bool { return x == value }
+		ne := func(x int) bool { return x != value }
+
+		// modify applies an algorithm to a copy of r and checks that it
+		// leaves the elements outside [r.First, r.Last) alone.
+		modify := func(name string, f func(c proptest.Range[int])) proptest.Range[int] {
+			c := r.Clone()
+			f(c)
+			checkResult(t, name, r, proptest.Unchanged(r.R, c.R, r.First, r.Last))
+			return c
+		}
+		// copyTo applies an algorithm that copies to a new slice, starting
+		// at index 1, and returns the elements it wrote.
+		copyTo := func(name string, f func(d []int) int) []int {
+			d := make([]int, len(s)+2)
+			end := f(d)
+			if end < 1 || end > len(d) || d[0] != 0 || slices.ContainsFunc(d[end:], func(x int) bool { return x != 0 }) {
+				t.Errorf("%s(%v) = %d, %v: wrote outside its output", name, r, end, d)
+				return nil
+			}
+			return d[1:end]
+		}
+
+		want := refFilter(s, ne)
+		var end int
+		c := modify("Remove", func(c proptest.Range[int]) { end = Remove(c.R, c.First, c.Last, value) })
+		checkResult(t, "Remove", r, proptest.Equal(c.R[r.First:end], want))
+		c = modify("RemoveIf", func(c proptest.Range[int]) { end = RemoveIf(c.R, c.First, c.Last, eq) })
+		checkResult(t, "RemoveIf", r, proptest.Equal(c.R[r.First:end], want))
+		got := copyTo("RemoveCopy", func(d []int) int { return RemoveCopy(r.R, d, r.First, r.Last, 1, value) })
+		checkResult(t, "RemoveCopy", r, proptest.Equal(got, want))
+		got = copyTo("RemoveCopyIf", func(d []int) int { return RemoveCopyIf(r.R, d, r.First, r.Last, 1, eq) })
+		checkResult(t, "RemoveCopyIf", r, proptest.Equal(got, want))
+		got = copyTo("CopyIf", func(d []int) int { return CopyIf(r.R, d, r.First, r.Last, 1, ne) })
+		checkResult(t, "CopyIf", r, proptest.Equal(got, want))
+
+		want = refUnique(s)
+		c = modify("Unique", func(c proptest.Range[int]) { end = Unique(c.R, c.First, c.Last) })
+		checkResult(t, "Unique", r, proptest.Equal(c.R[r.First:end], want))
+		c = modify("UniqueFunc", func(c proptest.Range[int]) { end = UniqueFunc(c.R, c.First, c.Last, equal) })
+		checkResult(t, "UniqueFunc", r, proptest.Equal(c.R[r.First:end], want))
+		got = copyTo("UniqueCopy", func(d []int) int { return UniqueCopy(r.R, d, r.First, r.Last, 1) })
+		checkResult(t, "UniqueCopy", r, proptest.Equal(got, want))
+		got = copyTo("UniqueCopyFunc", func(d []int) int { return UniqueCopyFunc(r.R, d, r.First, r.Last, 1, equal) })
+		checkResult(t, "UniqueCopyFunc", r, proptest.Equal(got, want))
+
+		want = refReplace(s, eq, 9)
+		c = modify("Replace", func(c proptest.Range[int]) { Replace(c.R, c.First, c.Last, value, 9) })
+		checkResult(t, "Replace", r, proptest.Equal(c.Elems(), want))
+		got = copyTo("ReplaceCopy", func(d []int) int { return ReplaceCopy(r.R, d, r.First, r.Last, 1, value, 9) })
+		checkResult(t, "ReplaceCopy", r, proptest.Equal(got, want))
+		want = refReplace(s, even, 9)
+		c = modify("ReplaceIf", func(c proptest.Range[int]) { ReplaceIf(c.R, c.First, c.Last, even, 9) })
+		checkResult(t, "ReplaceIf", r, proptest.Equal(c.Elems(), want))
+		got = copyTo("ReplaceCopyIf", func(d []int) int { return ReplaceCopyIf(r.R, d, r.First, r.Last, 1, even, 9) })
+		checkResult(t, "ReplaceCopyIf", r, proptest.Equal(got, want))
+
+		want = refReverse(s)
+		c = modify("Reverse", func(c proptest.Range[int]) { Reverse(c.R, c.First, c.Last) })
+		checkResult(t, "Reverse", r, proptest.Equal(c.Elems(), want))
+		got = copyTo("ReverseCopy", func(d []int) int { return ReverseCopy(r.R, d, r.First, r.Last, 1) })
+		checkResult(t, "ReverseCopy", r, proptest.Equal(got, want))
+
+		want = refRotate(s, middle)
+		c = modify("Rotate", func(c proptest.Range[int]) { end = Rotate(c.R, c.First, c.First+middle, c.Last) })
+		checkResult(t, "Rotate", r, proptest.Equal(c.Elems(), want))
+		checkIndex(t, "Rotate", r, end, r.Last-middle)
+		got = copyTo("RotateCopy", func(d []int) int { return RotateCopy(r.R, d, r.First, r.First+middle, r.Last, 1) })
+		checkResult(t, "RotateCopy", r, proptest.Equal(got, want))
+
+		// The elements a shift moves from are left in a valid but
+		// unspecified state, so only the moved ones are checked.
+		left, right := r.Last-n, r.First+n
+		switch {
+		case n == 0:
+			left, right = r.Last, r.First
+		case n >= len(s):
+			left, right = r.First, r.Last
+		}
+		c = modify("ShiftLeft", func(c proptest.Range[int]) { end = ShiftLeft(c.R, c.First, c.Last, n) })
+		checkIndex(t, "ShiftLeft", r, end, left)
+		if n < len(s) {
+			checkResult(t, "ShiftLeft", r, proptest.Equal(c.R[r.First:end], s[n:]))
+		}
+		c = modify("ShiftRight", func(c proptest.Range[int]) { end = ShiftRight(c.R, c.First, c.Last, n) })
+		checkIndex(t, "ShiftRight", r, end, right)
+		if n < len(s) {
+			checkResult(t, "ShiftRight", r, proptest.Equal(c.R[end:r.Last], s[:len(s)-n]))
+		}
+
+		got = copyTo("Copy", func(d []int) int { return Copy(r.R, d, r.First, r.Last, 1) })
+		checkResult(t, "Copy", r, proptest.Equal(got, s))
+		got = copyTo("Move", func(d []int) int { return Move(r.R, d, r.First, r.Last, 1) })
+		checkResult(t, "Move", r, proptest.Equal(got, s))
+		got = copyTo("CopyN", func(d []int) int { return CopyN(r.R, d, r.First, min(n, len(s)), 1) })
+		checkResult(t, "CopyN", r, proptest.Equal(got, s[:min(n, len(s))]))
+		got = copyTo("CopyBackward", func(d []int) int {
+			end = CopyBackward(r.R, d, r.First, r.Last, 1+len(s))
+			return 1 + len(s)
+		})
+		checkResult(t, "CopyBackward", r, proptest.Equal(got, s))
+		checkIndex(t, "CopyBackward", r, end, 1)
+
+		// Copying within the same slice, in the direction each algorithm
+		// allows its ranges to overlap.
+		if middle > 0 {
+			c = modify("Copy", func(c proptest.Range[int]) { Copy(c.R, c.R, c.First+middle, c.Last, c.First) })
+			checkResult(t, "Copy", r, proptest.Equal(c.R[r.First:r.Last-middle], s[middle:]))
+			c = modify("CopyBackward", func(c proptest.Range[int]) { CopyBackward(c.R, c.R, c.First, c.Last-middle, c.Last) })
+			checkResult(t, "CopyBackward", r, proptest.Equal(c.R[r.First+middle:r.Last], s[:len(s)-middle]))
+		}
+
+		want = refRepeat(value, len(s))
+		c = modify("Fill", func(c proptest.Range[int]) { Fill(c.R, c.First, c.Last, value) })
+		checkResult(t, "Fill", r, proptest.Equal(c.Elems(), want))
+		c = modify("FillN", func(c proptest.Range[int]) { end = FillN(c.R, c.First, len(s), value) })
+		checkResult(t, "FillN", r, proptest.Equal(c.Elems(), want))
+		checkIndex(t, "FillN", r, end, r.Last)
+
+		next := 0
+		g := func() int {
+			next++
+			return next
+		}
+		c = modify("Generate", func(c proptest.Range[int]) { Generate(c.R, c.First, c.Last, g) })
+		for i, x := range c.Elems() {
+			if x != i+1 {
+				t.Errorf("Generate(%v) = %v", r, c.R)
+				break
+			}
+		}
+		next = 0
+		c = modify("GenerateN", func(c proptest.Range[int]) { end = GenerateN(c.R, c.First, len(s), g) })
+		checkIndex(t, "GenerateN", r, end, r.Last)
+		checkIndex(t, "GenerateN", r, next, len(s))
+
+		c = modify("Transform", func(c proptest.Range[int]) {
+			Transform(c.R, c.R, c.First, c.Last, c.First, func(x int) int { return -x })
+		})
+		for i, x := range c.Elems() {
+			if x != -s[i] {
+				t.Errorf("Transform(%v) = %v", r, c.R)
+				break
+			}
+		}
+
+		other := r.Clone()
+		Reverse(other.R, 0, len(other.R))
+		o := slices.Clone(other.R)
+		c = modify("SwapRanges", func(c proptest.Range[int]) { end = SwapRanges(c.R, other.R, c.First, c.Last, 0) })
+		checkIndex(t, "SwapRanges", r, end, len(s))
+		checkResult(t, "SwapRanges", r, proptest.Equal(c.Elems(), o[:len(s)]))
+		checkResult(t, "SwapRanges", r, proptest.Equal(other.R[:len(s)], s))
+	})
+}
+
+func FuzzHeap(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 3})
+	f.Add([]byte{9, 5, 1, 7, 7, 0, 3, 2, 6, 1, 1, 8})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d := proptest.NewDecoder(data)
+		r := d.Range(64, 8)
+		sorted := slices.Clone(r.Elems())
+		slices.Sort(sorted)
+
+		c := r.Clone()
+		MakeHeap(c.R, c.First, c.Last)
+		if !IsHeap(c.R, c.First, c.Last) || refIsHeapUntil(c.Elems()) != c.Len() {
+			t.Errorf("MakeHeap(%v) = %v is not a heap", r, c.R)
+		}
+		checkResult(t, "MakeHeap", r, proptest.Permutation(c.Elems(), sorted))
+		checkResult(t, "MakeHeap", r, proptest.Unchanged(r.R, c.R, r.First, r.Last))
+		SortHeap(c.R, c.First, c.Last)
+		checkResult(t, "SortHeap", r, proptest.Equal(c.Elems(), sorted))
+
+		// Build the heap one element at a time, then take the elements out
+		// again largest first.
+		c = r.Clone()
+		for last := c.First + 1; last <= c.Last; last++ {
+			PushHeapFunc(c.R, c.First, last, less[int])
+			if refIsHeapUntil(c.R[c.First:last]) != last-c.First {
+				t.Fatalf("PushHeap(%v, %d, %d) = %v is not a heap", r, c.First, last, c.R)
+			}
+		}
+		for last := c.Last; last > c.First; last-- {
+			PopHeapFunc(c.R, c.First, last, less[int])
+			if c.R[last-1] != sorted[last-1-c.First] || refIsHeapUntil(c.R[c.First:last-1]) != last-1-c.First {
+				t.Fatalf("PopHeap(%v, %d, %d) = %v", r, c.First, last, c.R)
+			}
+		}
+		checkResult(t, "PopHeap", r, proptest.Equal(c.Elems(), sorted))
+	})
+}
@@ -0,0 +1,145 @@
+package algorithm
+
+import (
+	"testing"
+
+	"gocpp/internal/proptest"
+)
+
+// The tests in this file check properties that relate the results of the
+// algorithms to their inputs or to each other, on random inputs.
+
+const iterations = 1000
+
+func TestUniqueProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r := g.Range(32, 3)
+		c := r.Clone()
+		end := Unique(c.R, c.First, c.Last)
+		got := c.R[c.First:end]
+
+		// The output has no adjacent equal elements, keeps the order of the
+		// input, and is left alone by a second call.
+		checkResult(t, "Unique", r, proptest.NoAdjacentEqual(got))
+		checkResult(t, "Unique", r, proptest.Subsequence(got, r.Elems()))
+		checkIndex(t, "Unique twice", r, Unique(c.R, c.First, end), end)
+		if r.Len() > 0 && (end == r.First || got[0] != r.R[r.First]) {
+			t.Errorf("Unique(%v) = %v does not start with the first element", r, got)
+		}
+	})
+}
+
+func TestRemoveProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r := g.Range(32, 3)
+		value := g.Intn(3)
+		c := r.Clone()
+		end := Remove(c.R, c.First, c.Last, value)
+		got := c.R[c.First:end]
+
+		// The output keeps the relative order of the elements that are not
+		// removed, and only the removed ones are missing.
+		checkResult(t, "Remove", r, proptest.Subsequence(got, r.Elems()))
+		checkIndex(t, "Remove", r, end-r.First, r.Len()-Count(r.R, r.First, r.Last, value))
+		checkIndex(t, "Remove", r, Count(c.R, c.First, end, value), 0)
+	})
+}
+
+func TestRotateProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r := g.Range(32, 8)
+		middle := r.First + g.Intn(r.Len()+1)
+		c := r.Clone()
+
+		// Rotating the element that was first back to the front is the
+		// inverse of the rotation.
+		ret := Rotate(c.R, c.First, middle, c.Last)
+		checkIndex(t, "Rotate", r, ret, r.First+r.Last-middle)
+		checkResult(t, "Rotate", r, proptest.Permutation(c.Elems(), r.Elems()))
+		Rotate(c.R, c.First, ret, c.Last)
+		checkResult(t, "Rotate inverse", r, proptest.Equal(c.R, r.R))
+	})
+}
+
+func TestReverseProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r := g.Range(32, 8)
+		c := r.Clone()
+
+		// Reversing twice is the identity, and ReverseCopy writes what
+		// Reverse leaves in place.
+		Reverse(c.R, c.First, c.Last)
+		d := make([]int, r.Len())
+		ReverseCopy(r.R, d, r.First, r.Last, 0)
+		checkResult(t, "ReverseCopy", r, proptest.Equal(d, c.Elems()))
+		Reverse(c.R, c.First, c.Last)
+		checkResult(t, "Reverse twice", r, proptest.Equal(c.R, r.R))
+	})
+}
+
+func TestBoundsProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r := g.SortedRange(32, 8)
+		value := g.Intn(10) - 1
+		lower := LowerBound(r.R, r.First, r.Last, value)
+		upper := UpperBound(r.R, r.First, r.Last, value)
+
+		// [lower, upper) is the range of elements equivalent to value.
+		if lower < r.First || lower > upper || upper > r.Last {
+			t.Errorf("LowerBound, UpperBound(%v, %d) = %d, %d", r, value, lower, upper)
+		}
+		checkIndex(t, "EqualRange", r, upper-lower, Count(r.R, r.First, r.Last, value))
+		if !AllOf(r.R, r.First, lower, func(x int) bool { return x < value }) ||
+			!AllOf(r.R, upper, r.Last, func(x int) bool { return x > value }) {
+			t.Errorf("LowerBound, UpperBound(%v, %d) = %d, %d", r, value, lower, upper)
+		}
+	})
+}
+
+func TestHeapProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r := g.Range(64, 16)
+		c := r.Clone()
+
+		// Sorting a heap gives a sorted permutation of its elements.
+		MakeHeap(c.R, c.First, c.Last)
+		checkIndex(t, "IsHeapUntil", r, IsHeapUntil(c.R, c.First, c.Last), c.Last)
+		SortHeap(c.R, c.First, c.Last)
+		checkResult(t, "SortHeap", r, proptest.SortedFunc(c.Elems(), less[int]))
+		checkResult(t, "SortHeap", r, proptest.Permutation(c.Elems(), r.Elems()))
+	})
+}
+
+func TestSetProperties(t *testing.T) {
+	proptest.Check(t, iterations, func(g *proptest.Gen) {
+		r1, r2 := g.SortedRange(16, 4), g.SortedRange(16, 4)
+		run := func(op func(r1, r2, r3 []int, first1, last1, first2, last2, d_first int) int) []int {
+			d := make([]int, r1.Len()+r2.Len())
+			return d[:op(r1.R, r2.R, d, r1.First, r1.Last, r2.First, r2.Last, 0)]
+		}
+		union := run(SetUnion[int])
+		inter := run(SetIntersection[int])
+		diff := run(SetDifference[int])
+		symdiff := run(SetSymmetricDifference[int])
+
+		for _, s := range [][]int{union, inter, diff, symdiff} {
+			if err := proptest.SortedFunc(s, less[int]); err != nil {
+				t.Errorf("set operation on %v, %v: %v", r1, r2, err)
+			}
+		}
+		// |A ∪ B| + |A ∩ B| = |A| + |B|, A ∖ B and A ∩ B partition A, and
+		// the symmetric difference is what the union has beyond the
+		// intersection.
+		if len(union)+len(inter) != r1.Len()+r2.Len() ||
+			len(diff)+len(inter) != r1.Len() ||
+			len(symdiff) != len(union)-len(inter) {
+			t.Errorf("set operations on %v, %v: union %v, intersection %v, difference %v, symmetric difference %v",
+				r1, r2, union, inter, diff, symdiff)
+		}
+		if !Includes(union, r1.R, 0, len(union), r1.First, r1.Last) ||
+			!Includes(r1.R, inter, r1.First, r1.Last, 0, len(inter)) ||
+			!Includes(r1.R, diff, r1.First, r1.Last, 0, len(diff)) {
+			t.Errorf("set operations on %v, %v: union %v, intersection %v, difference %v", r1, r2, union, inter, diff)
+		}
+	})
+}
@@ -0,0 +1,1132 @@
+package safe
+
+import (
+	"cmp"
+
+	"gocpp/algorithm"
+	"gocpp/iterator"
+	"gocpp/utility"
+)
+
+// Calls algorithm.AllOf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func AllOf[T any](r []T, first, last int, p func(T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.AllOf(r, first, last, p), nil
+}
+
+// Calls algorithm.AnyOf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func AnyOf[T any](r []T, first, last int, p func(T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.AnyOf(r, first, last, p), nil
+}
+
+// Calls algorithm.NoneOf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func NoneOf[T any](r []T, first, last int, p func(T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.NoneOf(r, first, last, p), nil
+}
+
+// Calls algorithm.Find after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Find[T comparable](r []T, first, last int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Find(r, first, last, value), nil
+}
+
+// Calls algorithm.FindIf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func FindIf[T any](r []T, first, last int, p func(T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.FindIf(r, first, last, p), nil
+}
+
+// Calls algorithm.FindIfNot after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func FindIfNot[T any](r []T, first, last int, q func(T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.FindIfNot(r, first, last, q), nil
+}
+
+// Calls algorithm.FindOpt after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func FindOpt[T comparable](r []T, first, last int, value T) (utility.Optional[int], error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return utility.Optional[int]{}, c.err
+	}
+	return algorithm.FindOpt(r, first, last, value), nil
+}
+
+// Calls algorithm.FindIfOpt after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func FindIfOpt[T any](r []T, first, last int, p func(T) bool) (utility.Optional[int], error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return utility.Optional[int]{}, c.err
+	}
+	return algorithm.FindIfOpt(r, first, last, p), nil
+}
+
+// Calls algorithm.FindIfNotOpt after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func FindIfNotOpt[T any](r []T, first, last int, q func(T) bool) (utility.Optional[int], error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return utility.Optional[int]{}, c.err
+	}
+	return algorithm.FindIfNotOpt(r, first, last, q), nil
+}
+
+// Calls algorithm.LowerBound after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotSorted if one does not hold.
+func LowerBound[T cmp.Ordered](r []T, first, last int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.partitioned("element < value", r, first, last, func(e T) bool { return e < value })
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.LowerBound(r, first, last, value), nil
+}
+
+// Calls algorithm.LowerBoundFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotSorted if one does not hold.
+func LowerBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.partitioned("comp(element, value)", r, first, last, func(e T) bool { return comp(e, value) })
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.LowerBoundFunc(r, first, last, value, comp), nil
+}
+
+// Calls algorithm.UpperBound after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotSorted if one does not hold.
+func UpperBound[T cmp.Ordered](r []T, first, last int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.partitioned("!(value < element)", r, first, last, func(e T) bool { return !(value < e) })
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.UpperBound(r, first, last, value), nil
+}
+
+// Calls algorithm.UpperBoundFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotSorted if one does not hold.
+func UpperBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.partitioned("!comp(value, element)", r, first, last, func(e T) bool { return !comp(value, e) })
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.UpperBoundFunc(r, first, last, value, comp), nil
+}
+
+// Calls algorithm.AdjacentFind after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func AdjacentFind[T comparable](r []T, first, last int) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.AdjacentFind(r, first, last), nil
+}
+
+// Calls algorithm.AdjacentFindFunc after checking its preconditions. Returns
+// an error wrapping ErrInvalidRange if one does not hold.
+func AdjacentFindFunc[T any](r []T, first, last int, p func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.AdjacentFindFunc(r, first, last, p), nil
+}
+
+// Calls algorithm.Count after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Count[T comparable](r []T, first, last int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Count(r, first, last, value), nil
+}
+
+// Calls algorithm.CountIf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func CountIf[T any](r []T, first, last int, p func(T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.CountIf(r, first, last, p), nil
+}
+
+// Calls algorithm.Mismatch after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Mismatch[T comparable](r1, r2 []T, first1, last1, first2 int) (utility.Pair[int, int], error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.input("r2", len(r2), first2, last1-first1)
+	if c.err != nil {
+		return utility.Pair[int, int]{}, c.err
+	}
+	return algorithm.Mismatch(r1, r2, first1, last1, first2), nil
+}
+
+// Calls algorithm.MismatchFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func MismatchFunc[T any](r1, r2 []T, first1, last1, first2 int, p func(T, T) bool) (utility.Pair[int, int], error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.input("r2", len(r2), first2, last1-first1)
+	if c.err != nil {
+		return utility.Pair[int, int]{}, c.err
+	}
+	return algorithm.MismatchFunc(r1, r2, first1, last1, first2, p), nil
+}
+
+// Calls algorithm.Mismatch2 after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Mismatch2[T comparable](r1, r2 []T, first1, last1, first2, last2 int) (utility.Pair[int, int], error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	if c.err != nil {
+		return utility.Pair[int, int]{}, c.err
+	}
+	return algorithm.Mismatch2(r1, r2, first1, last1, first2, last2), nil
+}
+
+// Calls algorithm.MismatchFunc2 after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func MismatchFunc2[T any](r1, r2 []T, first1, last1, first2, last2 int, p func(T, T) bool) (utility.Pair[int, int], error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	if c.err != nil {
+		return utility.Pair[int, int]{}, c.err
+	}
+	return algorithm.MismatchFunc2(r1, r2, first1, last1, first2, last2, p), nil
+}
+
+// Calls algorithm.Equal after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Equal[T comparable](r1, r2 []T, first1, last1, first2 int) (bool, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.input("r2", len(r2), first2, last1-first1)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.Equal(r1, r2, first1, last1, first2), nil
+}
+
+// Calls algorithm.EqualFunc after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func EqualFunc[T any](r1, r2 []T, first1, last1, first2 int, p func(T, T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.input("r2", len(r2), first2, last1-first1)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.EqualFunc(r1, r2, first1, last1, first2, p), nil
+}
+
+// Calls algorithm.Equal2 after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Equal2[T comparable](r1, r2 []T, first1, last1, first2, last2 int) (bool, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.Equal2(r1, r2, first1, last1, first2, last2), nil
+}
+
+// Calls algorithm.EqualFunc2 after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func EqualFunc2[T any](r1, r2 []T, first1, last1, first2, last2 int, p func(T, T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.EqualFunc2(r1, r2, first1, last1, first2, last2, p), nil
+}
+
+// Calls algorithm.Search after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Search[T comparable](r1, r2 []T, first, last, s_first, s_last int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.rng("r2[s_first, s_last)", len(r2), s_first, s_last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Search(r1, r2, first, last, s_first, s_last), nil
+}
+
+// Calls algorithm.SearchFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func SearchFunc[T any](r1, r2 []T, first, last, s_first, s_last int, p func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.rng("r2[s_first, s_last)", len(r2), s_first, s_last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SearchFunc(r1, r2, first, last, s_first, s_last, p), nil
+}
+
+// Calls algorithm.SearchN after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func SearchN[T comparable](r []T, first, last, count int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SearchN(r, first, last, count, value), nil
+}
+
+// Calls algorithm.SearchNFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func SearchNFunc[T any](r []T, first, last, count int, value T, p func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SearchNFunc(r, first, last, count, value, p), nil
+}
+
+// Calls algorithm.Copy after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one does
+// not hold.
+func Copy[T any](r1, r2 []T, first, last, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_first, last-first)
+	c.notWithin("d_first", r1, first, last, r2, d_first, false)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Copy(r1, r2, first, last, d_first), nil
+}
+
+// Calls algorithm.CopyIf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one does
+// not hold. The output is counted before it is written, so the destination
+// needs room only for the elements actually written, and only those must not
+// overlap the input. pred is therefore called twice for each element.
+func CopyIf[T any](r1, r2 []T, first, last, d_first int, pred func(T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.CopyIfTo(r1, first, last, out, pred)
+	})
+	c.dest("r2", len(r2), d_first, n)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.CopyIf(r1, r2, first, last, d_first, pred), nil
+}
+
+// Calls algorithm.CopyN after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrNegativeCount or ErrDestinationTooSmall if one
+// does not hold.
+func CopyN[T any](r1, r2 []T, first, count, result int) (int, error) {
+	var c checker[T]
+	c.count("count", count)
+	c.input("r1", len(r1), first, count)
+	c.dest("r2", len(r2), result, count)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.CopyN(r1, r2, first, count, result), nil
+}
+
+// Calls algorithm.CopyBackward after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func CopyBackward[T any](r1, r2 []T, first, last, d_last int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_last-(last-first), last-first)
+	c.notWithin("d_last", r1, first, last, r2, d_last, true)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.CopyBackward(r1, r2, first, last, d_last), nil
+}
+
+// Calls algorithm.Move after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one does
+// not hold.
+func Move[T any](r1, r2 []T, first, last, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_first, last-first)
+	c.notWithin("d_first", r1, first, last, r2, d_first, false)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Move(r1, r2, first, last, d_first), nil
+}
+
+// Calls algorithm.MoveBackward after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func MoveBackward[T any](r1, r2 []T, first, last, d_last int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_last-(last-first), last-first)
+	c.notWithin("d_last", r1, first, last, r2, d_last, true)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.MoveBackward(r1, r2, first, last, d_last), nil
+}
+
+// Calls algorithm.SwapRanges after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func SwapRanges[T any](r1, r2 []T, first1, last1, first2 int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.dest("r2", len(r2), first2, last1-first1)
+	c.noOverlap(r1, first1, last1, r2, first2, first2+last1-first1)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SwapRanges(r1, r2, first1, last1, first2), nil
+}
+
+// Calls algorithm.Transform after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange or ErrDestinationTooSmall if one does not hold.
+func Transform[T1, T2 any](r1 []T1, r2 []T2, first1, last1, d_first int, unary_op func(T1) T2) (int, error) {
+	var c checker[T1]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.dest("r2", len(r2), d_first, last1-first1)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Transform(r1, r2, first1, last1, d_first, unary_op), nil
+}
+
+// Calls algorithm.Transform2 after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrDestinationTooSmall if one does not
+// hold.
+func Transform2[T1, T2, T3 any](r1 []T1, r2 []T2, r3 []T3, first1, last1, first2, d_first int, binary_op func(T1, T2) T3) (int, error) {
+	var c checker[T1]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.input("r2", len(r2), first2, last1-first1)
+	c.dest("r3", len(r3), d_first, last1-first1)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Transform2(r1, r2, r3, first1, last1, first2, d_first, binary_op), nil
+}
+
+// Calls algorithm.Replace after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Replace[T comparable](r []T, first, last int, old_value, new_value T) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.Replace(r, first, last, old_value, new_value)
+	return nil
+}
+
+// Calls algorithm.ReplaceIf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func ReplaceIf[T any](r []T, first, last int, p func(T) bool, new_value T) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.ReplaceIf(r, first, last, p, new_value)
+	return nil
+}
+
+// Calls algorithm.ReplaceCopy after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func ReplaceCopy[T comparable](r1, r2 []T, first, last, d_first int, old_value, new_value T) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_first, last-first)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+last-first)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.ReplaceCopy(r1, r2, first, last, d_first, old_value, new_value), nil
+}
+
+// Calls algorithm.ReplaceCopyIf after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func ReplaceCopyIf[T any](r1, r2 []T, first, last, d_first int, p func(T) bool, new_value T) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_first, last-first)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+last-first)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.ReplaceCopyIf(r1, r2, first, last, d_first, p, new_value), nil
+}
+
+// Calls algorithm.Fill after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Fill[T any](r []T, first, last int, value T) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.Fill(r, first, last, value)
+	return nil
+}
+
+// Calls algorithm.FillN after checking its preconditions. Returns an error
+// wrapping ErrDestinationTooSmall if one does not hold.
+func FillN[T any](r []T, first, count int, value T) (int, error) {
+	var c checker[T]
+	c.dest("r", len(r), first, max(count, 0))
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.FillN(r, first, count, value), nil
+}
+
+// Calls algorithm.Generate after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Generate[T any](r []T, first, last int, g func() T) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.Generate(r, first, last, g)
+	return nil
+}
+
+// Calls algorithm.GenerateN after checking its preconditions. Returns an error
+// wrapping ErrDestinationTooSmall if one does not hold.
+func GenerateN[T any](r []T, first, count int, g func() T) (int, error) {
+	var c checker[T]
+	c.dest("r", len(r), first, max(count, 0))
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.GenerateN(r, first, count, g), nil
+}
+
+// Calls algorithm.Remove after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Remove[T comparable](r []T, first, last int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Remove(r, first, last, value), nil
+}
+
+// Calls algorithm.RemoveIf after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func RemoveIf[T any](r []T, first, last int, p func(T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.RemoveIf(r, first, last, p), nil
+}
+
+// Calls algorithm.RemoveCopy after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one does
+// not hold. The output is counted before it is written, so the destination
+// needs room only for the elements actually written, and only those must not
+// overlap the input.
+func RemoveCopy[T comparable](r1, r2 []T, first, last, d_first int, value T) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.RemoveCopyTo(r1, first, last, out, value)
+	})
+	c.dest("r2", len(r2), d_first, n)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.RemoveCopy(r1, r2, first, last, d_first, value), nil
+}
+
+// Calls algorithm.RemoveCopyIf after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold. The output is counted before it is written, so the destination
+// needs room only for the elements actually written, and only those must not
+// overlap the input. p is therefore called twice for each element.
+func RemoveCopyIf[T any](r1, r2 []T, first, last, d_first int, p func(T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.RemoveCopyIfTo(r1, first, last, out, p)
+	})
+	c.dest("r2", len(r2), d_first, n)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.RemoveCopyIf(r1, r2, first, last, d_first, p), nil
+}
+
+// Calls algorithm.Unique after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Unique[T comparable](r []T, first, last int) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Unique(r, first, last), nil
+}
+
+// Calls algorithm.UniqueFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func UniqueFunc[T any](r []T, first, last int, p func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.UniqueFunc(r, first, last, p), nil
+}
+
+// Calls algorithm.UniqueCopy after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one does
+// not hold. The output is counted before it is written, so the destination
+// needs room only for the elements actually written, and only those must not
+// overlap the input.
+func UniqueCopy[T comparable](r1, r2 []T, first, last, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.UniqueCopyTo(r1, first, last, out)
+	})
+	c.dest("r2", len(r2), d_first, n)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.UniqueCopy(r1, r2, first, last, d_first), nil
+}
+
+// Calls algorithm.UniqueCopyFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold. The output is counted before it is written, so the destination
+// needs room only for the elements actually written, and only those must not
+// overlap the input. p is therefore called twice for each element.
+func UniqueCopyFunc[T any](r1, r2 []T, first, last, d_first int, p func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.UniqueCopyFuncTo(r1, first, last, out, p)
+	})
+	c.dest("r2", len(r2), d_first, n)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.UniqueCopyFunc(r1, r2, first, last, d_first, p), nil
+}
+
+// Calls algorithm.Reverse after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Reverse[T any](r []T, first, last int) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.Reverse(r, first, last)
+	return nil
+}
+
+// Calls algorithm.ReverseCopy after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func ReverseCopy[T any](r1, r2 []T, first, last, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.dest("r2", len(r2), d_first, last-first)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+last-first)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.ReverseCopy(r1, r2, first, last, d_first), nil
+}
+
+// Calls algorithm.Rotate after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func Rotate[T any](r []T, first, middle, last int) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.middle("middle", first, middle, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.Rotate(r, first, middle, last), nil
+}
+
+// Calls algorithm.RotateCopy after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall or ErrOverlap if one
+// does not hold.
+func RotateCopy[T any](r1, r2 []T, first, n_first, last, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first, last)", len(r1), first, last)
+	c.middle("n_first", first, n_first, last)
+	c.dest("r2", len(r2), d_first, last-first)
+	c.noOverlap(r1, first, last, r2, d_first, d_first+last-first)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.RotateCopy(r1, r2, first, n_first, last, d_first), nil
+}
+
+// Calls algorithm.ShiftLeft after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange or ErrNegativeCount if one does not hold.
+func ShiftLeft[T any](r []T, first, last, n int) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.count("n", n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.ShiftLeft(r, first, last, n), nil
+}
+
+// Calls algorithm.ShiftRight after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNegativeCount if one does not hold.
+func ShiftRight[T any](r []T, first, last, n int) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.count("n", n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.ShiftRight(r, first, last, n), nil
+}
+
+// Calls algorithm.Includes after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange or ErrNotSorted if one does not hold.
+func Includes[T cmp.Ordered](r1, r2 []T, first1, last1, first2, last2 int) (bool, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, cmp.Less[T])
+	c.sorted("r2[first2, last2)", r2, first2, last2, cmp.Less[T])
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.Includes(r1, r2, first1, last1, first2, last2), nil
+}
+
+// Calls algorithm.IncludesFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotSorted if one does not hold.
+func IncludesFunc[T any](r1, r2 []T, first1, last1, first2, last2 int, comp func(T, T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, comp)
+	c.sorted("r2[first2, last2)", r2, first2, last2, comp)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.IncludesFunc(r1, r2, first1, last1, first2, last2, comp), nil
+}
+
+// Calls algorithm.SetDifference after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap or
+// ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs.
+func SetDifference[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, cmp.Less[T])
+	c.sorted("r2[first2, last2)", r2, first2, last2, cmp.Less[T])
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetDifferenceTo(r1, r2, first1, last1, first2, last2, out)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetDifference(r1, r2, r3, first1, last1, first2, last2, d_first), nil
+}
+
+// Calls algorithm.SetDifferenceFunc after checking its preconditions. Returns
+// an error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap or
+// ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs. Counting the output
+// calls comp as often again as the algorithm does.
+func SetDifferenceFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, comp)
+	c.sorted("r2[first2, last2)", r2, first2, last2, comp)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetDifferenceFuncTo(r1, r2, first1, last1, first2, last2, out, comp)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetDifferenceFunc(r1, r2, r3, first1, last1, first2, last2, d_first, comp), nil
+}
+
+// Calls algorithm.SetIntersection after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap or
+// ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs.
+func SetIntersection[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, cmp.Less[T])
+	c.sorted("r2[first2, last2)", r2, first2, last2, cmp.Less[T])
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetIntersectionTo(r1, r2, first1, last1, first2, last2, out)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetIntersection(r1, r2, r3, first1, last1, first2, last2, d_first), nil
+}
+
+// Calls algorithm.SetIntersectionFunc after checking its preconditions. Returns
+// an error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap or
+// ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs. Counting the output
+// calls comp as often again as the algorithm does.
+func SetIntersectionFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, comp)
+	c.sorted("r2[first2, last2)", r2, first2, last2, comp)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetIntersectionFuncTo(r1, r2, first1, last1, first2, last2, out, comp)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetIntersectionFunc(r1, r2, r3, first1, last1, first2, last2, d_first, comp), nil
+}
+
+// Calls algorithm.SetSymmetricDifference after checking its preconditions.
+// Returns an error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap
+// or ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs.
+func SetSymmetricDifference[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, cmp.Less[T])
+	c.sorted("r2[first2, last2)", r2, first2, last2, cmp.Less[T])
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetSymmetricDifferenceTo(r1, r2, first1, last1, first2, last2, out)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetSymmetricDifference(r1, r2, r3, first1, last1, first2, last2, d_first), nil
+}
+
+// Calls algorithm.SetSymmetricDifferenceFunc after checking its preconditions.
+// Returns an error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap
+// or ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs. Counting the output
+// calls comp as often again as the algorithm does.
+func SetSymmetricDifferenceFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, comp)
+	c.sorted("r2[first2, last2)", r2, first2, last2, comp)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetSymmetricDifferenceFuncTo(r1, r2, first1, last1, first2, last2, out, comp)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetSymmetricDifferenceFunc(r1, r2, r3, first1, last1, first2, last2, d_first, comp), nil
+}
+
+// Calls algorithm.SetUnion after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap or ErrNotSorted
+// if one does not hold. The output is counted before it is written, so the
+// destination needs room only for the elements actually written, and only those
+// must not overlap the inputs.
+func SetUnion[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, cmp.Less[T])
+	c.sorted("r2[first2, last2)", r2, first2, last2, cmp.Less[T])
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetUnionTo(r1, r2, first1, last1, first2, last2, out)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetUnion(r1, r2, r3, first1, last1, first2, last2, d_first), nil
+}
+
+// Calls algorithm.SetUnionFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange, ErrDestinationTooSmall, ErrOverlap or
+// ErrNotSorted if one does not hold. The output is counted before it is
+// written, so the destination needs room only for the elements actually
+// written, and only those must not overlap the inputs. Counting the output
+// calls comp as often again as the algorithm does.
+func SetUnionFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r1[first1, last1)", len(r1), first1, last1)
+	c.rng("r2[first2, last2)", len(r2), first2, last2)
+	c.sorted("r1[first1, last1)", r1, first1, last1, comp)
+	c.sorted("r2[first2, last2)", r2, first2, last2, comp)
+	n := c.written(func(out iterator.Sink[T]) {
+		algorithm.SetUnionFuncTo(r1, r2, first1, last1, first2, last2, out, comp)
+	})
+	c.dest("r3", len(r3), d_first, n)
+	c.noOverlap(r1, first1, last1, r3, d_first, d_first+n)
+	c.noOverlap(r2, first2, last2, r3, d_first, d_first+n)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.SetUnionFunc(r1, r2, r3, first1, last1, first2, last2, d_first, comp), nil
+}
+
+// Calls algorithm.PushHeap after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange or ErrNotHeap if one does not hold.
+func PushHeap[T cmp.Ordered](r []T, first, last int) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.nonEmpty("r[first, last)", first, last)
+	c.heap("r[first, last - 1)", r, first, last-1, cmp.Less[T])
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.PushHeap(r, first, last)
+	return nil
+}
+
+// Calls algorithm.PushHeapFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotHeap if one does not hold.
+func PushHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.nonEmpty("r[first, last)", first, last)
+	c.heap("r[first, last - 1)", r, first, last-1, comp)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.PushHeapFunc(r, first, last, comp)
+	return nil
+}
+
+// Calls algorithm.PopHeap after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange or ErrNotHeap if one does not hold.
+func PopHeap[T cmp.Ordered](r []T, first, last int) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.nonEmpty("r[first, last)", first, last)
+	c.heap("r[first, last)", r, first, last, cmp.Less[T])
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.PopHeap(r, first, last)
+	return nil
+}
+
+// Calls algorithm.PopHeapFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotHeap if one does not hold.
+func PopHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.nonEmpty("r[first, last)", first, last)
+	c.heap("r[first, last)", r, first, last, comp)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.PopHeapFunc(r, first, last, comp)
+	return nil
+}
+
+// Calls algorithm.MakeHeap after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func MakeHeap[T cmp.Ordered](r []T, first, last int) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.MakeHeap(r, first, last)
+	return nil
+}
+
+// Calls algorithm.MakeHeapFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func MakeHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.MakeHeapFunc(r, first, last, comp)
+	return nil
+}
+
+// Calls algorithm.SortHeap after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange or ErrNotHeap if one does not hold.
+func SortHeap[T cmp.Ordered](r []T, first, last int) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.heap("r[first, last)", r, first, last, cmp.Less[T])
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.SortHeap(r, first, last)
+	return nil
+}
+
+// Calls algorithm.SortHeapFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange or ErrNotHeap if one does not hold.
+func SortHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) error {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	c.heap("r[first, last)", r, first, last, comp)
+	if c.err != nil {
+		return c.err
+	}
+	algorithm.SortHeapFunc(r, first, last, comp)
+	return nil
+}
+
+// Calls algorithm.IsHeapUntil after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func IsHeapUntil[T cmp.Ordered](r []T, first, last int) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.IsHeapUntil(r, first, last), nil
+}
+
+// Calls algorithm.IsHeapUntilFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func IsHeapUntilFunc[T any](r []T, first, last int, comp func(T, T) bool) (int, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return algorithm.IsHeapUntilFunc(r, first, last, comp), nil
+}
+
+// Calls algorithm.IsHeap after checking its preconditions. Returns an error
+// wrapping ErrInvalidRange if one does not hold.
+func IsHeap[T cmp.Ordered](r []T, first, last int) (bool, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.IsHeap(r, first, last), nil
+}
+
+// Calls algorithm.IsHeapFunc after checking its preconditions. Returns an
+// error wrapping ErrInvalidRange if one does not hold.
+func IsHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) (bool, error) {
+	var c checker[T]
+	c.rng("r[first, last)", len(r), first, last)
+	if c.err != nil {
+		return false, c.err
+	}
+	return algorithm.IsHeapFunc(r, first, last, comp), nil
+}
@@ -0,0 +1,173 @@
+// Package safe provides variants of the algorithms in package algorithm that
+// validate their arguments and return an error instead of panicking or
+// corrupting memory. Each function checks the preconditions of the algorithm
+// of the same name, then calls it. The errors wrap one of the sentinel errors
+// below, so callers can test for them with errors.Is.
+//
+// The checks take linear time in the size of the input ranges when an
+// algorithm requires sorted or heap-ordered input, so the binary searches in
+// this package are no faster than a linear search.
+package safe
+
+import (
+	"errors"
+	"fmt"
+
+	"gocpp/internal/alias"
+	"gocpp/iterator"
+)
+
+var (
+	// ErrInvalidRange is returned when a range [first, last) is not within
+	// its slice or first > last, or a position is not within its range.
+	ErrInvalidRange = errors.New("safe: invalid range")
+
+	// ErrDestinationTooSmall is returned when the destination slice has too
+	// few elements after the output position to hold the result.
+	ErrDestinationTooSmall = errors.New("safe: destination too small")
+
+	// ErrOverlap is returned when the source and destination ranges of an
+	// algorithm that does not allow it overlap.
+	ErrOverlap = errors.New("safe: overlapping ranges")
+
+	// ErrNotSorted is returned when an input range that must be sorted, or
+	// partitioned with respect to the searched value, is not.
+	ErrNotSorted = errors.New("safe: range not sorted")
+
+	// ErrNotHeap is returned when an input range that must be a heap is not.
+	ErrNotHeap = errors.New("safe: range not a heap")
+
+	// ErrNegativeCount is returned when a count or shift that must not be
+	// negative is.
+	ErrNegativeCount = errors.New("safe: negative count")
+)
+
+// checker records the first violated precondition. Once err is set, the
+// remaining checks do nothing, so later checks may assume that earlier ones
+// passed.
+type checker[T any] struct {
+	err error
+}
+
+// rng checks that [first, last) is a valid range of a slice of length n.
+func (c *checker[T]) rng(name string, n, first, last int) {
+	if c.err == nil && (first < 0 || first > last || last > n) {
+		c.err = fmt.Errorf("%w: %s = [%d, %d) is not within a slice of length %d", ErrInvalidRange, name, first, last, n)
+	}
+}
+
+// middle checks that middle is within [first, last].
+func (c *checker[T]) middle(name string, first, middle, last int) {
+	if c.err == nil && (middle < first || middle > last) {
+		c.err = fmt.Errorf("%w: %s = %d is not within [%d, %d]", ErrInvalidRange, name, middle, first, last)
+	}
+}
+
+// nonEmpty checks that [first, last) holds an element.
+func (c *checker[T]) nonEmpty(name string, first, last int) {
+	if c.err == nil && first == last {
+		c.err = fmt.Errorf("%w: %s is empty", ErrInvalidRange, name)
+	}
+}
+
+// input checks that an input slice of length n holds count elements starting
+// at index first.
+func (c *checker[T]) input(name string, n, first, count int) {
+	if c.err == nil && (first < 0 || first > n || count > n-first) {
+		c.err = fmt.Errorf("%w: %s needs %d elements at index %d but has length %d", ErrInvalidRange, name, count, first, n)
+	}
+}
+
+// dest checks that a destination slice of length n holds count elements
+// starting at index first.
+func (c *checker[T]) dest(name string, n, first, count int) {
+	if c.err == nil && (first < 0 || first > n || count > n-first) {
+		c.err = fmt.Errorf("%w: %s needs %d elements at index %d but has length %d", ErrDestinationTooSmall, name, count, first, n)
+	}
+}
+
+// count checks that n is not negative.
+func (c *checker[T]) count(name string, n int) {
+	if c.err == nil && n < 0 {
+		c.err = fmt.Errorf("%w: %s = %d", ErrNegativeCount, name, n)
+	}
+}
+
+// noOverlap checks that r1[first1, last1) and r2[first2, last2) share no
+// element.
+func (c *checker[T]) noOverlap(r1 []T, first1, last1 int, r2 []T, first2, last2 int) {
+	if c.err == nil && alias.Overlap(r1[first1:last1], r2[first2:last2]) {
+		c.err = fmt.Errorf("%w: the source and destination ranges share elements", ErrOverlap)
+	}
+}
+
+// written returns the number of elements that f, which runs an algorithm into
+// out, writes. It does not run f if an earlier check failed, so f may assume
+// that the input ranges are valid.
+func (c *checker[T]) written(f func(out iterator.Sink[T])) int {
+	if c.err != nil {
+		return 0
+	}
+	out := iterator.Counter[T](iterator.Discard[T]())
+	f(out)
+	return out.Count()
+}
+
+// notWithin checks that the element r2[pos] is not one of r1[first, last). If
+// open is true, the interval (first, last] is checked instead, as for the
+// d_last argument of the backward algorithms.
+func (c *checker[T]) notWithin(name string, r1 []T, first, last int, r2 []T, pos int, open bool) {
+	if c.err != nil || first == last {
+		return
+	}
+	interval := "[first, last)"
+	if open {
+		pos, interval = pos-1, "(first, last]"
+	}
+	if alias.Overlap(r1[first:last], r2[pos:pos+1]) {
+		c.err = fmt.Errorf("%w: %s is within the source range %s", ErrOverlap, name, interval)
+	}
+}
+
+// sorted checks that r[first, last) is sorted with respect to comp.
+func (c *checker[T]) sorted(name string, r []T, first, last int, comp func(T, T) bool) {
+	if c.err != nil {
+		return
+	}
+	for i := first; i+1 < last; i++ {
+		if comp(r[i+1], r[i]) {
+			c.err = fmt.Errorf("%w: %s has element %d ordered before element %d", ErrNotSorted, name, i+1, i)
+			return
+		}
+	}
+}
+
+// partitioned checks that every element of r[first, last) for which p holds
+// precedes every element for which it does not.
+func (c *checker[T]) partitioned(what string, r []T, first, last int, p func(T) bool) {
+	if c.err != nil {
+		return
+	}
+	for first != last && p(r[first]) {
+		first++
+	}
+	for ; first != last; first++ {
+		if p(r[first]) {
+			c.err = fmt.Errorf("%w: r[first, last) is not partitioned with respect to %s at index %d", ErrNotSorted, what, first)
+			return
+		}
+	}
+}
+
+// heap checks that r[first, last) is a max heap with respect to comp.
+func (c *checker[T]) heap(name string, r []T, first, last int, comp func(T, T) bool) {
+	if c.err != nil {
+		return
+	}
+	for child := 1; child < last-first; child++ {
+		if comp(r[first+(child-1)/2], r[first+child]) {
+			c.err = fmt.Errorf("%w: %s has element %d greater than its parent", ErrNotHeap, name, first+child)
+			return
+		}
+	}
+}
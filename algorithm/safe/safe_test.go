@@ -0,0 +1,183 @@
+package safe
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func even(x int) bool {
+	return x%2 == 0
+}
+
+func sameParity(a, b int) bool {
+	return a%2 == b%2
+}
+
+// call runs an algorithm that writes its output to r2 at d_first and returns
+// the end of the output.
+type call func(r2 []int, d_first int) (int, error)
+
+// variableOutput returns the algorithms whose output size depends on the
+// input, with the output they write for in1 and in2.
+func variableOutput(in1, in2 []int) []struct {
+	name string
+	f    call
+	want []int
+} {
+	n1, n2 := len(in1), len(in2)
+	return []struct {
+		name string
+		f    call
+		want []int
+	}{
+		{"CopyIf", func(r2 []int, d int) (int, error) { return CopyIf(in1, r2, 0, n1, d, even) }, []int{2, 2, 4}},
+		{"RemoveCopy", func(r2 []int, d int) (int, error) { return RemoveCopy(in1, r2, 0, n1, d, 2) }, []int{1, 3, 4, 5}},
+		{"RemoveCopyIf", func(r2 []int, d int) (int, error) { return RemoveCopyIf(in1, r2, 0, n1, d, even) }, []int{1, 3, 5}},
+		{"UniqueCopy", func(r2 []int, d int) (int, error) { return UniqueCopy(in1, r2, 0, n1, d) }, []int{1, 2, 3, 4, 5}},
+		{"UniqueCopyFunc", func(r2 []int, d int) (int, error) { return UniqueCopyFunc(in1, r2, 0, n1, d, sameParity) }, []int{1, 2, 3, 4, 5}},
+		{"SetDifference", func(r3 []int, d int) (int, error) { return SetDifference(in1, in2, r3, 0, n1, 0, n2, d) }, []int{1, 2, 5}},
+		{"SetDifferenceFunc", func(r3 []int, d int) (int, error) {
+			return SetDifferenceFunc(in1, in2, r3, 0, n1, 0, n2, d, cmp.Less[int])
+		}, []int{1, 2, 5}},
+		{"SetIntersection", func(r3 []int, d int) (int, error) { return SetIntersection(in1, in2, r3, 0, n1, 0, n2, d) }, []int{2, 3, 4}},
+		{"SetIntersectionFunc", func(r3 []int, d int) (int, error) {
+			return SetIntersectionFunc(in1, in2, r3, 0, n1, 0, n2, d, cmp.Less[int])
+		}, []int{2, 3, 4}},
+		{"SetSymmetricDifference", func(r3 []int, d int) (int, error) {
+			return SetSymmetricDifference(in1, in2, r3, 0, n1, 0, n2, d)
+		}, []int{1, 2, 5, 6}},
+		{"SetSymmetricDifferenceFunc", func(r3 []int, d int) (int, error) {
+			return SetSymmetricDifferenceFunc(in1, in2, r3, 0, n1, 0, n2, d, cmp.Less[int])
+		}, []int{1, 2, 5, 6}},
+		{"SetUnion", func(r3 []int, d int) (int, error) { return SetUnion(in1, in2, r3, 0, n1, 0, n2, d) }, []int{1, 2, 2, 3, 4, 5, 6}},
+		{"SetUnionFunc", func(r3 []int, d int) (int, error) {
+			return SetUnionFunc(in1, in2, r3, 0, n1, 0, n2, d, cmp.Less[int])
+		}, []int{1, 2, 2, 3, 4, 5, 6}},
+	}
+}
+
+func TestExactDestination(t *testing.T) {
+	in1, in2 := []int{1, 2, 2, 3, 4, 5}, []int{2, 3, 4, 6}
+	for _, tt := range variableOutput(in1, in2) {
+		// A destination with room for exactly the output is enough, wherever
+		// the output starts.
+		for _, d := range []int{0, 2} {
+			r2 := make([]int, d+len(tt.want))
+			end, err := tt.f(r2, d)
+			if err != nil || end != len(r2) || !slices.Equal(r2[d:], tt.want) {
+				t.Errorf("%s into %d elements at %d = %d, %v: %v, want %v", tt.name, len(r2), d, end, err, r2[d:], tt.want)
+			}
+		}
+
+		// One element less is too small, and nothing is written.
+		r2 := make([]int, len(tt.want)-1)
+		for i := range r2 {
+			r2[i] = -1
+		}
+		if _, err := tt.f(r2, 0); !errors.Is(err, ErrDestinationTooSmall) {
+			t.Errorf("%s into %d elements returned %v, want ErrDestinationTooSmall", tt.name, len(r2), err)
+		}
+		if slices.ContainsFunc(r2, func(x int) bool { return x != -1 }) {
+			t.Errorf("%s wrote %v before reporting an error", tt.name, r2)
+		}
+	}
+}
+
+func TestOverlapWritten(t *testing.T) {
+	// The output may be written after the input in the same slice, as long
+	// as the elements written do not overlap it, even if the worst-case
+	// output would.
+	r := []int{1, 2, 3, 4, 0, 0}
+	if end, err := CopyIf(r, r, 0, 4, 4, even); err != nil || end != 6 || !slices.Equal(r, []int{1, 2, 3, 4, 2, 4}) {
+		t.Errorf("CopyIf after its input = %d, %v: %v", end, err, r)
+	}
+	r = []int{1, 1, 2, 2, 0, 0}
+	if end, err := UniqueCopy(r, r, 0, 4, 4); err != nil || end != 6 || !slices.Equal(r, []int{1, 1, 2, 2, 1, 2}) {
+		t.Errorf("UniqueCopy after its input = %d, %v: %v", end, err, r)
+	}
+	s := []int{1, 2, 1, 3, 0}
+	if end, err := SetIntersection(s, s, s, 0, 2, 2, 4, 4); err != nil || end != 5 || s[4] != 1 {
+		t.Errorf("SetIntersection after its inputs = %d, %v: %v", end, err, s)
+	}
+
+	// Writing over the input is still an error.
+	r = []int{1, 2, 3, 4, 0, 0}
+	if _, err := CopyIf(r, r, 0, 4, 3, even); !errors.Is(err, ErrOverlap) {
+		t.Errorf("CopyIf over its input returned %v, want ErrOverlap", err)
+	}
+	s = []int{1, 2, 1, 3, 0, 0}
+	if _, err := SetUnion(s, s, s, 0, 2, 2, 4, 3); !errors.Is(err, ErrOverlap) {
+		t.Errorf("SetUnion over its second input returned %v, want ErrOverlap", err)
+	}
+	if !slices.Equal(r, []int{1, 2, 3, 4, 0, 0}) || !slices.Equal(s, []int{1, 2, 1, 3, 0, 0}) {
+		t.Errorf("overlapping calls wrote %v and %v", r, s)
+	}
+}
+
+func TestCountingPass(t *testing.T) {
+	// The output is counted in a separate pass, so the predicate is called
+	// twice for each element, and not at all if an earlier check fails.
+	calls := 0
+	pred := func(x int) bool {
+		calls++
+		return even(x)
+	}
+	r := []int{1, 2, 3, 4}
+	if _, err := CopyIf(r, make([]int, 2), 0, 4, 0, pred); err != nil || calls != 8 {
+		t.Errorf("CopyIf returned %v after %d calls, want 8", err, calls)
+	}
+	calls = 0
+	if _, err := CopyIf(r, make([]int, 2), 0, 5, 0, pred); !errors.Is(err, ErrInvalidRange) || calls != 0 {
+		t.Errorf("CopyIf of an invalid range returned %v after %d calls", err, calls)
+	}
+	calls = 0
+	comp := func(a, b int) bool {
+		calls++
+		return a < b
+	}
+	if _, err := SetUnionFunc([]int{2, 1}, []int{1}, make([]int, 3), 0, 2, 0, 1, 0, comp); !errors.Is(err, ErrNotSorted) || calls != 1 {
+		t.Errorf("SetUnionFunc of unsorted input returned %v after %d calls", err, calls)
+	}
+}
+
+func TestPreconditions(t *testing.T) {
+	r := []int{1, 2, 3, 4, 5}
+	heap := []int{5, 4, 3, 2, 1}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Find beyond len", second(Find(r, 0, 6, 1)), ErrInvalidRange},
+		{"Find reversed", second(Find(r, 3, 2, 1)), ErrInvalidRange},
+		{"Find negative", second(Find(r, -1, 2, 1)), ErrInvalidRange},
+		{"Find", second(Find(r, 0, 5, 1)), nil},
+		{"LowerBound unsorted", second(LowerBound([]int{3, 1, 2}, 0, 3, 2)), ErrNotSorted},
+		{"LowerBound partitioned", second(LowerBound([]int{1, 0, 3, 2}, 0, 4, 2)), nil},
+		{"UpperBoundFunc unsorted", second(UpperBoundFunc([]int{3, 1, 2}, 0, 3, 2, cmp.Less[int])), ErrNotSorted},
+		{"Copy too small", second(Copy(r, make([]int, 4), 0, 5, 0)), ErrDestinationTooSmall},
+		{"Copy onto itself", second(Copy(r, r, 1, 4, 2)), ErrOverlap},
+		{"Copy before itself", second(Copy(r, r, 1, 4, 0)), nil},
+		{"CopyBackward into itself", second(CopyBackward(r, r, 0, 3, 3)), ErrOverlap},
+		{"CopyBackward after itself", second(CopyBackward(r, r, 0, 3, 4)), nil},
+		{"CopyN negative", second(CopyN(r, r, 0, -1, 0)), ErrNegativeCount},
+		{"SwapRanges overlap", second(SwapRanges(r, r, 0, 3, 2)), ErrOverlap},
+		{"ShiftLeft negative", second(ShiftLeft(r, 0, 5, -1)), ErrNegativeCount},
+		{"Includes unsorted", second(Includes([]int{2, 1}, r, 0, 2, 0, 2)), ErrNotSorted},
+		{"PushHeap empty", PushHeap(r, 0, 0), ErrInvalidRange},
+		{"PushHeap not a heap", PushHeap([]int{1, 2, 3}, 0, 3), ErrNotHeap},
+		{"PushHeap", PushHeap(heap, 0, 5), nil},
+		{"PopHeapFunc not a heap", PopHeapFunc([]int{1, 2}, 0, 2, cmp.Less[int]), ErrNotHeap},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) || tt.want == nil && tt.err != nil {
+			t.Errorf("%s returned %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
@@ -0,0 +1,355 @@
+package algorithm
+
+import (
+	"cmp"
+
+	"gocpp/iterator"
+)
+
+// The functions in this file are the variants of the copying algorithms that
+// write to an iterator.Sink, such as an iterator.BackInserter, instead of
+// assigning to a preallocated destination range. They make a single forward
+// pass over each input range and never revisit an element they have passed.
+
+// Copies the elements in the range r[first, last) to out, starting from first
+// and proceeding to last.
+func CopyTo[T any](r []T, first, last int, out iterator.Sink[T]) {
+	if debug {
+		checkRange("CopyTo", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		out.Put(r[first])
+	}
+}
+
+// Copies the elements in the range r[first, last) for which the predicate
+// pred returns true to out. The relative order of the elements that are
+// copied is preserved.
+func CopyIfTo[T any](r []T, first, last int, out iterator.Sink[T], pred func(T) bool) {
+	if debug {
+		checkRange("CopyIfTo", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if pred(r[first]) {
+			out.Put(r[first])
+		}
+	}
+}
+
+// Copies exactly count values from the range beginning at r[first] to out if
+// count > 0. Does nothing otherwise.
+func CopyNTo[T any](r []T, first, count int, out iterator.Sink[T]) {
+	if debug && count > 0 {
+		checkRoom("CopyNTo", "r", len(r), first, count)
+	}
+
+	for i := 0; i < count; i++ {
+		out.Put(r[first])
+		first++
+	}
+}
+
+// Moves the elements in the range r[first, last) to out, starting from
+// r[first] and proceeding to r[last - 1].
+func MoveTo[T any](r []T, first, last int, out iterator.Sink[T]) {
+	if debug {
+		checkRange("MoveTo", "r[first, last)", len(r), first, last)
+	}
+
+	CopyTo(r, first, last, out)
+}
+
+// Applies the unary operation unary_op to the elements of the range
+// r[first1, last1) and writes the results to out in order.
+func TransformTo[T1, T2 any](r []T1, first1, last1 int, out iterator.Sink[T2], unary_op func(T1) T2) {
+	if debug {
+		checkRange("TransformTo", "r[first1, last1)", len(r), first1, last1)
+	}
+
+	for ; first1 != last1; first1++ {
+		out.Put(unary_op(r[first1]))
+	}
+}
+
+// Applies the binary operation binary_op to pairs of elements from two
+// ranges, one defined by r1[first1, last1) and the other beginning at
+// r2[first2], and writes the results to out in order.
+func Transform2To[T1, T2, T3 any](r1 []T1, r2 []T2, first1, last1, first2 int, out iterator.Sink[T3], binary_op func(T1, T2) T3) {
+	if debug {
+		checkRange("Transform2To", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("Transform2To", "r2", len(r2), first2, last1-first1)
+	}
+
+	for ; first1 != last1; first1++ {
+		out.Put(binary_op(r1[first1], r2[first2]))
+		first2++
+	}
+}
+
+// Copies the elements from the range r[first, last) to out, replacing all
+// elements that are equal to old_value (using operator==) with new_value.
+func ReplaceCopyTo[T comparable](r []T, first, last int, out iterator.Sink[T], old_value, new_value T) {
+	if debug {
+		checkRange("ReplaceCopyTo", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if r[first] == old_value {
+			out.Put(new_value)
+		} else {
+			out.Put(r[first])
+		}
+	}
+}
+
+// Copies the elements from the range r[first, last) to out, replacing all
+// elements for which predicate p returns true with new_value.
+func ReplaceCopyIfTo[T any](r []T, first, last int, out iterator.Sink[T], p func(T) bool, new_value T) {
+	if debug {
+		checkRange("ReplaceCopyIfTo", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if p(r[first]) {
+			out.Put(new_value)
+		} else {
+			out.Put(r[first])
+		}
+	}
+}
+
+// Copies elements from the range r[first, last) to out, omitting the elements
+// that are equal to value.
+func RemoveCopyTo[T comparable](r []T, first, last int, out iterator.Sink[T], value T) {
+	if debug {
+		checkRange("RemoveCopyTo", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if r[first] != value {
+			out.Put(r[first])
+		}
+	}
+}
+
+// Copies elements from the range r[first, last) to out, omitting the elements
+// for which predicate p returns true.
+func RemoveCopyIfTo[T any](r []T, first, last int, out iterator.Sink[T], p func(T) bool) {
+	if debug {
+		checkRange("RemoveCopyIfTo", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if !p(r[first]) {
+			out.Put(r[first])
+		}
+	}
+}
+
+// Copies the elements from the range r[first, last) to out in such a way that
+// there are no consecutive equal elements. Only the first element of each
+// group of equal elements is copied. Elements are compared using operator==.
+func UniqueCopyTo[T comparable](r []T, first, last int, out iterator.Sink[T]) {
+	if debug {
+		checkRange("UniqueCopyTo", "r[first, last)", len(r), first, last)
+	}
+
+	UniqueCopyFuncTo(r, first, last, out, func(a, b T) bool { return a == b })
+}
+
+// Copies the elements from the range r[first, last) to out in such a way that
+// there are no consecutive equal elements. Only the first element of each
+// group of equal elements is copied. Elements are compared using the given
+// binary predicate p, whose first argument is the element that was copied
+// last. The behavior is undefined if it is not an equivalence relation.
+func UniqueCopyFuncTo[T any](r []T, first, last int, out iterator.Sink[T], p func(T, T) bool) {
+	if debug {
+		checkRange("UniqueCopyFuncTo", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return
+	}
+
+	copied := r[first]
+	out.Put(copied)
+	for first++; first != last; first++ {
+		if !p(copied, r[first]) {
+			copied = r[first]
+			out.Put(copied)
+		}
+	}
+}
+
+// Copies the elements from the range r[first, last) to out in reverse order.
+func ReverseCopyTo[T any](r []T, first, last int, out iterator.Sink[T]) {
+	if debug {
+		checkRange("ReverseCopyTo", "r[first, last)", len(r), first, last)
+	}
+
+	for first != last {
+		last--
+		out.Put(r[last])
+	}
+}
+
+// Copies the elements from the range r[first, last) to out in such a way that
+// r[n_first] is written first and r[n_first - 1] last.
+func RotateCopyTo[T any](r []T, first, n_first, last int, out iterator.Sink[T]) {
+	if debug {
+		checkRange("RotateCopyTo", "r[first, last)", len(r), first, last)
+		checkMiddle("RotateCopyTo", "n_first", first, n_first, last)
+	}
+
+	CopyTo(r, n_first, last, out)
+	CopyTo(r, first, n_first, out)
+}
+
+// Writes to out the elements from the sorted range r1[first1, last1) which
+// are not found in the sorted range r2[first2, last2), in sorted order. Both
+// ranges must be sorted with operator<.
+func SetDifferenceTo[T cmp.Ordered](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T]) {
+	if debug {
+		checkSetInputs("SetDifferenceTo", r1, r2, first1, last1, first2, last2, less[T])
+	}
+
+	SetDifferenceFuncTo(r1, r2, first1, last1, first2, last2, out, less[T])
+}
+
+// Writes to out the elements from the sorted range r1[first1, last1) which
+// are not found in the sorted range r2[first2, last2), in sorted order. Both
+// ranges must be sorted with the given comparison function comp.
+func SetDifferenceFuncTo[T any](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T], comp func(T, T) bool) {
+	if debug {
+		checkSetInputs("SetDifferenceFuncTo", r1, r2, first1, last1, first2, last2, comp)
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			CopyTo(r1, first1, last1, out)
+			return
+		}
+
+		if comp(r1[first1], r2[first2]) {
+			out.Put(r1[first1])
+			first1++
+		} else {
+			if !comp(r2[first2], r1[first1]) {
+				first1++
+			}
+			first2++
+		}
+	}
+}
+
+// Writes to out, in sorted order, the elements that are found in both sorted
+// ranges r1[first1, last1) and r2[first2, last2). Both ranges must be sorted
+// with operator<.
+func SetIntersectionTo[T cmp.Ordered](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T]) {
+	if debug {
+		checkSetInputs("SetIntersectionTo", r1, r2, first1, last1, first2, last2, less[T])
+	}
+
+	SetIntersectionFuncTo(r1, r2, first1, last1, first2, last2, out, less[T])
+}
+
+// Writes to out, in sorted order, the elements that are found in both sorted
+// ranges r1[first1, last1) and r2[first2, last2). Both ranges must be sorted
+// with the given comparison function comp.
+func SetIntersectionFuncTo[T any](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T], comp func(T, T) bool) {
+	if debug {
+		checkSetInputs("SetIntersectionFuncTo", r1, r2, first1, last1, first2, last2, comp)
+	}
+
+	for first1 != last1 && first2 != last2 {
+		if comp(r1[first1], r2[first2]) {
+			first1++
+		} else {
+			if !comp(r2[first2], r1[first1]) {
+				out.Put(r1[first1])
+				first1++
+			}
+			first2++
+		}
+	}
+}
+
+// Writes to out, in sorted order, the elements that are found in either of the
+// sorted ranges r1[first1, last1) and r2[first2, last2), but not in both of
+// them. Both ranges must be sorted with operator<.
+func SetSymmetricDifferenceTo[T cmp.Ordered](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T]) {
+	if debug {
+		checkSetInputs("SetSymmetricDifferenceTo", r1, r2, first1, last1, first2, last2, less[T])
+	}
+
+	SetSymmetricDifferenceFuncTo(r1, r2, first1, last1, first2, last2, out, less[T])
+}
+
+// Writes to out, in sorted order, the elements that are found in either of the
+// sorted ranges r1[first1, last1) and r2[first2, last2), but not in both of
+// them. Both ranges must be sorted with the given comparison function comp.
+func SetSymmetricDifferenceFuncTo[T any](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T], comp func(T, T) bool) {
+	if debug {
+		checkSetInputs("SetSymmetricDifferenceFuncTo", r1, r2, first1, last1, first2, last2, comp)
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			CopyTo(r1, first1, last1, out)
+			return
+		}
+
+		if comp(r1[first1], r2[first2]) {
+			out.Put(r1[first1])
+			first1++
+		} else {
+			if comp(r2[first2], r1[first1]) {
+				out.Put(r2[first2])
+			} else {
+				first1++
+			}
+			first2++
+		}
+	}
+	CopyTo(r2, first2, last2, out)
+}
+
+// Writes to out the sorted union of the sorted ranges r1[first1, last1) and
+// r2[first2, last2). Both ranges must be sorted with operator<.
+func SetUnionTo[T cmp.Ordered](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T]) {
+	if debug {
+		checkSetInputs("SetUnionTo", r1, r2, first1, last1, first2, last2, less[T])
+	}
+
+	SetUnionFuncTo(r1, r2, first1, last1, first2, last2, out, less[T])
+}
+
+// Writes to out the sorted union of the sorted ranges r1[first1, last1) and
+// r2[first2, last2). Both ranges must be sorted with the given comparison
+// function comp.
+func SetUnionFuncTo[T any](r1, r2 []T, first1, last1, first2, last2 int, out iterator.Sink[T], comp func(T, T) bool) {
+	if debug {
+		checkSetInputs("SetUnionFuncTo", r1, r2, first1, last1, first2, last2, comp)
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			CopyTo(r1, first1, last1, out)
+			return
+		}
+
+		if comp(r2[first2], r1[first1]) {
+			out.Put(r2[first2])
+			first2++
+		} else {
+			out.Put(r1[first1])
+			if !comp(r1[first1], r2[first2]) {
+				first2++
+			}
+			first1++
+		}
+	}
+	CopyTo(r2, first2, last2, out)
+}
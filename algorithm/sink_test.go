@@ -0,0 +1,89 @@
+package algorithm
+
+import (
+	"slices"
+	"testing"
+
+	"gocpp/iterator"
+)
+
+func even(x int) bool { return x%2 == 0 }
+
+func equal(a, b int) bool { return a == b }
+
+// TestSinkVariants checks that each To variant writes the same elements as
+// the variant that writes to a range.
+func TestSinkVariants(t *testing.T) {
+	r := []int{1, 1, 2, 3, 3, 3, 4, 1, 6}
+	r2 := []int{0, 1, 3, 3, 5, 9}
+	first, last := 1, 7
+	first2, last2 := 0, 6
+	negate := func(x int) int { return -x }
+	sub := func(x, y int) int { return x - y }
+
+	tests := []struct {
+		name string
+		to   func(out iterator.Sink[int])
+		rng  func(d []int) int
+	}{
+		{"Copy", func(out iterator.Sink[int]) { CopyTo(r, first, last, out) },
+			func(d []int) int { return Copy(r, d, first, last, 0) }},
+		{"CopyIf", func(out iterator.Sink[int]) { CopyIfTo(r, first, last, out, even) },
+			func(d []int) int { return CopyIf(r, d, first, last, 0, even) }},
+		{"CopyN", func(out iterator.Sink[int]) { CopyNTo(r, first, 4, out) },
+			func(d []int) int { return CopyN(r, d, first, 4, 0) }},
+		{"CopyNNegative", func(out iterator.Sink[int]) { CopyNTo(r, first, -1, out) },
+			func(d []int) int { return CopyN(r, d, first, -1, 0) }},
+		{"Move", func(out iterator.Sink[int]) { MoveTo(r, first, last, out) },
+			func(d []int) int { return Move(r, d, first, last, 0) }},
+		{"Transform", func(out iterator.Sink[int]) { TransformTo(r, first, last, out, negate) },
+			func(d []int) int { return Transform(r, d, first, last, 0, negate) }},
+		{"Transform2", func(out iterator.Sink[int]) { Transform2To(r, r2, first, first+5, 1, out, sub) },
+			func(d []int) int { return Transform2(r, r2, d, first, first+5, 1, 0, sub) }},
+		{"ReplaceCopy", func(out iterator.Sink[int]) { ReplaceCopyTo(r, first, last, out, 3, 7) },
+			func(d []int) int { return ReplaceCopy(r, d, first, last, 0, 3, 7) }},
+		{"ReplaceCopyIf", func(out iterator.Sink[int]) { ReplaceCopyIfTo(r, first, last, out, even, 0) },
+			func(d []int) int { return ReplaceCopyIf(r, d, first, last, 0, even, 0) }},
+		{"RemoveCopy", func(out iterator.Sink[int]) { RemoveCopyTo(r, first, last, out, 3) },
+			func(d []int) int { return RemoveCopy(r, d, first, last, 0, 3) }},
+		{"RemoveCopyIf", func(out iterator.Sink[int]) { RemoveCopyIfTo(r, first, last, out, even) },
+			func(d []int) int { return RemoveCopyIf(r, d, first, last, 0, even) }},
+		{"UniqueCopy", func(out iterator.Sink[int]) { UniqueCopyTo(r, first, last, out) },
+			func(d []int) int { return UniqueCopy(r, d, first, last, 0) }},
+		{"UniqueCopyFunc", func(out iterator.Sink[int]) { UniqueCopyFuncTo(r, first, last, out, equal) },
+			func(d []int) int { return UniqueCopyFunc(r, d, first, last, 0, equal) }},
+		{"ReverseCopy", func(out iterator.Sink[int]) { ReverseCopyTo(r, first, last, out) },
+			func(d []int) int { return ReverseCopy(r, d, first, last, 0) }},
+		{"RotateCopy", func(out iterator.Sink[int]) { RotateCopyTo(r, first, 4, last, out) },
+			func(d []int) int { return RotateCopy(r, d, first, 4, last, 0) }},
+		{"SetDifference", func(out iterator.Sink[int]) { SetDifferenceTo(r, r2, first, last, first2, last2, out) },
+			func(d []int) int { return SetDifference(r, r2, d, first, last, first2, last2, 0) }},
+		{"SetDifferenceFunc", func(out iterator.Sink[int]) { SetDifferenceFuncTo(r, r2, first, last, first2, last2, out, less[int]) },
+			func(d []int) int { return SetDifferenceFunc(r, r2, d, first, last, first2, last2, 0, less[int]) }},
+		{"SetIntersection", func(out iterator.Sink[int]) { SetIntersectionTo(r, r2, first, last, first2, last2, out) },
+			func(d []int) int { return SetIntersection(r, r2, d, first, last, first2, last2, 0) }},
+		{"SetIntersectionFunc", func(out iterator.Sink[int]) { SetIntersectionFuncTo(r, r2, first, last, first2, last2, out, less[int]) },
+			func(d []int) int { return SetIntersectionFunc(r, r2, d, first, last, first2, last2, 0, less[int]) }},
+		{"SetSymmetricDifference", func(out iterator.Sink[int]) { SetSymmetricDifferenceTo(r, r2, first, last, first2, last2, out) },
+			func(d []int) int { return SetSymmetricDifference(r, r2, d, first, last, first2, last2, 0) }},
+		{"SetSymmetricDifferenceFunc", func(out iterator.Sink[int]) {
+			SetSymmetricDifferenceFuncTo(r, r2, first, last, first2, last2, out, less[int])
+		},
+			func(d []int) int {
+				return SetSymmetricDifferenceFunc(r, r2, d, first, last, first2, last2, 0, less[int])
+			}},
+		{"SetUnion", func(out iterator.Sink[int]) { SetUnionTo(r, r2, first, last, first2, last2, out) },
+			func(d []int) int { return SetUnion(r, r2, d, first, last, first2, last2, 0) }},
+		{"SetUnionFunc", func(out iterator.Sink[int]) { SetUnionFuncTo(r, r2, first, last, first2, last2, out, less[int]) },
+			func(d []int) int { return SetUnionFunc(r, r2, d, first, last, first2, last2, 0, less[int]) }},
+	}
+	for _, tt := range tests {
+		var got []int
+		tt.to(iterator.BackInserter(&got))
+		d := make([]int, len(r)+len(r2))
+		want := d[:tt.rng(d)]
+		if !slices.Equal(got, want) {
+			t.Errorf("%sTo = %v, want %v", tt.name, got, want)
+		}
+	}
+}
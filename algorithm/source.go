@@ -0,0 +1,79 @@
+package algorithm
+
+import "gocpp/iterator"
+
+// The functions in this file are the variants of the copying algorithms that
+// read from an iterator.Source, such as an iterator.IstreamIterator, and write
+// to an iterator.Sink. Like the C++ algorithms on input iterators, they read
+// each value exactly once.
+
+// Copies the values of in to out until in is exhausted.
+func CopyFrom[T any](in iterator.Source[T], out iterator.Sink[T]) {
+	for v, ok := in.Next(); ok; v, ok = in.Next() {
+		out.Put(v)
+	}
+}
+
+// Copies the values of in for which the predicate pred returns true to out,
+// until in is exhausted.
+func CopyIfFrom[T any](in iterator.Source[T], out iterator.Sink[T], pred func(T) bool) {
+	for v, ok := in.Next(); ok; v, ok = in.Next() {
+		if pred(v) {
+			out.Put(v)
+		}
+	}
+}
+
+// Applies the unary operation unary_op to the values of in and writes the
+// results to out, until in is exhausted.
+func TransformFrom[T1, T2 any](in iterator.Source[T1], out iterator.Sink[T2], unary_op func(T1) T2) {
+	for v, ok := in.Next(); ok; v, ok = in.Next() {
+		out.Put(unary_op(v))
+	}
+}
+
+// Copies the values of in to out, omitting the values that are equal to
+// value.
+func RemoveCopyFrom[T comparable](in iterator.Source[T], out iterator.Sink[T], value T) {
+	for v, ok := in.Next(); ok; v, ok = in.Next() {
+		if v != value {
+			out.Put(v)
+		}
+	}
+}
+
+// Copies the values of in to out, omitting the values for which predicate p
+// returns true.
+func RemoveCopyIfFrom[T any](in iterator.Source[T], out iterator.Sink[T], p func(T) bool) {
+	for v, ok := in.Next(); ok; v, ok = in.Next() {
+		if !p(v) {
+			out.Put(v)
+		}
+	}
+}
+
+// Copies the values of in to out in such a way that there are no consecutive
+// equal values. Only the first value of each group of equal values is copied.
+// Values are compared using operator==.
+func UniqueCopyFrom[T comparable](in iterator.Source[T], out iterator.Sink[T]) {
+	UniqueCopyFuncFrom(in, out, func(a, b T) bool { return a == b })
+}
+
+// Copies the values of in to out in such a way that there are no consecutive
+// equal values. Only the first value of each group of equal values is copied.
+// Values are compared using the given binary predicate p, whose first argument
+// is the value that was copied last. The behavior is undefined if it is not an
+// equivalence relation.
+func UniqueCopyFuncFrom[T any](in iterator.Source[T], out iterator.Sink[T], p func(T, T) bool) {
+	copied, ok := in.Next()
+	if !ok {
+		return
+	}
+	out.Put(copied)
+	for v, ok := in.Next(); ok; v, ok = in.Next() {
+		if !p(copied, v) {
+			copied = v
+			out.Put(v)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package algorithm
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gocpp/iterator"
+)
+
+// TestSourceVariants checks that each From variant writes the same elements
+// as the To variant reading the whole range.
+func TestSourceVariants(t *testing.T) {
+	const input = "1 1 2 3 3 3 4 1 6"
+	r := []int{1, 1, 2, 3, 3, 3, 4, 1, 6}
+	negate := func(x int) int { return -x }
+
+	tests := []struct {
+		name string
+		from func(in iterator.Source[int], out iterator.Sink[int])
+		to   func(out iterator.Sink[int])
+	}{
+		{"Copy", CopyFrom[int],
+			func(out iterator.Sink[int]) { CopyTo(r, 0, len(r), out) }},
+		{"CopyIf", func(in iterator.Source[int], out iterator.Sink[int]) { CopyIfFrom(in, out, even) },
+			func(out iterator.Sink[int]) { CopyIfTo(r, 0, len(r), out, even) }},
+		{"Transform", func(in iterator.Source[int], out iterator.Sink[int]) { TransformFrom(in, out, negate) },
+			func(out iterator.Sink[int]) { TransformTo(r, 0, len(r), out, negate) }},
+		{"RemoveCopy", func(in iterator.Source[int], out iterator.Sink[int]) { RemoveCopyFrom(in, out, 3) },
+			func(out iterator.Sink[int]) { RemoveCopyTo(r, 0, len(r), out, 3) }},
+		{"RemoveCopyIf", func(in iterator.Source[int], out iterator.Sink[int]) { RemoveCopyIfFrom(in, out, even) },
+			func(out iterator.Sink[int]) { RemoveCopyIfTo(r, 0, len(r), out, even) }},
+		{"UniqueCopy", UniqueCopyFrom[int],
+			func(out iterator.Sink[int]) { UniqueCopyTo(r, 0, len(r), out) }},
+		{"UniqueCopyFunc", func(in iterator.Source[int], out iterator.Sink[int]) { UniqueCopyFuncFrom(in, out, equal) },
+			func(out iterator.Sink[int]) { UniqueCopyFuncTo(r, 0, len(r), out, equal) }},
+	}
+	for _, tt := range tests {
+		var got, want []int
+		in := iterator.NewIstreamIterator[int](strings.NewReader(input))
+		tt.from(in, iterator.BackInserter(&got))
+		tt.to(iterator.BackInserter(&want))
+		if in.Err() != nil || !slices.Equal(got, want) {
+			t.Errorf("%sFrom = %v, %v, want %v", tt.name, got, in.Err(), want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package algorithm
+
+import (
+	"container/heap"
+	"flag"
+	"slices"
+	"sort"
+	"testing"
+
+	"gocpp/internal/benchutil"
+)
+
+var stdlibSizes = flag.String("benchsizes", benchutil.DefaultSizes, benchutil.SizesUsage)
+
+// BenchmarkStdlib compares the algorithms with their counterparts in slices,
+// sort and container/heap, and with hand-written loops where the standard
+// library has none, over the element types, sizes and distributions of
+// package benchutil. See there for how to run it and compare the results.
+func BenchmarkStdlib(b *testing.B) {
+	stdlibSuite(b, benchutil.Int)
+	stdlibSuite(b, benchutil.String)
+	stdlibSuite(b, benchutil.Struct)
+}
+
+// stdlibImpl is one implementation of an operation of the suite. Its run
+// function must leave in, the input shared by all implementations, alone.
+type stdlibImpl[T any] struct {
+	name string
+	run  func(b *testing.B, in []T)
+}
+
+type stdlibOp[T any] struct {
+	name  string
+	impls []stdlibImpl[T]
+}
+
+func stdlibSuite[T comparable](b *testing.B, typ benchutil.Type[T]) {
+	ops := stdlibOps(typ)
+	for _, n := range benchutil.Sizes(*stdlibSizes) {
+		for _, d := range benchutil.Dists {
+			in := typ.Slice(n, d)
+			name := benchutil.Name(typ.Name, d, n)
+			for _, op := range ops {
+				for _, impl := range op.impls {
+					b.Run(op.name+"/"+name+"/impl="+impl.name, func(b *testing.B) {
+						impl.run(b, in)
+					})
+				}
+			}
+		}
+	}
+}
+
+// stdHeap adapts a slice to heap.Interface for the container/heap baselines.
+type stdHeap[T any] struct {
+	s    []T
+	less func(a, b T) bool
+}
+
+func (h *stdHeap[T]) Len() int           { return len(h.s) }
+func (h *stdHeap[T]) Less(i, j int) bool { return h.less(h.s[i], h.s[j]) }
+func (h *stdHeap[T]) Swap(i, j int)      { h.s[i], h.s[j] = h.s[j], h.s[i] }
+func (h *stdHeap[T]) Push(x any)         { h.s = append(h.s, x.(T)) }
+
+func (h *stdHeap[T]) Pop() any {
+	x := h.s[len(h.s)-1]
+	h.s = h.s[:len(h.s)-1]
+	return x
+}
+
+var stdlibSink int
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func loopReverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func stdlibOps[T comparable](typ benchutil.Type[T]) []stdlibOp[T] {
+	less, compare := typ.Less, typ.Compare
+
+	// Operations that modify their input work on a copy made in every
+	// iteration, by every implementation alike.
+	modify := func(f func(s []T)) func(b *testing.B, in []T) {
+		return func(b *testing.B, in []T) {
+			s := make([]T, len(in))
+			for i := 0; i < b.N; i++ {
+				copy(s, in)
+				f(s)
+			}
+		}
+	}
+	// Operations on sorted input get a sorted copy, and look up the
+	// elements of in in turn. Their results are kept in stdlibSink so that
+	// the calls cannot be optimized away.
+	lookup := func(f func(sorted []T, x T) int) func(b *testing.B, in []T) {
+		return func(b *testing.B, in []T) {
+			sorted := slices.Clone(in)
+			slices.SortFunc(sorted, compare)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				stdlibSink += f(sorted, in[i%len(in)])
+			}
+		}
+	}
+	// Operations that do not modify their input.
+	read := func(f func(in []T) int) func(b *testing.B, in []T) {
+		return func(b *testing.B, in []T) {
+			for i := 0; i < b.N; i++ {
+				stdlibSink += f(in)
+			}
+		}
+	}
+
+	return []stdlibOp[T]{
+		{"Find", []stdlibImpl[T]{
+			{"gocpp", read(func(in []T) int { return Find(in, 0, len(in), typ.Absent) })},
+			{"slices", read(func(in []T) int { return slices.Index(in, typ.Absent) })},
+			{"loop", read(func(in []T) int { return loopFind(in, 0, len(in), typ.Absent) })},
+		}},
+		{"Count", []stdlibImpl[T]{
+			{"gocpp", read(func(in []T) int { return Count(in, 0, len(in), in[len(in)/2]) })},
+			{"loop", read(func(in []T) int { return loopCount(in, 0, len(in), in[len(in)/2]) })},
+		}},
+		{"Equal", []stdlibImpl[T]{
+			{"gocpp", read(func(in []T) int { return btoi(Equal2(in, in, 0, len(in), 0, len(in))) })},
+			{"slices", read(func(in []T) int { return btoi(slices.Equal(in, in)) })},
+		}},
+		{"LowerBound", []stdlibImpl[T]{
+			{"gocpp", lookup(func(sorted []T, x T) int { return LowerBoundFunc(sorted, 0, len(sorted), x, less) })},
+			{"slices", lookup(func(sorted []T, x T) int {
+				i, _ := slices.BinarySearchFunc(sorted, x, compare)
+				return i
+			})},
+			{"sort", lookup(func(sorted []T, x T) int {
+				return sort.Search(len(sorted), func(i int) bool { return !less(sorted[i], x) })
+			})},
+		}},
+		{"Sort", []stdlibImpl[T]{
+			{"gocpp", modify(func(s []T) {
+				MakeHeapFunc(s, 0, len(s), less)
+				SortHeapFunc(s, 0, len(s), less)
+			})},
+			{"slices", modify(func(s []T) { slices.SortFunc(s, compare) })},
+			{"sort", modify(func(s []T) { sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) }) })},
+			{"heap", modify(func(s []T) {
+				h := &stdHeap[T]{s, less}
+				heap.Init(h)
+				for h.Len() > 0 {
+					heap.Pop(h)
+				}
+			})},
+		}},
+		{"PushPopHeap", []stdlibImpl[T]{
+			{"gocpp", func(b *testing.B, in []T) {
+				s := make([]T, 0, len(in))
+				for i := 0; i < b.N; i++ {
+					for _, x := range in {
+						s = append(s, x)
+						PushHeapFunc(s, 0, len(s), less)
+					}
+					for len(s) > 0 {
+						PopHeapFunc(s, 0, len(s), less)
+						s = s[:len(s)-1]
+					}
+				}
+			}},
+			{"heap", func(b *testing.B, in []T) {
+				h := &stdHeap[T]{make([]T, 0, len(in)), less}
+				for i := 0; i < b.N; i++ {
+					for _, x := range in {
+						heap.Push(h, x)
+					}
+					for h.Len() > 0 {
+						heap.Pop(h)
+					}
+				}
+			}},
+		}},
+		{"Reverse", []stdlibImpl[T]{
+			{"gocpp", modify(func(s []T) { Reverse(s, 0, len(s)) })},
+			{"slices", modify(func(s []T) { slices.Reverse(s) })},
+		}},
+		{"Rotate", []stdlibImpl[T]{
+			{"gocpp", modify(func(s []T) { Rotate(s, 0, len(s)/3, len(s)) })},
+			{"loop", modify(func(s []T) {
+				loopReverse(s[:len(s)/3])
+				loopReverse(s[len(s)/3:])
+				loopReverse(s)
+			})},
+		}},
+		{"Unique", []stdlibImpl[T]{
+			{"gocpp", modify(func(s []T) { Unique(s, 0, len(s)) })},
+			{"slices", modify(func(s []T) { _ = slices.Compact(s) })},
+		}},
+		{"Remove", []stdlibImpl[T]{
+			{"gocpp", modify(func(s []T) { Remove(s, 0, len(s), s[len(s)/2]) })},
+			{"slices", modify(func(s []T) {
+				x := s[len(s)/2]
+				_ = slices.DeleteFunc(s, func(y T) bool { return y == x })
+			})},
+		}},
+	}
+}
@@ -0,0 +1,1236 @@
+// Code generated by variants_gen.go; DO NOT EDIT.
+
+package algorithm
+
+import (
+	"cmp"
+
+	"gocpp/utility"
+)
+
+// Searches the range r[first, last) for the first element equal to value (using
+// operator==). Returns the iterator to the element, or last if there is no such
+// element.
+func Find[T comparable](r []T, first, last int, value T) int {
+	if debug {
+		checkRange("Find", "r[first, last)", len(r), first, last)
+	}
+
+	if it, ok := findFast(r, first, last, value); ok {
+		return it
+	}
+
+	for ; first != last; first++ {
+		if r[first] == value {
+			return first
+		}
+	}
+	return last
+}
+
+// Searches the range r[first, last) for the first element for which predicate p
+// returns true. Returns the iterator to the element, or last if there is no
+// such element.
+func FindIf[T any](r []T, first, last int, p func(T) bool) int {
+	if debug {
+		checkRange("FindIf", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if p(r[first]) {
+			return first
+		}
+	}
+	return last
+}
+
+// Searches the range r[first, last) for the first element for which predicate q
+// returns false. Returns the iterator to the element, or last if there is no
+// such element.
+func FindIfNot[T any](r []T, first, last int, q func(T) bool) int {
+	if debug {
+		checkRange("FindIfNot", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if !q(r[first]) {
+			return first
+		}
+	}
+	return last
+}
+
+// Searches the range r[first, last) for the first element equal to value (using
+// operator==). Returns the iterator to the element as an Optional, which is
+// empty if there is no such element, instead of last.
+func FindOpt[T comparable](r []T, first, last int, value T) utility.Optional[int] {
+	if debug {
+		checkRange("FindOpt", "r[first, last)", len(r), first, last)
+	}
+
+	if it := Find(r, first, last, value); it != last {
+		return utility.MakeOptional(it)
+	}
+	return utility.Nullopt[int]()
+}
+
+// Searches the range r[first, last) for the first element for which predicate p
+// returns true. Returns the iterator to the element as an Optional, which is
+// empty if there is no such element, instead of last.
+func FindIfOpt[T any](r []T, first, last int, p func(T) bool) utility.Optional[int] {
+	if debug {
+		checkRange("FindIfOpt", "r[first, last)", len(r), first, last)
+	}
+
+	if it := FindIf(r, first, last, p); it != last {
+		return utility.MakeOptional(it)
+	}
+	return utility.Nullopt[int]()
+}
+
+// Searches the range r[first, last) for the first element for which predicate q
+// returns false. Returns the iterator to the element as an Optional, which is
+// empty if there is no such element, instead of last.
+func FindIfNotOpt[T any](r []T, first, last int, q func(T) bool) utility.Optional[int] {
+	if debug {
+		checkRange("FindIfNotOpt", "r[first, last)", len(r), first, last)
+	}
+
+	if it := FindIfNot(r, first, last, q); it != last {
+		return utility.MakeOptional(it)
+	}
+	return utility.Nullopt[int]()
+}
+
+// Returns the number of elements in the range r[first, last) equal to value
+// (using operator==).
+func Count[T comparable](r []T, first, last int, value T) int {
+	if debug {
+		checkRange("Count", "r[first, last)", len(r), first, last)
+	}
+
+	if ret, ok := countFast(r, first, last, value); ok {
+		return ret
+	}
+
+	ret := 0
+	for ; first != last; first++ {
+		if r[first] == value {
+			ret++
+		}
+	}
+	return ret
+}
+
+// Returns the number of elements in the range r[first, last) for which
+// predicate p returns true.
+func CountIf[T any](r []T, first, last int, p func(T) bool) int {
+	if debug {
+		checkRange("CountIf", "r[first, last)", len(r), first, last)
+	}
+
+	ret := 0
+	for ; first != last; first++ {
+		if p(r[first]) {
+			ret++
+		}
+	}
+	return ret
+}
+
+// Replaces all elements in the range r[first, last) equal to old_value (using
+// operator==) with new_value.
+func Replace[T comparable](r []T, first, last int, old_value T, new_value T) {
+	if debug {
+		checkRange("Replace", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if r[first] == old_value {
+			r[first] = new_value
+		}
+	}
+}
+
+// Replaces all elements in the range r[first, last) for which predicate p
+// returns true with new_value.
+func ReplaceIf[T any](r []T, first, last int, p func(T) bool, new_value T) {
+	if debug {
+		checkRange("ReplaceIf", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if p(r[first]) {
+			r[first] = new_value
+		}
+	}
+}
+
+// Copies the elements from the range r1[first, last) to another range beginning
+// at r2[d_first], replacing all elements equal to old_value (using operator==)
+// with new_value, and returns the iterator past the last element written. If
+// the source and destination ranges overlap, the behavior is undefined.
+func ReplaceCopy[T comparable](r1, r2 []T, first, last, d_first int, old_value T, new_value T) int {
+	if debug {
+		checkRange("ReplaceCopy", "r1[first, last)", len(r1), first, last)
+		checkRoom("ReplaceCopy", "destination r2", len(r2), d_first, last-first)
+		checkNoOverlap("ReplaceCopy", r1[first:last], r2[d_first:d_first+last-first])
+	}
+
+	for ; first != last; first++ {
+		r2[d_first] = r1[first]
+		if r2[d_first] == old_value {
+			r2[d_first] = new_value
+		}
+		d_first++
+	}
+	return d_first
+}
+
+// Copies the elements from the range r1[first, last) to another range beginning
+// at r2[d_first], replacing all elements for which predicate p returns true
+// with new_value, and returns the iterator past the last element written. If
+// the source and destination ranges overlap, the behavior is undefined.
+func ReplaceCopyIf[T any](r1, r2 []T, first, last, d_first int, p func(T) bool, new_value T) int {
+	if debug {
+		checkRange("ReplaceCopyIf", "r1[first, last)", len(r1), first, last)
+		checkRoom("ReplaceCopyIf", "destination r2", len(r2), d_first, last-first)
+		checkNoOverlap("ReplaceCopyIf", r1[first:last], r2[d_first:d_first+last-first])
+	}
+
+	for ; first != last; first++ {
+		r2[d_first] = r1[first]
+		if p(r2[d_first]) {
+			r2[d_first] = new_value
+		}
+		d_first++
+	}
+	return d_first
+}
+
+// Removes all elements equal to value (using operator==) from the range
+// r[first, last) and returns a past-the-end iterator for the new end of the
+// range. Removing is done by shifting the elements in the range in such a way
+// that elements to be erased are overwritten; the relative order of the
+// elements that remain is preserved.
+func Remove[T comparable](r []T, first, last int, value T) int {
+	if debug {
+		checkRange("Remove", "r[first, last)", len(r), first, last)
+	}
+
+	first = Find(r, first, last, value)
+	if first != last {
+		for i := first + 1; i != last; i++ {
+			if r[i] != value {
+				r[first] = r[i]
+				first++
+			}
+		}
+	}
+	return first
+}
+
+// Removes all elements for which predicate p returns true from the range
+// r[first, last) and returns a past-the-end iterator for the new end of the
+// range. Removing is done by shifting the elements in the range in such a way
+// that elements to be erased are overwritten; the relative order of the
+// elements that remain is preserved.
+func RemoveIf[T any](r []T, first, last int, p func(T) bool) int {
+	if debug {
+		checkRange("RemoveIf", "r[first, last)", len(r), first, last)
+	}
+
+	first = FindIf(r, first, last, p)
+	if first != last {
+		for i := first + 1; i != last; i++ {
+			if !p(r[i]) {
+				r[first] = r[i]
+				first++
+			}
+		}
+	}
+	return first
+}
+
+// Copies the elements from the range r1[first, last) to another range beginning
+// at r2[d_first], omitting the elements equal to value (using operator==), and
+// returns the iterator past the last element written. If the source and
+// destination ranges overlap, the behavior is undefined.
+func RemoveCopy[T comparable](r1, r2 []T, first, last, d_first int, value T) int {
+	if debug {
+		checkRange("RemoveCopy", "r1[first, last)", len(r1), first, last)
+	}
+
+	for ; first != last; first++ {
+		if r1[first] != value {
+			if debug {
+				checkWrite("RemoveCopy", "r2", r2, d_first, r1[first:last])
+			}
+			r2[d_first] = r1[first]
+			d_first++
+		}
+	}
+	return d_first
+}
+
+// Copies the elements from the range r1[first, last) to another range beginning
+// at r2[d_first], omitting the elements for which predicate p returns true, and
+// returns the iterator past the last element written. If the source and
+// destination ranges overlap, the behavior is undefined.
+func RemoveCopyIf[T any](r1, r2 []T, first, last, d_first int, p func(T) bool) int {
+	if debug {
+		checkRange("RemoveCopyIf", "r1[first, last)", len(r1), first, last)
+	}
+
+	for ; first != last; first++ {
+		if !p(r1[first]) {
+			if debug {
+				checkWrite("RemoveCopyIf", "r2", r2, d_first, r1[first:last])
+			}
+			r2[d_first] = r1[first]
+			d_first++
+		}
+	}
+	return d_first
+}
+
+// Searches the range r[first, last) for two consecutive equal elements. Returns
+// an iterator to the first of the first pair of equal elements, that is, the
+// first iterator it such that r[it] == r[it + 1], or last if there is no such
+// pair. Elements are compared using operator==.
+func AdjacentFind[T comparable](r []T, first, last int) int {
+	if debug {
+		checkRange("AdjacentFind", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return last
+	}
+
+	for next := first + 1; next != last; {
+		if r[first] == r[next] {
+			return first
+		}
+		next++
+		first++
+	}
+
+	return last
+}
+
+// Searches the range r[first, last) for two consecutive equal elements. Returns
+// an iterator to the first of the first pair of equal elements, that is, the
+// first iterator it such that p(r[it], r[it + 1]), or last if there is no such
+// pair. Elements are compared using the given binary predicate p.
+func AdjacentFindFunc[T any](r []T, first, last int, p func(T, T) bool) int {
+	if debug {
+		checkRange("AdjacentFindFunc", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return last
+	}
+
+	for next := first + 1; next != last; {
+		if p(r[first], r[next]) {
+			return first
+		}
+		next++
+		first++
+	}
+
+	return last
+}
+
+// Returns the first mismatching pair of elements from two ranges: one defined
+// by r1[first1, last1) and another defined by r2[first2, first2 + (last1 -
+// first1)). Elements are compared using operator==.
+func Mismatch[T comparable](r1, r2 []T, first1, last1, first2 int) utility.Pair[int, int] {
+	if debug {
+		checkRange("Mismatch", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("Mismatch", "r2", len(r2), first2, last1-first1)
+	}
+
+	for first1 != last1 && r1[first1] == r2[first2] {
+		first1++
+		first2++
+	}
+
+	return utility.MakePair(first1, first2)
+}
+
+// Returns the first mismatching pair of elements from two ranges: one defined
+// by r1[first1, last1) and another defined by r2[first2, first2 + (last1 -
+// first1)). Elements are compared using the given binary predicate p.
+func MismatchFunc[T any](r1, r2 []T, first1, last1, first2 int, p func(T, T) bool) utility.Pair[int, int] {
+	if debug {
+		checkRange("MismatchFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("MismatchFunc", "r2", len(r2), first2, last1-first1)
+	}
+
+	for first1 != last1 && p(r1[first1], r2[first2]) {
+		first1++
+		first2++
+	}
+
+	return utility.MakePair(first1, first2)
+}
+
+// Returns the first mismatching pair of elements from two ranges: one defined
+// by r1[first1, last1) and another defined by r2[first2, last2). Elements are
+// compared using operator==.
+func Mismatch2[T comparable](r1, r2 []T, first1, last1, first2, last2 int) utility.Pair[int, int] {
+	if debug {
+		checkRange("Mismatch2", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("Mismatch2", "r2[first2, last2)", len(r2), first2, last2)
+	}
+
+	for first1 != last1 && first2 != last2 && r1[first1] == r2[first2] {
+		first1++
+		first2++
+	}
+
+	return utility.MakePair(first1, first2)
+}
+
+// Returns the first mismatching pair of elements from two ranges: one defined
+// by r1[first1, last1) and another defined by r2[first2, last2). Elements are
+// compared using the given binary predicate p.
+func MismatchFunc2[T any](r1, r2 []T, first1, last1, first2, last2 int, p func(T, T) bool) utility.Pair[int, int] {
+	if debug {
+		checkRange("MismatchFunc2", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("MismatchFunc2", "r2[first2, last2)", len(r2), first2, last2)
+	}
+
+	for first1 != last1 && first2 != last2 && p(r1[first1], r2[first2]) {
+		first1++
+		first2++
+	}
+
+	return utility.MakePair(first1, first2)
+}
+
+// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
+// first2 + (last1 - first1)), and false otherwise. Two ranges are considered
+// equal if they have the same number of elements and, for every iterator i in
+// the range [first1, last1), *i equals *(first2 + (i - first1)). Elements are
+// compared using operator==.
+func Equal[T comparable](r1, r2 []T, first1, last1, first2 int) bool {
+	if debug {
+		checkRange("Equal", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("Equal", "r2", len(r2), first2, last1-first1)
+	}
+
+	for first1 != last1 {
+		if r1[first1] != r2[first2] {
+			return false
+		}
+		first1++
+		first2++
+	}
+
+	return true
+}
+
+// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
+// first2 + (last1 - first1)), and false otherwise. Two ranges are considered
+// equal if they have the same number of elements and, for every iterator i in
+// the range [first1, last1), *i equals *(first2 + (i - first1)). Elements are
+// compared using the given binary predicate p.
+func EqualFunc[T any](r1, r2 []T, first1, last1, first2 int, p func(T, T) bool) bool {
+	if debug {
+		checkRange("EqualFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRoom("EqualFunc", "r2", len(r2), first2, last1-first1)
+	}
+
+	for first1 != last1 {
+		if !p(r1[first1], r2[first2]) {
+			return false
+		}
+		first1++
+		first2++
+	}
+
+	return true
+}
+
+// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
+// last2), and false otherwise. Two ranges are considered equal if they have the
+// same number of elements and, for every iterator i in the range [first1,
+// last1), *i equals *(first2 + (i - first1)). Elements are compared using
+// operator==.
+func Equal2[T comparable](r1, r2 []T, first1, last1, first2, last2 int) bool {
+	if debug {
+		checkRange("Equal2", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("Equal2", "r2[first2, last2)", len(r2), first2, last2)
+	}
+
+	if last1-first1 != last2-first2 {
+		return false
+	}
+
+	for first1 != last1 {
+		if r1[first1] != r2[first2] {
+			return false
+		}
+		first1++
+		first2++
+	}
+
+	return true
+}
+
+// Returns true if the range r1[first1, last1) is equal to the range r2[first2,
+// last2), and false otherwise. Two ranges are considered equal if they have the
+// same number of elements and, for every iterator i in the range [first1,
+// last1), *i equals *(first2 + (i - first1)). Elements are compared using the
+// given binary predicate p.
+func EqualFunc2[T any](r1, r2 []T, first1, last1, first2, last2 int, p func(T, T) bool) bool {
+	if debug {
+		checkRange("EqualFunc2", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("EqualFunc2", "r2[first2, last2)", len(r2), first2, last2)
+	}
+
+	if last1-first1 != last2-first2 {
+		return false
+	}
+
+	for first1 != last1 {
+		if !p(r1[first1], r2[first2]) {
+			return false
+		}
+		first1++
+		first2++
+	}
+
+	return true
+}
+
+// Searches for the first occurrence of the sequence of elements r2[s_first,
+// s_last) in the range r1[first, last). Returns the iterator to the beginning
+// of the occurrence, or last if there is none; an empty sequence is found at
+// first. Elements are compared using operator==.
+func Search[T comparable](r1, r2 []T, first, last, s_first, s_last int) int {
+	if debug {
+		checkRange("Search", "r1[first, last)", len(r1), first, last)
+		checkRange("Search", "r2[s_first, s_last)", len(r2), s_first, s_last)
+	}
+
+	if it, ok := searchFast(r1, r2, first, last, s_first, s_last); ok {
+		return it
+	}
+
+	for {
+		it := first
+		for s_it := s_first; ; {
+			if s_it == s_last {
+				return first
+			}
+			if it == last {
+				return last
+			}
+			if r1[it] != r2[s_it] {
+				break
+			}
+			it++
+			s_it++
+		}
+		first++
+	}
+}
+
+// Searches for the first occurrence of the sequence of elements r2[s_first,
+// s_last) in the range r1[first, last). Returns the iterator to the beginning
+// of the occurrence, or last if there is none; an empty sequence is found at
+// first. Elements are compared using the given binary predicate p.
+func SearchFunc[T any](r1, r2 []T, first, last, s_first, s_last int, p func(T, T) bool) int {
+	if debug {
+		checkRange("SearchFunc", "r1[first, last)", len(r1), first, last)
+		checkRange("SearchFunc", "r2[s_first, s_last)", len(r2), s_first, s_last)
+	}
+
+	for {
+		it := first
+		for s_it := s_first; ; {
+			if s_it == s_last {
+				return first
+			}
+			if it == last {
+				return last
+			}
+			if !p(r1[it], r2[s_it]) {
+				break
+			}
+			it++
+			s_it++
+		}
+		first++
+	}
+}
+
+// Searches the range r[first, last) for the first sequence of count identical
+// elements, each equal to the given value. Returns the iterator to the
+// beginning of the sequence, or last if there is none; if count <= 0, returns
+// first. Elements are compared using operator==.
+func SearchN[T comparable](r []T, first, last, count int, value T) int {
+	if debug {
+		checkRange("SearchN", "r[first, last)", len(r), first, last)
+	}
+
+	if count <= 0 {
+		return first
+	}
+
+	for ; first != last; first++ {
+		if r[first] != value {
+			continue
+		}
+
+		candidate := first
+
+		for cur_count := 1; ; cur_count++ {
+			if cur_count >= count {
+				return candidate
+			}
+
+			first++
+			if first == last {
+				return last
+			}
+
+			if r[first] != value {
+				break
+			}
+		}
+	}
+	return last
+}
+
+// Searches the range r[first, last) for the first sequence of count identical
+// elements, each equal to the given value. Returns the iterator to the
+// beginning of the sequence, or last if there is none; if count <= 0, returns
+// first. Elements are compared using the given binary predicate p.
+func SearchNFunc[T any](r []T, first, last, count int, value T, p func(T, T) bool) int {
+	if debug {
+		checkRange("SearchNFunc", "r[first, last)", len(r), first, last)
+	}
+
+	if count <= 0 {
+		return first
+	}
+
+	for ; first != last; first++ {
+		if !p(r[first], value) {
+			continue
+		}
+
+		candidate := first
+
+		for cur_count := 1; ; cur_count++ {
+			if cur_count >= count {
+				return candidate
+			}
+
+			first++
+			if first == last {
+				return last
+			}
+
+			if !p(r[first], value) {
+				break
+			}
+		}
+	}
+	return last
+}
+
+// Eliminates all except the first element from every consecutive group of
+// equivalent elements from the range r[first, last) and returns a past-the-end
+// iterator for the new logical end of the range. Removing is done by shifting
+// the elements in the range in such a way that elements to be erased are
+// overwritten. Elements are compared using operator==. The behavior is
+// undefined if it is not an equivalence relation.
+func Unique[T comparable](r []T, first, last int) int {
+	if debug {
+		checkRange("Unique", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return last
+	}
+
+	result := first
+	for first++; first != last; first++ {
+		if r[result] != r[first] {
+			result++
+			r[result] = r[first]
+		}
+	}
+	return result + 1
+}
+
+// Eliminates all except the first element from every consecutive group of
+// equivalent elements from the range r[first, last) and returns a past-the-end
+// iterator for the new logical end of the range. Removing is done by shifting
+// the elements in the range in such a way that elements to be erased are
+// overwritten. Elements are compared using the given binary predicate p. The
+// behavior is undefined if it is not an equivalence relation.
+func UniqueFunc[T any](r []T, first, last int, p func(T, T) bool) int {
+	if debug {
+		checkRange("UniqueFunc", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return last
+	}
+
+	result := first
+	for first++; first != last; first++ {
+		if !p(r[result], r[first]) {
+			result++
+			r[result] = r[first]
+		}
+	}
+	return result + 1
+}
+
+// Copies the elements from the range r1[first, last) to another range beginning
+// at r2[d_first] in such a way that there are no consecutive equal elements,
+// and returns the iterator past the last element written. Only the first
+// element of each group of equal elements is copied. Elements are compared
+// using operator==. The behavior is undefined if it is not an equivalence
+// relation.
+func UniqueCopy[T comparable](r1, r2 []T, first, last, d_first int) int {
+	if debug {
+		checkRange("UniqueCopy", "r1[first, last)", len(r1), first, last)
+	}
+
+	if first == last {
+		return d_first
+	}
+
+	if debug {
+		checkWrite("UniqueCopy", "r2", r2, d_first, r1[first:last])
+	}
+	r2[d_first] = r1[first]
+
+	for first++; first != last; first++ {
+		if r2[d_first] != r1[first] {
+			d_first++
+			if debug {
+				checkWrite("UniqueCopy", "r2", r2, d_first, r1[first:last])
+			}
+			r2[d_first] = r1[first]
+		}
+	}
+
+	return d_first + 1
+}
+
+// Copies the elements from the range r1[first, last) to another range beginning
+// at r2[d_first] in such a way that there are no consecutive equal elements,
+// and returns the iterator past the last element written. Only the first
+// element of each group of equal elements is copied. Elements are compared
+// using the given binary predicate p. The behavior is undefined if it is not an
+// equivalence relation.
+func UniqueCopyFunc[T any](r1, r2 []T, first, last, d_first int, p func(T, T) bool) int {
+	if debug {
+		checkRange("UniqueCopyFunc", "r1[first, last)", len(r1), first, last)
+	}
+
+	if first == last {
+		return d_first
+	}
+
+	if debug {
+		checkWrite("UniqueCopyFunc", "r2", r2, d_first, r1[first:last])
+	}
+	r2[d_first] = r1[first]
+
+	for first++; first != last; first++ {
+		if !p(r2[d_first], r1[first]) {
+			d_first++
+			if debug {
+				checkWrite("UniqueCopyFunc", "r2", r2, d_first, r1[first:last])
+			}
+			r2[d_first] = r1[first]
+		}
+	}
+
+	return d_first + 1
+}
+
+// Returns an iterator pointing to the first element in the range r[first, last)
+// such that element < value is false, or last if no such element is found. The
+// range must be sorted with operator<, or at least partitioned with respect to
+// element < value.
+func LowerBound[T cmp.Ordered](r []T, first, last int, value T) int {
+	if debug {
+		checkRange("LowerBound", "r[first, last)", len(r), first, last)
+		checkPartitioned("LowerBound", "element < value", r, first, last, func(e T) bool { return e < value })
+	}
+
+	for count := last - first; count > 0; {
+		step := count / 2
+		if it := first + step; r[it] < value {
+			first = it + 1
+			count -= step + 1
+		} else {
+			count = step
+		}
+	}
+	return first
+}
+
+// Returns an iterator pointing to the first element in the range r[first, last)
+// such that comp(element, value) is false, or last if no such element is found.
+// The range must be sorted with the given comparison function comp, or at least
+// partitioned with respect to comp(element, value).
+func LowerBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) int {
+	if debug {
+		checkRange("LowerBoundFunc", "r[first, last)", len(r), first, last)
+		checkPartitioned("LowerBoundFunc", "comp(element, value)", r, first, last, func(e T) bool { return comp(e, value) })
+	}
+
+	for count := last - first; count > 0; {
+		step := count / 2
+		if it := first + step; comp(r[it], value) {
+			first = it + 1
+			count -= step + 1
+		} else {
+			count = step
+		}
+	}
+	return first
+}
+
+// Returns an iterator pointing to the first element in the range r[first, last)
+// such that value < element is true, or last if no such element is found. The
+// range must be sorted with operator<, or at least partitioned with respect to
+// !(value < element).
+func UpperBound[T cmp.Ordered](r []T, first, last int, value T) int {
+	if debug {
+		checkRange("UpperBound", "r[first, last)", len(r), first, last)
+		checkPartitioned("UpperBound", "!(value < element)", r, first, last, func(e T) bool { return !(value < e) })
+	}
+
+	for count := last - first; count > 0; {
+		step := count / 2
+		if it := first + step; !(value < r[it]) {
+			first = it + 1
+			count -= step + 1
+		} else {
+			count = step
+		}
+	}
+	return first
+}
+
+// Returns an iterator pointing to the first element in the range r[first, last)
+// such that comp(value, element) is true, or last if no such element is found.
+// The range must be sorted with the given comparison function comp, or at least
+// partitioned with respect to !comp(value, element).
+func UpperBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) int {
+	if debug {
+		checkRange("UpperBoundFunc", "r[first, last)", len(r), first, last)
+		checkPartitioned("UpperBoundFunc", "!comp(value, element)", r, first, last, func(e T) bool { return !comp(value, e) })
+	}
+
+	for count := last - first; count > 0; {
+		step := count / 2
+		if it := first + step; !comp(value, r[it]) {
+			first = it + 1
+			count -= step + 1
+		} else {
+			count = step
+		}
+	}
+	return first
+}
+
+// Returns true if the sorted range r2[first2, last2) is a subsequence of the
+// sorted range r1[first1, last1) (a subsequence need not be contiguous). Both
+// ranges must be sorted with operator<.
+func Includes[T cmp.Ordered](r1, r2 []T, first1, last1, first2, last2 int) bool {
+	if debug {
+		checkRange("Includes", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("Includes", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("Includes", "r1[first1, last1)", r1, first1, last1, less[T])
+		checkSorted("Includes", "r2[first2, last2)", r2, first2, last2, less[T])
+	}
+
+	for ; first2 != last2; first1++ {
+		if first1 == last1 || r2[first2] < r1[first1] {
+			return false
+		}
+		if !(r1[first1] < r2[first2]) {
+			first2++
+		}
+	}
+	return true
+}
+
+// Returns true if the sorted range r2[first2, last2) is a subsequence of the
+// sorted range r1[first1, last1) (a subsequence need not be contiguous). Both
+// ranges must be sorted with the given comparison function comp.
+func IncludesFunc[T any](r1, r2 []T, first1, last1, first2, last2 int, comp func(T, T) bool) bool {
+	if debug {
+		checkRange("IncludesFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("IncludesFunc", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("IncludesFunc", "r1[first1, last1)", r1, first1, last1, comp)
+		checkSorted("IncludesFunc", "r2[first2, last2)", r2, first2, last2, comp)
+	}
+
+	for ; first2 != last2; first1++ {
+		if first1 == last1 || comp(r2[first2], r1[first1]) {
+			return false
+		}
+		if !comp(r1[first1], r2[first2]) {
+			first2++
+		}
+	}
+	return true
+}
+
+// Copies the elements from the sorted range r1[first1, last1) which are not
+// found in the sorted range r2[first2, last2) to the range beginning at
+// r3[d_first], and returns the iterator past the last element written. The
+// output range is also sorted. If r1[first1, last1) contains m elements that
+// are equivalent to each other and r2[first2, last2) contains n elements that
+// are equivalent to them, the final max(m - n, 0) elements will be copied from
+// r1. Both ranges must be sorted with operator<.
+func SetDifference[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) int {
+	if debug {
+		checkRange("SetDifference", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetDifference", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetDifference", "r1[first1, last1)", r1, first1, last1, less[T])
+		checkSorted("SetDifference", "r2[first2, last2)", r2, first2, last2, less[T])
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			if debug {
+				checkRoom("SetDifference", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("SetDifference", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if r1[first1] < r2[first2] {
+			if debug {
+				checkWrite("SetDifference", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+			}
+			r3[d_first] = r1[first1]
+			d_first++
+			first1++
+		} else {
+			if !(r2[first2] < r1[first1]) {
+				first1++
+			}
+			first2++
+		}
+	}
+	return d_first
+}
+
+// Copies the elements from the sorted range r1[first1, last1) which are not
+// found in the sorted range r2[first2, last2) to the range beginning at
+// r3[d_first], and returns the iterator past the last element written. The
+// output range is also sorted. If r1[first1, last1) contains m elements that
+// are equivalent to each other and r2[first2, last2) contains n elements that
+// are equivalent to them, the final max(m - n, 0) elements will be copied from
+// r1. Both ranges must be sorted with the given comparison function comp.
+func SetDifferenceFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) int {
+	if debug {
+		checkRange("SetDifferenceFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetDifferenceFunc", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetDifferenceFunc", "r1[first1, last1)", r1, first1, last1, comp)
+		checkSorted("SetDifferenceFunc", "r2[first2, last2)", r2, first2, last2, comp)
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			if debug {
+				checkRoom("SetDifferenceFunc", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("SetDifferenceFunc", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if comp(r1[first1], r2[first2]) {
+			if debug {
+				checkWrite("SetDifferenceFunc", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+			}
+			r3[d_first] = r1[first1]
+			d_first++
+			first1++
+		} else {
+			if !comp(r2[first2], r1[first1]) {
+				first1++
+			}
+			first2++
+		}
+	}
+	return d_first
+}
+
+// Constructs a sorted range beginning at r3[d_first] consisting of elements
+// that are found in both sorted ranges r1[first1, last1) and r2[first2, last2),
+// and returns the iterator past the last element written. If r1[first1, last1)
+// contains m elements that are equivalent to each other and r2[first2, last2)
+// contains n elements that are equivalent to them, the first min(m, n) elements
+// will be copied from r1. Both ranges must be sorted with operator<.
+func SetIntersection[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) int {
+	if debug {
+		checkRange("SetIntersection", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetIntersection", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetIntersection", "r1[first1, last1)", r1, first1, last1, less[T])
+		checkSorted("SetIntersection", "r2[first2, last2)", r2, first2, last2, less[T])
+	}
+
+	for first1 != last1 && first2 != last2 {
+		if r1[first1] < r2[first2] {
+			first1++
+		} else {
+			if !(r2[first2] < r1[first1]) {
+				if debug {
+					checkWrite("SetIntersection", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+				}
+				r3[d_first] = r1[first1]
+				d_first++
+				first1++
+			}
+			first2++
+		}
+	}
+	return d_first
+}
+
+// Constructs a sorted range beginning at r3[d_first] consisting of elements
+// that are found in both sorted ranges r1[first1, last1) and r2[first2, last2),
+// and returns the iterator past the last element written. If r1[first1, last1)
+// contains m elements that are equivalent to each other and r2[first2, last2)
+// contains n elements that are equivalent to them, the first min(m, n) elements
+// will be copied from r1. Both ranges must be sorted with the given comparison
+// function comp.
+func SetIntersectionFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) int {
+	if debug {
+		checkRange("SetIntersectionFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetIntersectionFunc", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetIntersectionFunc", "r1[first1, last1)", r1, first1, last1, comp)
+		checkSorted("SetIntersectionFunc", "r2[first2, last2)", r2, first2, last2, comp)
+	}
+
+	for first1 != last1 && first2 != last2 {
+		if comp(r1[first1], r2[first2]) {
+			first1++
+		} else {
+			if !comp(r2[first2], r1[first1]) {
+				if debug {
+					checkWrite("SetIntersectionFunc", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+				}
+				r3[d_first] = r1[first1]
+				d_first++
+				first1++
+			}
+			first2++
+		}
+	}
+	return d_first
+}
+
+// Computes the symmetric difference of two sorted ranges: the elements that are
+// found in either of the ranges, but not in both of them, are copied to the
+// range beginning at r3[d_first]. Returns the iterator past the last element
+// written. The output range is also sorted. If r1[first1, last1) contains m
+// elements that are equivalent to each other and r2[first2, last2) contains n
+// elements that are equivalent to them, the last |m - n| of those elements will
+// be copied from r1 if m > n, or from r2 otherwise. Both ranges must be sorted
+// with operator<.
+func SetSymmetricDifference[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) int {
+	if debug {
+		checkRange("SetSymmetricDifference", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetSymmetricDifference", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetSymmetricDifference", "r1[first1, last1)", r1, first1, last1, less[T])
+		checkSorted("SetSymmetricDifference", "r2[first2, last2)", r2, first2, last2, less[T])
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			if debug {
+				checkRoom("SetSymmetricDifference", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("SetSymmetricDifference", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if r1[first1] < r2[first2] {
+			if debug {
+				checkWrite("SetSymmetricDifference", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+			}
+			r3[d_first] = r1[first1]
+			d_first++
+			first1++
+		} else {
+			if r2[first2] < r1[first1] {
+				if debug {
+					checkWrite("SetSymmetricDifference", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+				}
+				r3[d_first] = r2[first2]
+				d_first++
+			} else {
+				first1++
+			}
+			first2++
+		}
+	}
+	if debug {
+		checkRoom("SetSymmetricDifference", "destination r3", len(r3), d_first, last2-first2)
+		checkNotWithin("SetSymmetricDifference", "d_first", r2, first2, last2, r3, d_first, false)
+	}
+	return Copy(r2, r3, first2, last2, d_first)
+}
+
+// Computes the symmetric difference of two sorted ranges: the elements that are
+// found in either of the ranges, but not in both of them, are copied to the
+// range beginning at r3[d_first]. Returns the iterator past the last element
+// written. The output range is also sorted. If r1[first1, last1) contains m
+// elements that are equivalent to each other and r2[first2, last2) contains n
+// elements that are equivalent to them, the last |m - n| of those elements will
+// be copied from r1 if m > n, or from r2 otherwise. Both ranges must be sorted
+// with the given comparison function comp.
+func SetSymmetricDifferenceFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) int {
+	if debug {
+		checkRange("SetSymmetricDifferenceFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetSymmetricDifferenceFunc", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetSymmetricDifferenceFunc", "r1[first1, last1)", r1, first1, last1, comp)
+		checkSorted("SetSymmetricDifferenceFunc", "r2[first2, last2)", r2, first2, last2, comp)
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			if debug {
+				checkRoom("SetSymmetricDifferenceFunc", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("SetSymmetricDifferenceFunc", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if comp(r1[first1], r2[first2]) {
+			if debug {
+				checkWrite("SetSymmetricDifferenceFunc", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+			}
+			r3[d_first] = r1[first1]
+			d_first++
+			first1++
+		} else {
+			if comp(r2[first2], r1[first1]) {
+				if debug {
+					checkWrite("SetSymmetricDifferenceFunc", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+				}
+				r3[d_first] = r2[first2]
+				d_first++
+			} else {
+				first1++
+			}
+			first2++
+		}
+	}
+	if debug {
+		checkRoom("SetSymmetricDifferenceFunc", "destination r3", len(r3), d_first, last2-first2)
+		checkNotWithin("SetSymmetricDifferenceFunc", "d_first", r2, first2, last2, r3, d_first, false)
+	}
+	return Copy(r2, r3, first2, last2, d_first)
+}
+
+// Constructs a sorted union beginning at r3[d_first] consisting of the set of
+// elements present in one or both sorted ranges r1[first1, last1) and
+// r2[first2, last2), and returns the iterator past the last element written. If
+// r1[first1, last1) contains m elements that are equivalent to each other and
+// r2[first2, last2) contains n elements that are equivalent to them, then all m
+// elements will be copied from r1 to the output range, preserving order, and
+// then the final max(n - m, 0) elements will be copied from r2. Both ranges
+// must be sorted with operator<.
+func SetUnion[T cmp.Ordered](r1, r2, r3 []T, first1, last1, first2, last2, d_first int) int {
+	if debug {
+		checkRange("SetUnion", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetUnion", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetUnion", "r1[first1, last1)", r1, first1, last1, less[T])
+		checkSorted("SetUnion", "r2[first2, last2)", r2, first2, last2, less[T])
+	}
+
+	for ; first1 != last1; d_first++ {
+		if first2 == last2 {
+			if debug {
+				checkRoom("SetUnion", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("SetUnion", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if debug {
+			checkWrite("SetUnion", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+		}
+		if r2[first2] < r1[first1] {
+			r3[d_first] = r2[first2]
+			first2++
+		} else {
+			r3[d_first] = r1[first1]
+			if !(r1[first1] < r2[first2]) {
+				first2++
+			}
+			first1++
+		}
+	}
+	if debug {
+		checkRoom("SetUnion", "destination r3", len(r3), d_first, last2-first2)
+		checkNotWithin("SetUnion", "d_first", r2, first2, last2, r3, d_first, false)
+	}
+	return Copy(r2, r3, first2, last2, d_first)
+}
+
+// Constructs a sorted union beginning at r3[d_first] consisting of the set of
+// elements present in one or both sorted ranges r1[first1, last1) and
+// r2[first2, last2), and returns the iterator past the last element written. If
+// r1[first1, last1) contains m elements that are equivalent to each other and
+// r2[first2, last2) contains n elements that are equivalent to them, then all m
+// elements will be copied from r1 to the output range, preserving order, and
+// then the final max(n - m, 0) elements will be copied from r2. Both ranges
+// must be sorted with the given comparison function comp.
+func SetUnionFunc[T any](r1, r2, r3 []T, first1, last1, first2, last2, d_first int, comp func(T, T) bool) int {
+	if debug {
+		checkRange("SetUnionFunc", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("SetUnionFunc", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("SetUnionFunc", "r1[first1, last1)", r1, first1, last1, comp)
+		checkSorted("SetUnionFunc", "r2[first2, last2)", r2, first2, last2, comp)
+	}
+
+	for ; first1 != last1; d_first++ {
+		if first2 == last2 {
+			if debug {
+				checkRoom("SetUnionFunc", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("SetUnionFunc", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if debug {
+			checkWrite("SetUnionFunc", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+		}
+		if comp(r2[first2], r1[first1]) {
+			r3[d_first] = r2[first2]
+			first2++
+		} else {
+			r3[d_first] = r1[first1]
+			if !comp(r1[first1], r2[first2]) {
+				first2++
+			}
+			first1++
+		}
+	}
+	if debug {
+		checkRoom("SetUnionFunc", "destination r3", len(r3), d_first, last2-first2)
+		checkNotWithin("SetUnionFunc", "d_first", r2, first2, last2, r3, d_first, false)
+	}
+	return Copy(r2, r3, first2, last2, d_first)
+}
@@ -0,0 +1,983 @@
+//go:build ignore
+
+// This program generates variants.go. Run it with go generate.
+//
+// Each algorithm below is defined once, as a template of its doc comment and
+// a template of its function, and both are executed for each variant of the
+// algorithm. The variants of an algorithm depend on how it compares elements:
+//
+//   - an algorithm that matches elements against a value has a variant that
+//     uses operator==, such as Find, one that takes a unary predicate p, such
+//     as FindIf, and optionally one that takes a unary predicate q that must
+//     return false, such as FindIfNot;
+//   - an algorithm that compares elements for equality has a variant that
+//     uses operator==, such as Search, and one that takes a binary predicate
+//     p, such as SearchFunc;
+//   - an algorithm that orders elements has a variant that uses operator<,
+//     such as Includes, and one that takes a comparison function comp, such
+//     as IncludesFunc.
+//
+// Algorithms over two ranges may also have variants that take the end of the
+// second range, last2, instead of assuming that it is as long as the first,
+// such as Equal2 and EqualFunc2. Variants that apply a projection to the
+// elements before comparing them, or that write to an iterator.Sink, would be
+// added the same way, as more fields of variant.
+//
+// The templates refer to the variant with the methods of variant, such as
+// {{.Eq "a" "b"}}, which is a == b or p(a, b). The doc comments are reflowed
+// to fit in 80 columns.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// kind is how an algorithm compares elements.
+type kind int
+
+const (
+	valueKind kind = iota // against a value, or with a unary predicate
+	equalKind             // with operator==, or a binary predicate
+	lessKind              // with operator<, or a comparison function
+)
+
+// algo is the definition of an algorithm and its variants.
+type algo struct {
+	name  string // the name of the base variant, such as Find
+	kind  kind
+	value string // for valueKind, the name of the value; "value" if empty
+	opt   bool   // whether the names end with Opt, after the suffix
+	ifNot bool   // for valueKind, whether there is an IfNot variant
+	two   bool   // whether there are 2 variants
+	doc   string // template of the doc comment
+	fn    string // template of the function
+}
+
+// variant is a variant of an algorithm, the data of its templates.
+type variant struct {
+	Name   string // the name of the function, such as MismatchFunc2
+	kind   kind
+	suffix string // "", "If", "IfNot" or "Func"
+	value  string
+	Two    bool // whether the variant takes last2
+}
+
+// Func reports whether the variant takes a function instead of using an
+// operator or a value.
+func (v variant) Func() bool {
+	return v.suffix != ""
+}
+
+// T returns the type parameter list of the variant.
+func (v variant) T() string {
+	switch {
+	case v.Func():
+		return "T any"
+	case v.kind == lessKind:
+		return "T cmp.Ordered"
+	}
+	return "T comparable"
+}
+
+// Param returns the declaration of the value or function parameter of the
+// variant, or "" if it has none.
+func (v variant) Param() string {
+	switch v.suffix {
+	case "If":
+		return "p func(T) bool"
+	case "IfNot":
+		return "q func(T) bool"
+	case "Func":
+		if v.kind == lessKind {
+			return "comp func(T, T) bool"
+		}
+		return "p func(T, T) bool"
+	}
+	if v.kind == valueKind {
+		return v.value + " T"
+	}
+	return ""
+}
+
+// Arg returns the name of the value or function parameter of the variant.
+func (v variant) Arg() string {
+	if p := v.Param(); p != "" {
+		return strings.Fields(p)[0]
+	}
+	return ""
+}
+
+// Call returns the name of the variant of the algorithm base that corresponds
+// to this one.
+func (v variant) Call(base string) string {
+	return base + v.suffix
+}
+
+// Match returns the condition that element x matches.
+func (v variant) Match(x string) string {
+	switch v.suffix {
+	case "If":
+		return fmt.Sprintf("p(%s)", x)
+	case "IfNot":
+		return fmt.Sprintf("!q(%s)", x)
+	}
+	return fmt.Sprintf("%s == %s", x, v.value)
+}
+
+// NoMatch returns the condition that element x does not match.
+func (v variant) NoMatch(x string) string {
+	switch v.suffix {
+	case "If":
+		return fmt.Sprintf("!p(%s)", x)
+	case "IfNot":
+		return fmt.Sprintf("q(%s)", x)
+	}
+	return fmt.Sprintf("%s != %s", x, v.value)
+}
+
+// Criterion describes the elements that match, as in "the elements equal to
+// value (using operator==)".
+func (v variant) Criterion() string {
+	switch v.suffix {
+	case "If":
+		return "for which predicate p returns true"
+	case "IfNot":
+		return "for which predicate q returns false"
+	}
+	return fmt.Sprintf("equal to %s (using operator==)", v.value)
+}
+
+// Eq returns the condition that a and b are equal.
+func (v variant) Eq(a, b string) string {
+	if v.Func() {
+		return fmt.Sprintf("p(%s, %s)", a, b)
+	}
+	return fmt.Sprintf("%s == %s", a, b)
+}
+
+// Ne returns the condition that a and b are not equal.
+func (v variant) Ne(a, b string) string {
+	if v.Func() {
+		return fmt.Sprintf("!p(%s, %s)", a, b)
+	}
+	return fmt.Sprintf("%s != %s", a, b)
+}
+
+// Less returns the condition that a is ordered before b.
+func (v variant) Less(a, b string) string {
+	if v.Func() {
+		return fmt.Sprintf("comp(%s, %s)", a, b)
+	}
+	return fmt.Sprintf("%s < %s", a, b)
+}
+
+// NotLess returns the condition that a is not ordered before b.
+func (v variant) NotLess(a, b string) string {
+	if v.Func() {
+		return fmt.Sprintf("!comp(%s, %s)", a, b)
+	}
+	return fmt.Sprintf("!(%s < %s)", a, b)
+}
+
+// Comp returns the comparison function of the variant, for the checks of the
+// debug mode.
+func (v variant) Comp() string {
+	if v.Func() {
+		return "comp"
+	}
+	return "less[T]"
+}
+
+// Op describes how the variant compares elements, as in "Elements are
+// compared using operator==".
+func (v variant) Op() string {
+	switch {
+	case v.kind == lessKind && v.Func():
+		return "the given comparison function comp"
+	case v.kind == lessKind:
+		return "operator<"
+	case v.Func():
+		return "the given binary predicate p"
+	}
+	return "operator=="
+}
+
+// Range2 describes the second range of an algorithm over two ranges.
+func (v variant) Range2() string {
+	if v.Two {
+		return "r2[first2, last2)"
+	}
+	return "r2[first2, first2 + (last1 - first1))"
+}
+
+// variants returns the variants of a.
+func (a algo) variants() []variant {
+	suffixes := []string{"", "Func"}
+	if a.kind == valueKind {
+		suffixes = []string{"", "If"}
+		if a.ifNot {
+			suffixes = append(suffixes, "IfNot")
+		}
+	}
+	value := a.value
+	if value == "" {
+		value = "value"
+	}
+	var vs []variant
+	for _, two := range []bool{false, true} {
+		if two && !a.two {
+			break
+		}
+		for _, s := range suffixes {
+			name := a.name + s
+			if a.opt {
+				name += "Opt"
+			}
+			if two {
+				name += "2"
+			}
+			vs = append(vs, variant{Name: name, kind: a.kind, suffix: s, value: value, Two: two})
+		}
+	}
+	return vs
+}
+
+var algos = []algo{
+	{
+		name:  "Find",
+		kind:  valueKind,
+		ifNot: true,
+		doc: `Searches the range r[first, last) for the first element {{.Criterion}}.
+Returns the iterator to the element, or last if there is no such element.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, {{.Param}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+{{if not .Func}}
+	if it, ok := findFast(r, first, last, value); ok {
+		return it
+	}
+{{end}}
+	for ; first != last; first++ {
+		if {{.Match "r[first]"}} {
+			return first
+		}
+	}
+	return last
+}
+`,
+	},
+	{
+		name:  "Find",
+		kind:  valueKind,
+		opt:   true,
+		ifNot: true,
+		doc: `Searches the range r[first, last) for the first element {{.Criterion}}.
+Returns the iterator to the element as an Optional, which is empty if there is
+no such element, instead of last.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, {{.Param}}) utility.Optional[int] {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+
+	if it := {{.Call "Find"}}(r, first, last, {{.Arg}}); it != last {
+		return utility.MakeOptional(it)
+	}
+	return utility.Nullopt[int]()
+}
+`,
+	},
+	{
+		name: "Count",
+		kind: valueKind,
+		doc:  `Returns the number of elements in the range r[first, last) {{.Criterion}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, {{.Param}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+{{if not .Func}}
+	if ret, ok := countFast(r, first, last, value); ok {
+		return ret
+	}
+{{end}}
+	ret := 0
+	for ; first != last; first++ {
+		if {{.Match "r[first]"}} {
+			ret++
+		}
+	}
+	return ret
+}
+`,
+	},
+	{
+		name:  "Replace",
+		kind:  valueKind,
+		value: "old_value",
+		doc:   `Replaces all elements in the range r[first, last) {{.Criterion}} with new_value.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, {{.Param}}, new_value T) {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+
+	for ; first != last; first++ {
+		if {{.Match "r[first]"}} {
+			r[first] = new_value
+		}
+	}
+}
+`,
+	},
+	{
+		name:  "ReplaceCopy",
+		kind:  valueKind,
+		value: "old_value",
+		doc: `Copies the elements from the range r1[first, last) to another range beginning
+at r2[d_first], replacing all elements {{.Criterion}} with new_value, and
+returns the iterator past the last element written. If the source and
+destination ranges overlap, the behavior is undefined.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first, last, d_first int, {{.Param}}, new_value T) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first, last)", len(r1), first, last)
+		checkRoom("{{.Name}}", "destination r2", len(r2), d_first, last-first)
+		checkNoOverlap("{{.Name}}", r1[first:last], r2[d_first:d_first+last-first])
+	}
+
+	for ; first != last; first++ {
+		r2[d_first] = r1[first]
+		if {{.Match "r2[d_first]"}} {
+			r2[d_first] = new_value
+		}
+		d_first++
+	}
+	return d_first
+}
+`,
+	},
+	{
+		name: "Remove",
+		kind: valueKind,
+		doc: `Removes all elements {{.Criterion}} from the range r[first, last) and returns
+a past-the-end iterator for the new end of the range. Removing is done by
+shifting the elements in the range in such a way that elements to be erased are
+overwritten; the relative order of the elements that remain is preserved.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, {{.Param}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+
+	first = {{.Call "Find"}}(r, first, last, {{.Arg}})
+	if first != last {
+		for i := first + 1; i != last; i++ {
+			if {{.NoMatch "r[i]"}} {
+				r[first] = r[i]
+				first++
+			}
+		}
+	}
+	return first
+}
+`,
+	},
+	{
+		name: "RemoveCopy",
+		kind: valueKind,
+		doc: `Copies the elements from the range r1[first, last) to another range beginning
+at r2[d_first], omitting the elements {{.Criterion}}, and returns the iterator
+past the last element written. If the source and destination ranges overlap,
+the behavior is undefined.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first, last, d_first int, {{.Param}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first, last)", len(r1), first, last)
+	}
+
+	for ; first != last; first++ {
+		if {{.NoMatch "r1[first]"}} {
+			if debug {
+				checkWrite("{{.Name}}", "r2", r2, d_first, r1[first:last])
+			}
+			r2[d_first] = r1[first]
+			d_first++
+		}
+	}
+	return d_first
+}
+`,
+	},
+	{
+		name: "AdjacentFind",
+		kind: equalKind,
+		doc: `Searches the range r[first, last) for two consecutive equal elements. Returns
+an iterator to the first of the first pair of equal elements, that is, the
+first iterator it such that {{.Eq "r[it]" "r[it + 1]"}}, or last if there is
+no such pair. Elements are compared using {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return last
+	}
+
+	for next := first + 1; next != last; {
+		if {{.Eq "r[first]" "r[next]"}} {
+			return first
+		}
+		next++
+		first++
+	}
+
+	return last
+}
+`,
+	},
+	{
+		name: "Mismatch",
+		kind: equalKind,
+		two:  true,
+		doc: `Returns the first mismatching pair of elements from two ranges: one defined
+by r1[first1, last1) and another defined by {{.Range2}}. Elements are compared
+using {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first1, last1, first2{{if .Two}}, last2{{end}} int{{with .Param}}, {{.}}{{end}}) utility.Pair[int, int] {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+{{- if .Two}}
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+{{- else}}
+		checkRoom("{{.Name}}", "r2", len(r2), first2, last1-first1)
+{{- end}}
+	}
+
+	for first1 != last1{{if .Two}} && first2 != last2{{end}} && {{.Eq "r1[first1]" "r2[first2]"}} {
+		first1++
+		first2++
+	}
+
+	return utility.MakePair(first1, first2)
+}
+`,
+	},
+	{
+		name: "Equal",
+		kind: equalKind,
+		two:  true,
+		doc: `Returns true if the range r1[first1, last1) is equal to the range {{.Range2}},
+and false otherwise. Two ranges are considered equal if they have the same
+number of elements and, for every iterator i in the range [first1, last1), *i
+equals *(first2 + (i - first1)). Elements are compared using {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first1, last1, first2{{if .Two}}, last2{{end}} int{{with .Param}}, {{.}}{{end}}) bool {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+{{- if .Two}}
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+{{- else}}
+		checkRoom("{{.Name}}", "r2", len(r2), first2, last1-first1)
+{{- end}}
+	}
+{{if .Two}}
+	if last1-first1 != last2-first2 {
+		return false
+	}
+{{end}}
+	for first1 != last1 {
+		if {{.Ne "r1[first1]" "r2[first2]"}} {
+			return false
+		}
+		first1++
+		first2++
+	}
+
+	return true
+}
+`,
+	},
+	{
+		name: "Search",
+		kind: equalKind,
+		doc: `Searches for the first occurrence of the sequence of elements r2[s_first,
+s_last) in the range r1[first, last). Returns the iterator to the beginning of
+the occurrence, or last if there is none; an empty sequence is found at first.
+Elements are compared using {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first, last, s_first, s_last int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first, last)", len(r1), first, last)
+		checkRange("{{.Name}}", "r2[s_first, s_last)", len(r2), s_first, s_last)
+	}
+{{if not .Func}}
+	if it, ok := searchFast(r1, r2, first, last, s_first, s_last); ok {
+		return it
+	}
+{{end}}
+	for {
+		it := first
+		for s_it := s_first; ; {
+			if s_it == s_last {
+				return first
+			}
+			if it == last {
+				return last
+			}
+			if {{.Ne "r1[it]" "r2[s_it]"}} {
+				break
+			}
+			it++
+			s_it++
+		}
+		first++
+	}
+}
+`,
+	},
+	{
+		name: "SearchN",
+		kind: equalKind,
+		doc: `Searches the range r[first, last) for the first sequence of count identical
+elements, each equal to the given value. Returns the iterator to the beginning
+of the sequence, or last if there is none; if count <= 0, returns first.
+Elements are compared using {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last, count int, value T{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+
+	if count <= 0 {
+		return first
+	}
+
+	for ; first != last; first++ {
+		if {{.Ne "r[first]" "value"}} {
+			continue
+		}
+
+		candidate := first
+
+		for cur_count := 1; ; cur_count++ {
+			if cur_count >= count {
+				return candidate
+			}
+
+			first++
+			if first == last {
+				return last
+			}
+
+			if {{.Ne "r[first]" "value"}} {
+				break
+			}
+		}
+	}
+	return last
+}
+`,
+	},
+	{
+		name: "Unique",
+		kind: equalKind,
+		doc: `Eliminates all except the first element from every consecutive group of
+equivalent elements from the range r[first, last) and returns a past-the-end
+iterator for the new logical end of the range. Removing is done by shifting the
+elements in the range in such a way that elements to be erased are
+overwritten. Elements are compared using {{.Op}}. The behavior is undefined if
+it is not an equivalence relation.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+	}
+
+	if first == last {
+		return last
+	}
+
+	result := first
+	for first++; first != last; first++ {
+		if {{.Ne "r[result]" "r[first]"}} {
+			result++
+			r[result] = r[first]
+		}
+	}
+	return result + 1
+}
+`,
+	},
+	{
+		name: "UniqueCopy",
+		kind: equalKind,
+		doc: `Copies the elements from the range r1[first, last) to another range beginning
+at r2[d_first] in such a way that there are no consecutive equal elements, and
+returns the iterator past the last element written. Only the first element of
+each group of equal elements is copied. Elements are compared using {{.Op}}.
+The behavior is undefined if it is not an equivalence relation.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first, last, d_first int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first, last)", len(r1), first, last)
+	}
+
+	if first == last {
+		return d_first
+	}
+
+	if debug {
+		checkWrite("{{.Name}}", "r2", r2, d_first, r1[first:last])
+	}
+	r2[d_first] = r1[first]
+
+	for first++; first != last; first++ {
+		if {{.Ne "r2[d_first]" "r1[first]"}} {
+			d_first++
+			if debug {
+				checkWrite("{{.Name}}", "r2", r2, d_first, r1[first:last])
+			}
+			r2[d_first] = r1[first]
+		}
+	}
+
+	return d_first + 1
+}
+`,
+	},
+	{
+		name: "LowerBound",
+		kind: lessKind,
+		doc: `Returns an iterator pointing to the first element in the range r[first, last)
+such that {{.Less "element" "value"}} is false, or last if no such element is
+found. The range must be sorted with {{.Op}}, or at least partitioned with
+respect to {{.Less "element" "value"}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, value T{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+		checkPartitioned("{{.Name}}", "{{.Less "element" "value"}}", r, first, last, func(e T) bool { return {{.Less "e" "value"}} })
+	}
+
+	for count := last - first; count > 0; {
+		step := count / 2
+		if it := first + step; {{.Less "r[it]" "value"}} {
+			first = it + 1
+			count -= step + 1
+		} else {
+			count = step
+		}
+	}
+	return first
+}
+`,
+	},
+	{
+		name: "UpperBound",
+		kind: lessKind,
+		doc: `Returns an iterator pointing to the first element in the range r[first, last)
+such that {{.Less "value" "element"}} is true, or last if no such element is
+found. The range must be sorted with {{.Op}}, or at least partitioned with
+respect to {{.NotLess "value" "element"}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r []T, first, last int, value T{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r[first, last)", len(r), first, last)
+		checkPartitioned("{{.Name}}", "{{.NotLess "value" "element"}}", r, first, last, func(e T) bool { return {{.NotLess "value" "e"}} })
+	}
+
+	for count := last - first; count > 0; {
+		step := count / 2
+		if it := first + step; {{.NotLess "value" "r[it]"}} {
+			first = it + 1
+			count -= step + 1
+		} else {
+			count = step
+		}
+	}
+	return first
+}
+`,
+	},
+	{
+		name: "Includes",
+		kind: lessKind,
+		doc: `Returns true if the sorted range r2[first2, last2) is a subsequence of the
+sorted range r1[first1, last1) (a subsequence need not be contiguous). Both
+ranges must be sorted with {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2 []T, first1, last1, first2, last2 int{{with .Param}}, {{.}}{{end}}) bool {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("{{.Name}}", "r1[first1, last1)", r1, first1, last1, {{.Comp}})
+		checkSorted("{{.Name}}", "r2[first2, last2)", r2, first2, last2, {{.Comp}})
+	}
+
+	for ; first2 != last2; first1++ {
+		if first1 == last1 || {{.Less "r2[first2]" "r1[first1]"}} {
+			return false
+		}
+		if {{.NotLess "r1[first1]" "r2[first2]"}} {
+			first2++
+		}
+	}
+	return true
+}
+`,
+	},
+	{
+		name: "SetDifference",
+		kind: lessKind,
+		doc: `Copies the elements from the sorted range r1[first1, last1) which are not
+found in the sorted range r2[first2, last2) to the range beginning at
+r3[d_first], and returns the iterator past the last element written. The output
+range is also sorted. If r1[first1, last1) contains m elements that are
+equivalent to each other and r2[first2, last2) contains n elements that are
+equivalent to them, the final max(m - n, 0) elements will be copied from r1.
+Both ranges must be sorted with {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2, r3 []T, first1, last1, first2, last2, d_first int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("{{.Name}}", "r1[first1, last1)", r1, first1, last1, {{.Comp}})
+		checkSorted("{{.Name}}", "r2[first2, last2)", r2, first2, last2, {{.Comp}})
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			if debug {
+				checkRoom("{{.Name}}", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("{{.Name}}", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if {{.Less "r1[first1]" "r2[first2]"}} {
+			if debug {
+				checkWrite("{{.Name}}", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+			}
+			r3[d_first] = r1[first1]
+			d_first++
+			first1++
+		} else {
+			if {{.NotLess "r2[first2]" "r1[first1]"}} {
+				first1++
+			}
+			first2++
+		}
+	}
+	return d_first
+}
+`,
+	},
+	{
+		name: "SetIntersection",
+		kind: lessKind,
+		doc: `Constructs a sorted range beginning at r3[d_first] consisting of elements
+that are found in both sorted ranges r1[first1, last1) and r2[first2, last2),
+and returns the iterator past the last element written. If r1[first1, last1)
+contains m elements that are equivalent to each other and r2[first2, last2)
+contains n elements that are equivalent to them, the first min(m, n) elements
+will be copied from r1. Both ranges must be sorted with {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2, r3 []T, first1, last1, first2, last2, d_first int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("{{.Name}}", "r1[first1, last1)", r1, first1, last1, {{.Comp}})
+		checkSorted("{{.Name}}", "r2[first2, last2)", r2, first2, last2, {{.Comp}})
+	}
+
+	for first1 != last1 && first2 != last2 {
+		if {{.Less "r1[first1]" "r2[first2]"}} {
+			first1++
+		} else {
+			if {{.NotLess "r2[first2]" "r1[first1]"}} {
+				if debug {
+					checkWrite("{{.Name}}", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+				}
+				r3[d_first] = r1[first1]
+				d_first++
+				first1++
+			}
+			first2++
+		}
+	}
+	return d_first
+}
+`,
+	},
+	{
+		name: "SetSymmetricDifference",
+		kind: lessKind,
+		doc: `Computes the symmetric difference of two sorted ranges: the elements that are
+found in either of the ranges, but not in both of them, are copied to the range
+beginning at r3[d_first]. Returns the iterator past the last element written.
+The output range is also sorted. If r1[first1, last1) contains m elements that
+are equivalent to each other and r2[first2, last2) contains n elements that are
+equivalent to them, the last |m - n| of those elements will be copied from r1
+if m > n, or from r2 otherwise. Both ranges must be sorted with {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2, r3 []T, first1, last1, first2, last2, d_first int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("{{.Name}}", "r1[first1, last1)", r1, first1, last1, {{.Comp}})
+		checkSorted("{{.Name}}", "r2[first2, last2)", r2, first2, last2, {{.Comp}})
+	}
+
+	for first1 != last1 {
+		if first2 == last2 {
+			if debug {
+				checkRoom("{{.Name}}", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("{{.Name}}", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if {{.Less "r1[first1]" "r2[first2]"}} {
+			if debug {
+				checkWrite("{{.Name}}", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+			}
+			r3[d_first] = r1[first1]
+			d_first++
+			first1++
+		} else {
+			if {{.Less "r2[first2]" "r1[first1]"}} {
+				if debug {
+					checkWrite("{{.Name}}", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+				}
+				r3[d_first] = r2[first2]
+				d_first++
+			} else {
+				first1++
+			}
+			first2++
+		}
+	}
+	if debug {
+		checkRoom("{{.Name}}", "destination r3", len(r3), d_first, last2-first2)
+		checkNotWithin("{{.Name}}", "d_first", r2, first2, last2, r3, d_first, false)
+	}
+	return Copy(r2, r3, first2, last2, d_first)
+}
+`,
+	},
+	{
+		name: "SetUnion",
+		kind: lessKind,
+		doc: `Constructs a sorted union beginning at r3[d_first] consisting of the set of
+elements present in one or both sorted ranges r1[first1, last1) and r2[first2,
+last2), and returns the iterator past the last element written. If r1[first1,
+last1) contains m elements that are equivalent to each other and r2[first2,
+last2) contains n elements that are equivalent to them, then all m elements
+will be copied from r1 to the output range, preserving order, and then the
+final max(n - m, 0) elements will be copied from r2. Both ranges must be
+sorted with {{.Op}}.`,
+		fn: `
+func {{.Name}}[{{.T}}](r1, r2, r3 []T, first1, last1, first2, last2, d_first int{{with .Param}}, {{.}}{{end}}) int {
+	if debug {
+		checkRange("{{.Name}}", "r1[first1, last1)", len(r1), first1, last1)
+		checkRange("{{.Name}}", "r2[first2, last2)", len(r2), first2, last2)
+		checkSorted("{{.Name}}", "r1[first1, last1)", r1, first1, last1, {{.Comp}})
+		checkSorted("{{.Name}}", "r2[first2, last2)", r2, first2, last2, {{.Comp}})
+	}
+
+	for ; first1 != last1; d_first++ {
+		if first2 == last2 {
+			if debug {
+				checkRoom("{{.Name}}", "destination r3", len(r3), d_first, last1-first1)
+				checkNotWithin("{{.Name}}", "d_first", r1, first1, last1, r3, d_first, false)
+			}
+			return Copy(r1, r3, first1, last1, d_first)
+		}
+
+		if debug {
+			checkWrite("{{.Name}}", "r3", r3, d_first, r1[first1:last1], r2[first2:last2])
+		}
+		if {{.Less "r2[first2]" "r1[first1]"}} {
+			r3[d_first] = r2[first2]
+			first2++
+		} else {
+			r3[d_first] = r1[first1]
+			if {{.NotLess "r1[first1]" "r2[first2]"}} {
+				first2++
+			}
+			first1++
+		}
+	}
+	if debug {
+		checkRoom("{{.Name}}", "destination r3", len(r3), d_first, last2-first2)
+		checkNotWithin("{{.Name}}", "d_first", r2, first2, last2, r3, d_first, false)
+	}
+	return Copy(r2, r3, first2, last2, d_first)
+}
+`,
+	},
+}
+
+func main() {
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by variants_gen.go; DO NOT EDIT.
+
+package algorithm
+
+import (
+	"cmp"
+
+	"gocpp/utility"
+)
+`)
+
+	for _, a := range algos {
+		doc := template.Must(template.New(a.name + " doc").Parse(a.doc))
+		fn := template.Must(template.New(a.name).Parse(a.fn))
+		for _, v := range a.variants() {
+			var text bytes.Buffer
+			if err := doc.Execute(&text, v); err != nil {
+				log.Fatal(err)
+			}
+			b.WriteString("\n" + comment(text.String()))
+			if err := fn.Execute(&b, v); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("%v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile("variants.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// comment formats text as a line comment, with its words reflowed to fit in
+// 80 columns.
+func comment(text string) string {
+	var b strings.Builder
+	line := "//"
+	for _, w := range strings.Fields(text) {
+		if len(line)+1+len(w) > 80 && line != "//" {
+			b.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + w
+	}
+	b.WriteString(line)
+	return b.String()
+}
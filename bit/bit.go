@@ -0,0 +1,116 @@
+package bit
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// Unsigned is a constraint that permits any unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// width returns the number of bits in T.
+func width[T Unsigned]() int {
+	var x T
+	return int(unsafe.Sizeof(x)) * 8
+}
+
+// Checks if x is an integral power of two.
+func HasSingleBit[T Unsigned](x T) bool {
+	return x != 0 && x&(x-1) == 0
+}
+
+// Calculates the smallest integral power of two that is not smaller than x. If
+// that value is not representable in T, the behavior is undefined; this
+// implementation returns 0.
+func BitCeil[T Unsigned](x T) T {
+	if x <= 1 {
+		return 1
+	}
+	w := BitWidth(x - 1)
+	if w >= width[T]() {
+		return 0
+	}
+	return T(1) << w
+}
+
+// If x is not zero, calculates the largest integral power of two that is not
+// greater than x. If x is zero, returns zero.
+func BitFloor[T Unsigned](x T) T {
+	if x == 0 {
+		return 0
+	}
+	return T(1) << (BitWidth(x) - 1)
+}
+
+// If x is not zero, calculates the number of bits needed to store the value x,
+// that is, 1 + floor(log2(x)). If x is zero, returns zero.
+func BitWidth[T Unsigned](x T) int {
+	return bits.Len64(uint64(x))
+}
+
+// Computes the result of bitwise left-rotating the value of x by s positions.
+// A negative s rotates right.
+func Rotl[T Unsigned](x T, s int) T {
+	w := width[T]()
+	r := s % w
+	if r == 0 {
+		return x
+	}
+	if r < 0 {
+		r += w
+	}
+	return x<<r | x>>(w-r)
+}
+
+// Computes the result of bitwise right-rotating the value of x by s positions.
+// A negative s rotates left.
+func Rotr[T Unsigned](x T, s int) T {
+	return Rotl(x, -(s % width[T]()))
+}
+
+// Returns the number of consecutive 0 bits in the value of x, starting from
+// the most significant bit ("left").
+func CountlZero[T Unsigned](x T) int {
+	return bits.LeadingZeros64(uint64(x)) - (64 - width[T]())
+}
+
+// Returns the number of consecutive 1 bits in the value of x, starting from
+// the most significant bit ("left").
+func CountlOne[T Unsigned](x T) int {
+	return CountlZero(^x)
+}
+
+// Returns the number of consecutive 0 bits in the value of x, starting from
+// the least significant bit ("right").
+func CountrZero[T Unsigned](x T) int {
+	if x == 0 {
+		return width[T]()
+	}
+	return bits.TrailingZeros64(uint64(x))
+}
+
+// Returns the number of consecutive 1 bits in the value of x, starting from
+// the least significant bit ("right").
+func CountrOne[T Unsigned](x T) int {
+	return CountrZero(^x)
+}
+
+// Returns the number of 1 bits in the value of x.
+func Popcount[T Unsigned](x T) int {
+	return bits.OnesCount64(uint64(x))
+}
+
+// Reverses the bytes in the given integer value x.
+func Byteswap[T Unsigned](x T) T {
+	switch width[T]() {
+	case 16:
+		return T(bits.ReverseBytes16(uint16(x)))
+	case 32:
+		return T(bits.ReverseBytes32(uint32(x)))
+	case 64:
+		return T(bits.ReverseBytes64(uint64(x)))
+	}
+	return x
+}
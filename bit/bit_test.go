@@ -0,0 +1,129 @@
+package bit
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRot(t *testing.T) {
+	tests := []struct {
+		x          uint8
+		s          int
+		rotl, rotr uint8
+	}{
+		{0b00011101, 0, 0b00011101, 0b00011101},
+		{0b00011101, 1, 0b00111010, 0b10001110},
+		{0b00011101, 4, 0b11010001, 0b11010001},
+		{0b00011101, 8, 0b00011101, 0b00011101},
+		{0b00011101, 9, 0b00111010, 0b10001110},
+		{0b00011101, -1, 0b10001110, 0b00111010},
+		{0b00011101, -9, 0b10001110, 0b00111010},
+		{0b00011101, 800, 0b00011101, 0b00011101},
+		{0b00011101, math.MaxInt, 0b10001110, 0b00111010},
+		{0b00011101, math.MinInt, 0b00011101, 0b00011101},
+	}
+	for _, tt := range tests {
+		if got := Rotl(tt.x, tt.s); got != tt.rotl {
+			t.Errorf("Rotl(%08b, %d) = %08b, want %08b", tt.x, tt.s, got, tt.rotl)
+		}
+		if got := Rotr(tt.x, tt.s); got != tt.rotr {
+			t.Errorf("Rotr(%08b, %d) = %08b, want %08b", tt.x, tt.s, got, tt.rotr)
+		}
+	}
+	if got := Rotl(uint64(1), -1); got != 1<<63 {
+		t.Errorf("Rotl(uint64(1), -1) = %#x", got)
+	}
+	if got := Rotr(uint16(1), 17); got != 1<<15 {
+		t.Errorf("Rotr(uint16(1), 17) = %#x", got)
+	}
+}
+
+func TestPowersOfTwo(t *testing.T) {
+	tests := []struct {
+		x           uint8
+		single      bool
+		ceil, floor uint8
+		width       int
+	}{
+		{0, false, 1, 0, 0},
+		{1, true, 1, 1, 1},
+		{2, true, 2, 2, 2},
+		{3, false, 4, 2, 2},
+		{5, false, 8, 4, 3},
+		{64, true, 64, 64, 7},
+		{128, true, 128, 128, 8},
+		// 2^8 is not representable in uint8.
+		{129, false, 0, 128, 8},
+		{255, false, 0, 128, 8},
+	}
+	for _, tt := range tests {
+		if got := HasSingleBit(tt.x); got != tt.single {
+			t.Errorf("HasSingleBit(%d) = %v, want %v", tt.x, got, tt.single)
+		}
+		if got := BitCeil(tt.x); got != tt.ceil {
+			t.Errorf("BitCeil(%d) = %d, want %d", tt.x, got, tt.ceil)
+		}
+		if got := BitFloor(tt.x); got != tt.floor {
+			t.Errorf("BitFloor(%d) = %d, want %d", tt.x, got, tt.floor)
+		}
+		if got := BitWidth(tt.x); got != tt.width {
+			t.Errorf("BitWidth(%d) = %d, want %d", tt.x, got, tt.width)
+		}
+	}
+	if got := BitCeil(uint64(1<<63 + 1)); got != 0 {
+		t.Errorf("BitCeil(2^63+1) = %d, want 0", got)
+	}
+	if got := BitCeil(uint64(1 << 63)); got != 1<<63 {
+		t.Errorf("BitCeil(2^63) = %d", got)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		x                               uint16
+		countlZero, countlOne           int
+		countrZero, countrOne, popcount int
+	}{
+		{0, 16, 0, 16, 0, 0},
+		{0xffff, 0, 16, 0, 16, 16},
+		{1, 15, 0, 0, 1, 1},
+		{0x8000, 0, 1, 15, 0, 1},
+		{0xf00f, 0, 4, 0, 4, 8},
+		{0x0ff0, 4, 0, 4, 0, 8},
+	}
+	for _, tt := range tests {
+		if got := CountlZero(tt.x); got != tt.countlZero {
+			t.Errorf("CountlZero(%#x) = %d, want %d", tt.x, got, tt.countlZero)
+		}
+		if got := CountlOne(tt.x); got != tt.countlOne {
+			t.Errorf("CountlOne(%#x) = %d, want %d", tt.x, got, tt.countlOne)
+		}
+		if got := CountrZero(tt.x); got != tt.countrZero {
+			t.Errorf("CountrZero(%#x) = %d, want %d", tt.x, got, tt.countrZero)
+		}
+		if got := CountrOne(tt.x); got != tt.countrOne {
+			t.Errorf("CountrOne(%#x) = %d, want %d", tt.x, got, tt.countrOne)
+		}
+		if got := Popcount(tt.x); got != tt.popcount {
+			t.Errorf("Popcount(%#x) = %d, want %d", tt.x, got, tt.popcount)
+		}
+	}
+	if CountlZero(uint8(0)) != 8 || CountlZero(uint32(0)) != 32 || CountlZero(uint64(0)) != 64 {
+		t.Errorf("CountlZero(0) is not the width of the type")
+	}
+}
+
+func TestByteswap(t *testing.T) {
+	if got := Byteswap(uint8(0x12)); got != 0x12 {
+		t.Errorf("Byteswap(uint8) = %#x", got)
+	}
+	if got := Byteswap(uint16(0x1234)); got != 0x3412 {
+		t.Errorf("Byteswap(uint16) = %#x", got)
+	}
+	if got := Byteswap(uint32(0x12345678)); got != 0x78563412 {
+		t.Errorf("Byteswap(uint32) = %#x", got)
+	}
+	if got := Byteswap(uint64(0x0123456789abcdef)); got != 0xefcdab8967452301 {
+		t.Errorf("Byteswap(uint64) = %#x", got)
+	}
+}
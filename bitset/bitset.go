@@ -0,0 +1,340 @@
+package bitset
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrOverflow is returned when the value of a Bitset cannot be
+	// represented in the requested integer type, like std::overflow_error.
+	ErrOverflow = errors.New("bitset: value does not fit in the result type")
+
+	// ErrInvalidArgument is returned when a string holds a character that is
+	// neither the zero nor the one character, like std::invalid_argument.
+	ErrInvalidArgument = errors.New("bitset: invalid character")
+)
+
+const wordBits = 64
+
+// Bitset represents a fixed-size sequence of bits, like std::bitset. The size
+// is chosen when the Bitset is constructed and never changes. Bit 0 is the
+// least significant bit. Binary operations require both operands to have the
+// same size. The zero Bitset has size 0.
+type Bitset struct {
+	words []uint64
+	n     int
+}
+
+// Constructs a Bitset of n bits, all of them zero.
+func New(n int) *Bitset {
+	if n < 0 {
+		panic(fmt.Sprintf("bitset: negative size %d", n))
+	}
+	return &Bitset{make([]uint64, (n+wordBits-1)/wordBits), n}
+}
+
+// Constructs a Bitset of n bits, initializing the first (rightmost, least
+// significant) min(n, 64) bit positions to the corresponding bit values of
+// val.
+func FromUint64(n int, val uint64) *Bitset {
+	b := New(n)
+	if len(b.words) > 0 {
+		b.words[0] = val
+		b.sanitize()
+	}
+	return b
+}
+
+// Constructs a Bitset of n bits from the characters of str, where zero and one
+// are the characters for unset and set bits. The first min(n, number of
+// characters in str) characters are used, the last of them giving bit 0;
+// remaining bits are zero. Returns ErrInvalidArgument if one of those
+// characters is neither zero nor one.
+func FromString(n int, str string, zero, one rune) (*Bitset, error) {
+	runes := []rune(str)
+	if len(runes) > n {
+		runes = runes[:n]
+	}
+
+	b := New(n)
+	for i, c := range runes {
+		pos := len(runes) - 1 - i
+		switch c {
+		case zero:
+		case one:
+			b.Set(pos)
+		default:
+			return nil, fmt.Errorf("%w %q at position %d", ErrInvalidArgument, c, i)
+		}
+	}
+	return b, nil
+}
+
+// Parses a string of '0' and '1' characters into a Bitset with one bit per
+// character. The last character gives bit 0.
+func Parse(str string) (*Bitset, error) {
+	return FromString(utf8.RuneCountInString(str), str, '0', '1')
+}
+
+// sanitize clears the bits of the last word beyond the size of the bitset.
+func (b *Bitset) sanitize() {
+	if extra := b.n % wordBits; extra != 0 {
+		b.words[len(b.words)-1] &= 1<<extra - 1
+	}
+}
+
+func (b *Bitset) checkPos(method string, pos int) {
+	if pos < 0 || pos >= b.n {
+		panic(fmt.Sprintf("bitset: Bitset.%s: position %d out of range [0, %d)", method, pos, b.n))
+	}
+}
+
+func (b *Bitset) checkSize(method string, other *Bitset) {
+	if b.n != other.n {
+		panic(fmt.Sprintf("bitset: Bitset.%s: size mismatch %d != %d", method, b.n, other.n))
+	}
+}
+
+// Returns a copy of the bitset.
+func (b *Bitset) Clone() *Bitset {
+	return &Bitset{append([]uint64(nil), b.words...), b.n}
+}
+
+// Returns the number of bits that the bitset holds.
+func (b *Bitset) Size() int {
+	return b.n
+}
+
+// Returns the value of the bit at position pos. Panics if pos does not
+// correspond to a valid position within the bitset.
+func (b *Bitset) Test(pos int) bool {
+	b.checkPos("Test", pos)
+	return b.words[pos/wordBits]&(1<<(pos%wordBits)) != 0
+}
+
+// Checks if all bits are set to true.
+func (b *Bitset) All() bool {
+	return b.Count() == b.n
+}
+
+// Checks if any bits are set to true.
+func (b *Bitset) Any() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if none of the bits are set to true.
+func (b *Bitset) None() bool {
+	return !b.Any()
+}
+
+// Returns the number of bits that are set to true.
+func (b *Bitset) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Sets the bit at position pos to true. Returns b.
+func (b *Bitset) Set(pos int) *Bitset {
+	return b.setTo("Set", pos, true)
+}
+
+// Sets the bit at position pos to value. Returns b.
+func (b *Bitset) SetTo(pos int, value bool) *Bitset {
+	return b.setTo("SetTo", pos, value)
+}
+
+func (b *Bitset) setTo(method string, pos int, value bool) *Bitset {
+	b.checkPos(method, pos)
+	if value {
+		b.words[pos/wordBits] |= 1 << (pos % wordBits)
+	} else {
+		b.words[pos/wordBits] &^= 1 << (pos % wordBits)
+	}
+	return b
+}
+
+// Sets all bits to true. Returns b.
+func (b *Bitset) SetAll() *Bitset {
+	for i := range b.words {
+		b.words[i] = ^uint64(0)
+	}
+	b.sanitize()
+	return b
+}
+
+// Sets the bit at position pos to false. Returns b.
+func (b *Bitset) Reset(pos int) *Bitset {
+	return b.setTo("Reset", pos, false)
+}
+
+// Sets all bits to false. Returns b.
+func (b *Bitset) ResetAll() *Bitset {
+	clear(b.words)
+	return b
+}
+
+// Flips the bit at position pos. Returns b.
+func (b *Bitset) Flip(pos int) *Bitset {
+	b.checkPos("Flip", pos)
+	b.words[pos/wordBits] ^= 1 << (pos % wordBits)
+	return b
+}
+
+// Flips all bits, like operator~ applied in place. Returns b.
+func (b *Bitset) FlipAll() *Bitset {
+	for i := range b.words {
+		b.words[i] = ^b.words[i]
+	}
+	b.sanitize()
+	return b
+}
+
+// Sets the bits to the result of binary AND on corresponding pairs of bits of
+// b and other, like operator&=. Returns b.
+func (b *Bitset) And(other *Bitset) *Bitset {
+	b.checkSize("And", other)
+	for i := range b.words {
+		b.words[i] &= other.words[i]
+	}
+	return b
+}
+
+// Sets the bits to the result of binary OR on corresponding pairs of bits of
+// b and other, like operator|=. Returns b.
+func (b *Bitset) Or(other *Bitset) *Bitset {
+	b.checkSize("Or", other)
+	for i := range b.words {
+		b.words[i] |= other.words[i]
+	}
+	return b
+}
+
+// Sets the bits to the result of binary XOR on corresponding pairs of bits of
+// b and other, like operator^=. Returns b.
+func (b *Bitset) Xor(other *Bitset) *Bitset {
+	b.checkSize("Xor", other)
+	for i := range b.words {
+		b.words[i] ^= other.words[i]
+	}
+	return b
+}
+
+// Shifts the bits towards higher positions by n, like operator<<=. Zeroes are
+// shifted in, and bits shifted past the end are lost. Returns b.
+func (b *Bitset) ShiftLeft(n int) *Bitset {
+	if n < 0 {
+		panic(fmt.Sprintf("bitset: Bitset.ShiftLeft: negative shift %d", n))
+	}
+	if n >= b.n {
+		return b.ResetAll()
+	}
+	wordShift, bitShift := n/wordBits, n%wordBits
+	for i := len(b.words) - 1; i >= 0; i-- {
+		var w uint64
+		if j := i - wordShift; j >= 0 {
+			w = b.words[j] << bitShift
+			if bitShift != 0 && j > 0 {
+				w |= b.words[j-1] >> (wordBits - bitShift)
+			}
+		}
+		b.words[i] = w
+	}
+	b.sanitize()
+	return b
+}
+
+// Shifts the bits towards lower positions by n, like operator>>=. Zeroes are
+// shifted in, and bits shifted past position 0 are lost. Returns b.
+func (b *Bitset) ShiftRight(n int) *Bitset {
+	if n < 0 {
+		panic(fmt.Sprintf("bitset: Bitset.ShiftRight: negative shift %d", n))
+	}
+	if n >= b.n {
+		return b.ResetAll()
+	}
+	wordShift, bitShift := n/wordBits, n%wordBits
+	for i := range b.words {
+		var w uint64
+		if j := i + wordShift; j < len(b.words) {
+			w = b.words[j] >> bitShift
+			if bitShift != 0 && j+1 < len(b.words) {
+				w |= b.words[j+1] << (wordBits - bitShift)
+			}
+		}
+		b.words[i] = w
+	}
+	return b
+}
+
+// Checks if b and other have the same size and all their bits are equal.
+func (b *Bitset) Equal(other *Bitset) bool {
+	if b.n != other.n {
+		return false
+	}
+	for i := range b.words {
+		if b.words[i] != other.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Converts the contents of the bitset to a string, using zero for unset bits
+// and one for set bits. The first character corresponds to the highest
+// position, the last character to bit 0.
+func (b *Bitset) ToString(zero, one rune) string {
+	var sb strings.Builder
+	sb.Grow(b.n)
+	for pos := b.n - 1; pos >= 0; pos-- {
+		if b.Test(pos) {
+			sb.WriteRune(one)
+		} else {
+			sb.WriteRune(zero)
+		}
+	}
+	return sb.String()
+}
+
+// Returns the bits as a string of '0' and '1' characters, the last one being
+// bit 0.
+func (b *Bitset) String() string {
+	return b.ToString('0', '1')
+}
+
+// Converts the contents of the bitset to a uint, the Go counterpart of
+// unsigned long. Returns ErrOverflow if the value cannot be represented in a
+// uint.
+func (b *Bitset) ToULong() (uint, error) {
+	v, err := b.ToULLong()
+	if err != nil || uint64(uint(v)) != v {
+		return 0, ErrOverflow
+	}
+	return uint(v), nil
+}
+
+// Converts the contents of the bitset to a uint64, the Go counterpart of
+// unsigned long long. Returns ErrOverflow if the value cannot be represented
+// in a uint64.
+func (b *Bitset) ToULLong() (uint64, error) {
+	if len(b.words) == 0 {
+		return 0, nil
+	}
+	for _, w := range b.words[1:] {
+		if w != 0 {
+			return 0, ErrOverflow
+		}
+	}
+	return b.words[0], nil
+}
@@ -0,0 +1,212 @@
+package bitset
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// sizes includes sizes that are not a multiple of the word size and sizes
+// around word boundaries.
+var sizes = []int{0, 1, 7, 63, 64, 65, 100, 128, 130, 200}
+
+// model is the reference a Bitset is checked against: one bool per bit.
+type model []bool
+
+func (m model) String() string {
+	var sb strings.Builder
+	for pos := len(m) - 1; pos >= 0; pos-- {
+		if m[pos] {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+func random(rng *rand.Rand, n int) (*Bitset, model) {
+	b, m := New(n), make(model, n)
+	for pos := range m {
+		if rng.Intn(2) == 0 {
+			b.Set(pos)
+			m[pos] = true
+		}
+	}
+	return b, m
+}
+
+func check(t *testing.T, op string, b *Bitset, m model) {
+	t.Helper()
+	count := 0
+	for _, v := range m {
+		if v {
+			count++
+		}
+	}
+	if b.String() != m.String() || b.Count() != count || b.Size() != len(m) {
+		t.Fatalf("%s = %s with count %d, want %s with count %d", op, b, b.Count(), m, count)
+	}
+	// The bits beyond the size stay clear, so All and Equal see only the
+	// bits of the bitset.
+	if b.All() != (count == len(m)) || b.Any() != (count > 0) || b.None() != (count == 0) {
+		t.Fatalf("%s: All, Any, None = %v, %v, %v with count %d of %d", op, b.All(), b.Any(), b.None(), count, len(m))
+	}
+}
+
+func TestSetResetFlip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range sizes {
+		b, m := New(n), make(model, n)
+		check(t, "New", b, m)
+		for i := 0; i < 3*n; i++ {
+			pos := rng.Intn(n)
+			switch rng.Intn(4) {
+			case 0:
+				b.Set(pos)
+				m[pos] = true
+			case 1:
+				b.Reset(pos)
+				m[pos] = false
+			case 2:
+				b.Flip(pos)
+				m[pos] = !m[pos]
+			case 3:
+				v := rng.Intn(2) == 0
+				b.SetTo(pos, v)
+				m[pos] = v
+			}
+			if b.Test(pos) != m[pos] {
+				t.Fatalf("size %d: Test(%d) = %v, want %v", n, pos, b.Test(pos), m[pos])
+			}
+		}
+		check(t, "Set, Reset and Flip", b, m)
+
+		b.FlipAll()
+		for pos := range m {
+			m[pos] = !m[pos]
+		}
+		check(t, "FlipAll", b, m)
+		b.SetAll()
+		for pos := range m {
+			m[pos] = true
+		}
+		check(t, "SetAll", b, m)
+		b.ResetAll()
+		for pos := range m {
+			m[pos] = false
+		}
+		check(t, "ResetAll", b, m)
+	}
+}
+
+func TestShift(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, n := range sizes {
+		for _, s := range []int{0, 1, 3, 63, 64, 65, 127, 128, 129, n - 1, n, n + 1, 1000} {
+			if s < 0 {
+				continue
+			}
+			b, m := random(rng, n)
+			left := make(model, n)
+			for pos := s; pos < n; pos++ {
+				left[pos] = m[pos-s]
+			}
+			check(t, "ShiftLeft", b.Clone().ShiftLeft(s), left)
+
+			right := make(model, n)
+			for pos := 0; pos+s < n; pos++ {
+				right[pos] = m[pos+s]
+			}
+			check(t, "ShiftRight", b.ShiftRight(s), right)
+		}
+	}
+}
+
+func TestBinaryOps(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, n := range sizes {
+		a, ma := random(rng, n)
+		b, mb := random(rng, n)
+		and, or, xor := make(model, n), make(model, n), make(model, n)
+		for pos := range ma {
+			and[pos], or[pos], xor[pos] = ma[pos] && mb[pos], ma[pos] || mb[pos], ma[pos] != mb[pos]
+		}
+		check(t, "And", a.Clone().And(b), and)
+		check(t, "Or", a.Clone().Or(b), or)
+		check(t, "Xor", a.Clone().Xor(b), xor)
+		if !a.Equal(a.Clone()) || n > 0 && a.Equal(a.Clone().Flip(n-1)) {
+			t.Errorf("size %d: Equal does not compare the bits", n)
+		}
+	}
+	if New(64).Equal(New(65)) {
+		t.Errorf("bitsets of different sizes are equal")
+	}
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		n    int
+		val  uint64
+		want string
+	}{
+		{0, 5, ""},
+		{4, 0xff, "1111"},
+		{8, 0x5, "00000101"},
+		{70, 1<<63 | 1, "0000001000000000000000000000000000000000000000000000000000000000000001"},
+	}
+	for _, tt := range tests {
+		b := FromUint64(tt.n, tt.val)
+		if b.String() != tt.want {
+			t.Errorf("FromUint64(%d, %#x) = %s, want %s", tt.n, tt.val, b, tt.want)
+		}
+		p, err := Parse(tt.want)
+		if err != nil || !p.Equal(b) {
+			t.Errorf("Parse(%s) = %v, %v", tt.want, p, err)
+		}
+	}
+
+	b, err := FromString(6, "xoxxoxoo", 'o', 'x')
+	if err != nil || b.ToString('o', 'x') != "xoxxox" || b.String() != "101101" {
+		t.Errorf("FromString = %v, %v", b, err)
+	}
+	if _, err := FromString(4, "01a1", '0', '1'); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("FromString of an invalid character returned %v", err)
+	}
+
+	if v, err := FromUint64(100, 1<<40).ToULLong(); v != 1<<40 || err != nil {
+		t.Errorf("ToULLong() = %d, %v", v, err)
+	}
+	if _, err := New(100).Set(64).ToULLong(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ToULLong of a value beyond 64 bits returned %v", err)
+	}
+	if v, err := FromUint64(10, 1000).ToULong(); v != 1000 || err != nil {
+		t.Errorf("ToULong() = %d, %v", v, err)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	tests := []struct {
+		name, want string
+		f          func()
+	}{
+		{"New(-1)", "negative size -1", func() { New(-1) }},
+		{"Test(n)", "Bitset.Test: position 65 out of range [0, 65)", func() { New(65).Test(65) }},
+		{"Set(-1)", "Bitset.Set: position -1 out of range", func() { New(65).Set(-1) }},
+		{"Reset(65)", "Bitset.Reset: position 65 out of range", func() { New(65).Reset(65) }},
+		{"And", "Bitset.And: size mismatch 64 != 65", func() { New(64).And(New(65)) }},
+		{"ShiftLeft(-1)", "negative shift -1", func() { New(8).ShiftLeft(-1) }},
+		{"ShiftRight(-1)", "negative shift -1", func() { New(8).ShiftRight(-1) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r, _ := recover().(string); !strings.Contains(r, tt.want) {
+					t.Errorf("%s panicked with %q, want %q", tt.name, r, tt.want)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
@@ -0,0 +1,198 @@
+// Package charconv provides conversions between numbers and their character
+// representations, like <charconv>. The conversions work on byte slices
+// supplied by the caller, never allocate, and do not depend on a locale.
+//
+// Like std::from_chars, the parsing functions consume the longest prefix of
+// the input that matches the expected pattern, and return the index just past
+// it, so that a caller can continue parsing from there. Like std::to_chars,
+// the formatting functions write to the start of dst and return the index just
+// past the last byte written.
+package charconv
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Errc is the error code of the conversions, like std::errc. The zero Errc
+// reports success.
+type Errc int
+
+const (
+	// InvalidArgument is returned when the input does not start with a
+	// number, like std::errc::invalid_argument.
+	InvalidArgument Errc = iota + 1
+
+	// ResultOutOfRange is returned when a parsed number cannot be
+	// represented in the type of the result, like
+	// std::errc::result_out_of_range.
+	ResultOutOfRange
+
+	// ValueTooLarge is returned when the representation of a number does not
+	// fit in the output, like std::errc::value_too_large.
+	ValueTooLarge
+)
+
+// Returns a description of the error code.
+func (e Errc) Error() string {
+	switch e {
+	case 0:
+		return "charconv: success"
+	case InvalidArgument:
+		return "charconv: invalid argument"
+	case ResultOutOfRange:
+		return "charconv: result out of range"
+	case ValueTooLarge:
+		return "charconv: value too large"
+	}
+	return "charconv: unknown error"
+}
+
+// CharsFormat selects the representation of floating-point numbers, like
+// std::chars_format.
+type CharsFormat int
+
+const (
+	// Scientific is the representation d.ddde±dd, as with %e.
+	Scientific CharsFormat = 1 << iota
+	// Fixed is the representation ddd.ddd, as with %f.
+	Fixed
+	// Hex is the representation h.hhhp±d, as with %a but without the 0x
+	// prefix.
+	Hex
+	// General is Fixed or Scientific, whichever suits the exponent, as with
+	// %g.
+	General = Fixed | Scientific
+)
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func checkBase(method string, base int) {
+	if base < 2 || base > 36 {
+		panic("charconv: " + method + ": base out of range [2, 36]")
+	}
+}
+
+// digitValue returns the value of the digit c in the bases up to 36, or 36 if
+// c is not a digit.
+func digitValue(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		return int(c-'A') + 10
+	}
+	return 36
+}
+
+// limits returns whether T is signed, and the largest value of T.
+func limits[T Integer]() (signed bool, max uint64) {
+	var zero T
+	bits := unsafe.Sizeof(zero) * 8
+	if ^zero < 0 {
+		return true, 1<<(bits-1) - 1
+	}
+	return false, math.MaxUint64 >> (64 - bits)
+}
+
+// put copies s to the start of dst, if it fits.
+func put(dst, s []byte) (int, Errc) {
+	if len(s) > len(dst) {
+		return len(dst), ValueTooLarge
+	}
+	return copy(dst, s), 0
+}
+
+// Parses an integer in the given base, from 2 to 36, from the start of b, and
+// stores it in *value. The integer is a sequence of digits, the letters a to
+// z or A to Z standing for the digits 10 to 35, optionally preceded by '-' if
+// T is signed; there is no '+' sign, base prefix or leading whitespace.
+//
+// Returns the index just past the digits. If b does not start with an
+// integer, returns 0 and InvalidArgument; if the integer does not fit in T,
+// returns the index past it and ResultOutOfRange. *value is not modified on
+// error. Panics if base is out of range.
+func FromChars[T Integer](b []byte, value *T, base int) (ptr int, ec Errc) {
+	checkBase("FromChars", base)
+
+	signed, max := limits[T]()
+	neg := signed && len(b) > 0 && b[0] == '-'
+	i := 0
+	if neg {
+		i++
+		max++
+	}
+
+	first := i
+	var mag uint64
+	overflow := false
+	for ; i < len(b); i++ {
+		d := digitValue(b[i])
+		if d >= base {
+			break
+		}
+		if overflow || mag > (max-uint64(d))/uint64(base) {
+			overflow = true
+			continue
+		}
+		mag = mag*uint64(base) + uint64(d)
+	}
+
+	switch {
+	case i == first:
+		return 0, InvalidArgument
+	case overflow:
+		return i, ResultOutOfRange
+	case neg:
+		*value = -T(mag)
+	default:
+		*value = T(mag)
+	}
+	return i, 0
+}
+
+// Writes the representation of value in the given base, from 2 to 36, to the
+// start of dst: its digits, with lowercase letters for the digits above 9,
+// preceded by '-' if value is negative. There are no leading zeros, except for
+// the representation of zero.
+//
+// Returns the index just past the representation. If it does not fit in dst,
+// returns len(dst) and ValueTooLarge, and the contents of dst are unspecified.
+// Panics if base is out of range.
+func ToChars[T Integer](dst []byte, value T, base int) (ptr int, ec Errc) {
+	checkBase("ToChars", base)
+
+	var buf [65]byte
+	i := len(buf)
+	neg := value < 0
+	u := uint64(value)
+	if neg {
+		u = -u
+	}
+	for {
+		i--
+		buf[i] = digits[u%uint64(base)]
+		u /= uint64(base)
+		if u == 0 {
+			break
+		}
+	}
+	if neg {
+		i--
+		buf[i] = '-'
+	}
+	return put(dst, buf[i:])
+}
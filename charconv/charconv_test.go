@@ -0,0 +1,127 @@
+package charconv
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFromChars(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		base int
+		ptr  int
+		ec   Errc
+		want int64
+	}{
+		{"123", 10, 3, 0, 123},
+		{"-42x", 10, 3, 0, -42},
+		{"ff", 16, 2, 0, 255},
+		{"FFz", 16, 2, 0, 255},
+		{"zz", 36, 2, 0, 35*36 + 35},
+		{"102", 2, 2, 0, 2},
+		{"9223372036854775807", 10, 19, 0, math.MaxInt64},
+		{"-9223372036854775808", 10, 20, 0, math.MinInt64},
+		{"9223372036854775808", 10, 19, ResultOutOfRange, 0},
+		{"-9223372036854775809!", 10, 20, ResultOutOfRange, 0},
+		{"", 10, 0, InvalidArgument, 0},
+		{"-", 10, 0, InvalidArgument, 0},
+		{"+1", 10, 0, InvalidArgument, 0},
+		{" 1", 10, 0, InvalidArgument, 0},
+	} {
+		var got int64
+		ptr, ec := FromChars([]byte(tt.s), &got, tt.base)
+		if ptr != tt.ptr || ec != tt.ec || got != tt.want {
+			t.Errorf("FromChars(%q, %d) = %d, %d, %v, want %d, %d, %v", tt.s, tt.base, got, ptr, ec, tt.want, tt.ptr, tt.ec)
+		}
+	}
+}
+
+func TestFromCharsLimits(t *testing.T) {
+	var u8 uint8
+	if ptr, ec := FromChars([]byte("255"), &u8, 10); ptr != 3 || ec != 0 || u8 != 255 {
+		t.Errorf("FromChars(255) into uint8 = %d, %d, %v", u8, ptr, ec)
+	}
+	if ptr, ec := FromChars([]byte("256"), &u8, 10); ptr != 3 || ec != ResultOutOfRange || u8 != 255 {
+		t.Errorf("FromChars(256) into uint8 = %d, %d, %v, want it unmodified and out of range", u8, ptr, ec)
+	}
+	if ptr, ec := FromChars([]byte("-1"), &u8, 10); ptr != 0 || ec != InvalidArgument {
+		t.Errorf("FromChars(-1) into uint8 = %d, %v, want 0, InvalidArgument", ptr, ec)
+	}
+	var i8 int8
+	if ptr, ec := FromChars([]byte("-128"), &i8, 10); ptr != 4 || ec != 0 || i8 != -128 {
+		t.Errorf("FromChars(-128) into int8 = %d, %d, %v", i8, ptr, ec)
+	}
+	if _, ec := FromChars([]byte("128"), &i8, 10); ec != ResultOutOfRange {
+		t.Errorf("FromChars(128) into int8 = %v, want ResultOutOfRange", ec)
+	}
+	var u64 uint64
+	if _, ec := FromChars([]byte("18446744073709551615"), &u64, 10); ec != 0 || u64 != math.MaxUint64 {
+		t.Errorf("FromChars(MaxUint64) = %d, %v", u64, ec)
+	}
+	if _, ec := FromChars([]byte("18446744073709551616"), &u64, 10); ec != ResultOutOfRange {
+		t.Errorf("FromChars(MaxUint64 + 1) = %v, want ResultOutOfRange", ec)
+	}
+}
+
+func TestToChars(t *testing.T) {
+	for _, tt := range []struct {
+		value int64
+		base  int
+		want  string
+	}{
+		{0, 10, "0"},
+		{-42, 10, "-42"},
+		{255, 16, "ff"},
+		{5, 2, "101"},
+		{math.MinInt64, 10, "-9223372036854775808"},
+		{math.MinInt64, 2, "-1" + strings.Repeat("0", 63)},
+	} {
+		var buf [80]byte
+		ptr, ec := ToChars(buf[:], tt.value, tt.base)
+		if got := string(buf[:ptr]); ec != 0 || got != tt.want {
+			t.Errorf("ToChars(%d, %d) = %q, %v, want %q", tt.value, tt.base, got, ec, tt.want)
+		}
+	}
+
+	var u64 [20]byte
+	if ptr, ec := ToChars(u64[:], uint64(math.MaxUint64), 10); ec != 0 || string(u64[:ptr]) != "18446744073709551615" {
+		t.Errorf("ToChars(MaxUint64) = %q, %v", u64[:ptr], ec)
+	}
+	var short [2]byte
+	if ptr, ec := ToChars(short[:], 100, 10); ptr != 2 || ec != ValueTooLarge {
+		t.Errorf("ToChars into a short buffer = %d, %v, want 2, ValueTooLarge", ptr, ec)
+	}
+}
+
+func TestBaseOutOfRange(t *testing.T) {
+	for _, base := range []int{1, 37} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FromChars with base %d did not panic", base)
+				}
+			}()
+			var x int
+			FromChars([]byte("1"), &x, base)
+		}()
+	}
+}
+
+func TestAllocs(t *testing.T) {
+	var x int
+	var f float64
+	var buf [64]byte
+	allocs := testing.AllocsPerRun(100, func() {
+		FromChars([]byte("-12345"), &x, 10)
+		ToChars(buf[:], x, 16)
+		FromCharsFloat([]byte("1.5e10"), &f, General)
+		FromCharsFloat([]byte("1.8p3"), &f, Hex)
+		ToCharsFloat(buf[:], f, 0, -1)
+		ToCharsFloat(buf[:], f, Scientific, 10)
+		ToCharsFloat(buf[:], f, Hex, -1)
+	})
+	if allocs != 0 {
+		t.Errorf("the conversions made %v allocations per run, want 0", allocs)
+	}
+}
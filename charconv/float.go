@@ -0,0 +1,517 @@
+package charconv
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"unsafe"
+)
+
+// floatFormat describes the binary format of a floating-point type.
+type floatFormat struct {
+	bitSize  int
+	mantBits int // including the implicit leading bit
+	minExp   int // of the normal numbers
+	maxExp   int
+
+	// The decimal digits of the smallest integer that rounds to infinity.
+	overflow string
+}
+
+var float32Format, float64Format = floatFormat{32, 24, -126, 127, overflowDigits(128, 24)},
+	floatFormat{64, 53, -1022, 1023, overflowDigits(1024, 53)}
+
+// overflowDigits returns the decimal digits of the midpoint between the
+// largest finite value and 2^e, which rounds to infinity.
+func overflowDigits(e, mantBits int) string {
+	one := big.NewInt(1)
+	t := new(big.Int).Lsh(one, uint(e))
+	return t.Sub(t, new(big.Int).Lsh(one, uint(e-mantBits-1))).String()
+}
+
+func formatOf[T Float]() *floatFormat {
+	var zero T
+	if unsafe.Sizeof(zero) == 4 {
+		return &float32Format
+	}
+	return &float64Format
+}
+
+func checkFormat(method string, fmt CharsFormat) {
+	switch fmt {
+	case Scientific, Fixed, Hex, General:
+	default:
+		panic("charconv: " + method + ": invalid chars format")
+	}
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return digitValue(c) < 16
+}
+
+// hasPrefixFold checks if b begins with the lowercase ASCII word, ignoring
+// case.
+func hasPrefixFold(b []byte, word string) bool {
+	if len(b) < len(word) {
+		return false
+	}
+	for i := 0; i < len(word); i++ {
+		if b[i]|0x20 != word[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// scanExponent scans an exponent, an optional sign followed by decimal
+// digits, at the start of b. Returns the length of the exponent, or 0 if there
+// is none, and its value, saturated far beyond the range of any format.
+func scanExponent(b []byte) (n, exp int) {
+	neg := false
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		neg = b[0] == '-'
+		n++
+	}
+	first := n
+	for ; n < len(b) && isDigit(b[n]); n++ {
+		if exp < 1<<20 {
+			exp = exp*10 + int(b[n]-'0')
+		}
+	}
+	if n == first {
+		return 0, 0
+	}
+	if neg {
+		exp = -exp
+	}
+	return n, exp
+}
+
+// Parses a floating-point number in the given format from the start of b, and
+// stores it in *value, rounded to nearest, ties to even. The number is
+// optionally preceded by '-'; there is no '+' sign and no leading whitespace.
+// Its pattern is that of strtod, restricted by fmt:
+//
+//   - Fixed: ddd.ddd, without an exponent.
+//   - Scientific: ddd.ddde±dd, with a required exponent.
+//   - General: ddd.ddd with an optional exponent.
+//   - Hex: hhh.hhhp±dd with an optional binary exponent, without the 0x
+//     prefix.
+//
+// Either side of the point may be empty, but not both. In every format, the
+// number may also be inf or infinity, or nan optionally followed by
+// parenthesized letters, digits and underscores, in any case.
+//
+// Returns the index just past the number. If b does not start with a number,
+// returns 0 and InvalidArgument; if the number is too large for T, or too
+// small and not zero, returns the index past it and ResultOutOfRange. *value is
+// not modified on error. Panics if fmt is not one of the formats.
+func FromCharsFloat[T Float](b []byte, value *T, fmt CharsFormat) (ptr int, ec Errc) {
+	checkFormat("FromCharsFloat", fmt)
+
+	i := 0
+	neg := len(b) > 0 && b[0] == '-'
+	if neg {
+		i++
+	}
+
+	switch {
+	case hasPrefixFold(b[i:], "inf"):
+		i += len("inf")
+		if hasPrefixFold(b[i:], "inity") {
+			i += len("inity")
+		}
+		*value = T(math.Copysign(math.Inf(1), sign(neg)))
+		return i, 0
+
+	case hasPrefixFold(b[i:], "nan"):
+		i += len("nan")
+		if i < len(b) && b[i] == '(' {
+			j := i + 1
+			for j < len(b) && (digitValue(b[j]) < 36 || b[j] == '_') {
+				j++
+			}
+			if j < len(b) && b[j] == ')' {
+				i = j + 1
+			}
+		}
+		*value = T(math.Copysign(math.NaN(), sign(neg)))
+		return i, 0
+	}
+
+	var x float64
+	if fmt == Hex {
+		ptr, x, ec = parseHex(b, i, formatOf[T]())
+	} else {
+		ptr, x, ec = parseDecimal(b, i, fmt, formatOf[T]())
+	}
+	if ec == 0 {
+		*value = T(math.Copysign(x, sign(neg)))
+	}
+	return ptr, ec
+}
+
+func sign(neg bool) float64 {
+	if neg {
+		return -1
+	}
+	return 1
+}
+
+// parseDecimal parses the magnitude of a decimal number starting at b[first].
+func parseDecimal(b []byte, first int, fmt CharsFormat, f *floatFormat) (int, float64, Errc) {
+	i := first
+	for i < len(b) && isDigit(b[i]) {
+		i++
+	}
+	intEnd := i
+	if i < len(b) && b[i] == '.' {
+		i++
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+	}
+	numDigits := i - first
+	if intEnd < i {
+		numDigits--
+	}
+	if numDigits == 0 {
+		return 0, 0, InvalidArgument
+	}
+	mantEnd := i
+
+	exp := 0
+	if fmt != Fixed && i < len(b) && b[i]|0x20 == 'e' {
+		n, e := scanExponent(b[i+1:])
+		if n > 0 {
+			i += 1 + n
+			exp = e
+		}
+	}
+	if fmt == Scientific && i == mantEnd {
+		return 0, 0, InvalidArgument
+	}
+
+	// The number is 0.d1d2... * 10^k, where d1 is its first significant
+	// digit, if it has one. Compare it with the smallest number that
+	// overflows, 0.o1o2... * 10^len(overflow).
+	j := first
+	for j < mantEnd && (b[j] == '0' || b[j] == '.') {
+		j++
+	}
+	zero := j == mantEnd
+	if !zero {
+		k := exp + intEnd - j
+		if j > intEnd {
+			k++
+		}
+		if k > len(f.overflow) || k == len(f.overflow) && !lessDigits(b[j:mantEnd], f.overflow) {
+			return i, 0, ResultOutOfRange
+		}
+	}
+
+	s := unsafe.String(&b[first], i-first)
+	x, err := strconv.ParseFloat(s, f.bitSize)
+	if err != nil || x == 0 && !zero {
+		return i, 0, ResultOutOfRange
+	}
+	return i, x, 0
+}
+
+// lessDigits checks if the digits of m, which may contain a point, are less
+// than those of o, both read as fractions.
+func lessDigits(m []byte, o string) bool {
+	k := 0
+	for _, c := range m {
+		if c == '.' {
+			continue
+		}
+		if k == len(o) {
+			return false
+		}
+		if c != o[k] {
+			return c < o[k]
+		}
+		k++
+	}
+	// The digits of o do not end with a zero, so o is greater if it has
+	// more of them.
+	return k < len(o)
+}
+
+// parseHex parses the magnitude of a hexadecimal number starting at b[first].
+func parseHex(b []byte, first int, f *floatFormat) (int, float64, Errc) {
+	// The number is m * 2^exp, with sticky set if nonzero bits beyond the
+	// 64 of m were dropped.
+	var m uint64
+	exp := 0
+	sticky := false
+	point := false
+	numDigits := 0
+	i := first
+	for ; i < len(b); i++ {
+		if b[i] == '.' && !point {
+			point = true
+			continue
+		}
+		if !isHexDigit(b[i]) {
+			break
+		}
+		numDigits++
+		d := uint64(digitValue(b[i]))
+		switch {
+		case m>>60 == 0:
+			m = m<<4 | d
+			if point {
+				exp -= 4
+			}
+		case !point:
+			sticky = sticky || d != 0
+			exp += 4
+		default:
+			sticky = sticky || d != 0
+		}
+	}
+	if numDigits == 0 {
+		return 0, 0, InvalidArgument
+	}
+	if i < len(b) && b[i]|0x20 == 'p' {
+		n, e := scanExponent(b[i+1:])
+		if n > 0 {
+			i += 1 + n
+			exp += e
+		}
+	}
+	if m == 0 {
+		return i, 0, 0
+	}
+
+	// Normalize m, and keep as many of its bits as the format holds at the
+	// exponent of the number, rounding to nearest, ties to even.
+	lz := bits.LeadingZeros64(m)
+	m <<= lz
+	exp -= lz
+	e := exp + 63
+	if e > f.maxExp {
+		return i, 0, ResultOutOfRange
+	}
+	keep := f.mantBits
+	if e < f.minExp {
+		keep -= f.minExp - e
+	}
+	shift := 64 - keep
+	var r uint64
+	switch {
+	case shift > 64:
+	case shift == 64:
+		if m<<1 != 0 || sticky {
+			r = 1
+		}
+	default:
+		r = m >> shift
+		dropped := m << keep
+		if dropped>>63 == 1 && (dropped<<1 != 0 || sticky || r&1 == 1) {
+			r++
+		}
+	}
+	if r == 0 {
+		return i, 0, ResultOutOfRange
+	}
+	if r>>f.mantBits != 0 && e+1 > f.maxExp {
+		return i, 0, ResultOutOfRange
+	}
+	return i, math.Ldexp(float64(r), exp+shift), 0
+}
+
+// The largest representation strconv produces with the clamped precisions:
+// 309 integral digits and maxFixedPrecision fractional digits.
+const (
+	maxFixedPrecision = 1100
+	maxExpPrecision   = 800
+	bufSize           = 1 + 309 + 1 + maxFixedPrecision
+)
+
+// Writes the representation of value in the given format to the start of dst,
+// as printf would in the "C" locale with the conversion %e for Scientific, %f
+// for Fixed, %g for General, and %a without the 0x prefix for Hex. Infinities
+// are written as inf and NaNs as nan, preceded by '-' if their sign is
+// negative.
+//
+// If precision is negative, the representation is the shortest one in the
+// format from which FromCharsFloat recovers value exactly, with General using
+// %e for exponents below -4 or from 6 on, as %g does by default. Otherwise it
+// is the number of digits after the point, or the number of significant
+// digits for General. A zero fmt selects the shortest representation of
+// value among those of Fixed and Scientific, preferring Fixed on ties, like
+// std::to_chars without a format; it cannot be combined with a precision.
+//
+// Returns the index just past the representation. If it does not fit in dst,
+// returns len(dst) and ValueTooLarge, and the contents of dst are unspecified.
+// Panics if fmt is not one of the formats, or zero.
+func ToCharsFloat[T Float](dst []byte, value T, fmt CharsFormat, precision int) (ptr int, ec Errc) {
+	if fmt != 0 {
+		checkFormat("ToCharsFloat", fmt)
+	} else if precision >= 0 {
+		panic("charconv: ToCharsFloat: precision without a chars format")
+	}
+
+	x := float64(value)
+	switch {
+	case math.IsNaN(x):
+		return putSigned(dst, math.Signbit(x), "nan")
+	case math.IsInf(x, 0):
+		return putSigned(dst, x < 0, "inf")
+	}
+
+	f := formatOf[T]()
+	if fmt == Hex {
+		// Beyond the digits of the fraction, all digits are zeros.
+		pad := max(precision-(f.mantBits+2)/4, 0)
+		var buf [64]byte
+		return putPadded(dst, appendHex(buf[:0], x, f, precision-pad), pad, 'p')
+	}
+
+	var buf [bufSize]byte
+	if fmt == 0 {
+		var ebuf [32]byte
+		e := strconv.AppendFloat(ebuf[:0], x, 'e', -1, f.bitSize)
+		s := strconv.AppendFloat(buf[:0], x, 'f', -1, f.bitSize)
+		if len(e) < len(s) {
+			return put(dst, e)
+		}
+		return put(dst, s)
+	}
+
+	verb := byte('g')
+	switch fmt {
+	case Scientific:
+		verb = 'e'
+	case Fixed:
+		verb = 'f'
+	}
+	if precision < 0 {
+		return put(dst, strconv.AppendFloat(buf[:0], x, verb, -1, f.bitSize))
+	}
+
+	// Beyond the clamped precisions, all digits are zeros, which %g omits.
+	pad := 0
+	switch verb {
+	case 'e':
+		pad = max(precision-maxExpPrecision, 0)
+		precision -= pad
+	case 'f':
+		pad = max(precision-maxFixedPrecision, 0)
+		precision -= pad
+	default:
+		precision = min(precision, maxExpPrecision)
+	}
+	s := strconv.AppendFloat(buf[:0], x, verb, precision, f.bitSize)
+	if verb == 'e' {
+		return putPadded(dst, s, pad, 'e')
+	}
+	return putPadded(dst, s, pad, 0)
+}
+
+// putPadded copies s to the start of dst with pad zeros inserted before the
+// last occurrence of marker, or appended if marker is 0, if it fits.
+func putPadded(dst, s []byte, pad int, marker byte) (int, Errc) {
+	if pad == 0 {
+		return put(dst, s)
+	}
+	if len(s)+pad > len(dst) {
+		return len(dst), ValueTooLarge
+	}
+	k := len(s)
+	if marker != 0 {
+		for s[k-1] != marker {
+			k--
+		}
+		k--
+	}
+	n := copy(dst, s[:k])
+	for ; pad > 0; pad-- {
+		dst[n] = '0'
+		n++
+	}
+	n += copy(dst[n:], s[k:])
+	return n, 0
+}
+
+// putSigned copies s to the start of dst, preceded by '-' if neg, if it fits.
+func putSigned(dst []byte, neg bool, s string) (int, Errc) {
+	n := len(s)
+	if neg {
+		n++
+	}
+	if n > len(dst) {
+		return len(dst), ValueTooLarge
+	}
+	i := 0
+	if neg {
+		dst[0] = '-'
+		i++
+	}
+	copy(dst[i:], s)
+	return n, 0
+}
+
+// appendHex appends the representation of the finite x with the Hex format
+// to dst. Normal numbers have the leading digit 1, or 2 if rounding carries
+// into it; subnormal numbers have the leading digit 0 and the exponent of the
+// smallest normal number.
+func appendHex(dst []byte, x float64, f *floatFormat, precision int) []byte {
+	if math.Signbit(x) {
+		dst = append(dst, '-')
+		x = -x
+	}
+
+	// x is lead.frac * 2^exp, with the fraction in nd hex digits.
+	fracBits := f.mantBits - 1
+	nd := (fracBits + 3) / 4
+	exp := 0
+	if x != 0 {
+		_, exp = math.Frexp(x)
+		exp = max(exp-1, f.minExp)
+	}
+	m := uint64(math.Ldexp(x, fracBits-exp)) << (nd*4 - fracBits)
+
+	if precision >= 0 && precision < nd {
+		drop := uint(nd-precision) * 4
+		rem := m & (1<<drop - 1)
+		m >>= drop
+		half := uint64(1) << (drop - 1)
+		if rem > half || rem == half && m&1 == 1 {
+			m++
+		}
+		nd = precision
+	}
+	lead := m >> (nd * 4)
+	frac := m & (1<<(nd*4) - 1)
+	if precision < 0 {
+		for nd > 0 && frac&0xf == 0 {
+			frac >>= 4
+			nd--
+		}
+	}
+
+	dst = append(dst, digits[lead])
+	if nd > 0 || precision > 0 {
+		dst = append(dst, '.')
+	}
+	for k := nd - 1; k >= 0; k-- {
+		dst = append(dst, digits[frac>>(k*4)&0xf])
+	}
+	for k := nd; k < precision; k++ {
+		dst = append(dst, '0')
+	}
+	dst = append(dst, 'p')
+	if exp >= 0 {
+		dst = append(dst, '+')
+	}
+	return strconv.AppendInt(dst, int64(exp), 10)
+}
@@ -0,0 +1,171 @@
+package charconv
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFromCharsFloatPartial(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		fmt  CharsFormat
+		ptr  int
+		ec   Errc
+		want float64
+	}{
+		// An incomplete exponent is not part of the number.
+		{"1e", General, 1, 0, 1},
+		{"1e+", General, 1, 0, 1},
+		{"1e+x", General, 1, 0, 1},
+		{"1.5e-2x", General, 6, 0, 0.015},
+		{"1p", Hex, 1, 0, 1},
+		{"1.8p+1", Hex, 6, 0, 3},
+		{".5", Fixed, 2, 0, 0.5},
+		{"5.", Fixed, 2, 0, 5},
+		{"1e5", Fixed, 1, 0, 1},
+		{"-2.5", General, 4, 0, -2.5},
+		// An unterminated nan payload is not part of the number.
+		{"nan(abc", General, 3, 0, math.NaN()},
+		{"nan(a_1)x", General, 8, 0, math.NaN()},
+		{"NaN()", General, 5, 0, math.NaN()},
+		// An incomplete infinity is inf.
+		{"infin", General, 3, 0, math.Inf(1)},
+		{"-Infinity", General, 9, 0, math.Inf(-1)},
+		{"", General, 0, InvalidArgument, 0},
+		{".", General, 0, InvalidArgument, 0},
+		{"-", General, 0, InvalidArgument, 0},
+		{"e5", General, 0, InvalidArgument, 0},
+		{"+1", General, 0, InvalidArgument, 0},
+		{"0x1p0", Hex, 1, 0, 0},
+	} {
+		var got float64
+		ptr, ec := FromCharsFloat([]byte(tt.s), &got, tt.fmt)
+		same := got == tt.want || math.IsNaN(got) && math.IsNaN(tt.want)
+		if ptr != tt.ptr || ec != tt.ec || !same {
+			t.Errorf("FromCharsFloat(%q) = %v, %d, %v, want %v, %d, %v", tt.s, got, ptr, ec, tt.want, tt.ptr, tt.ec)
+		}
+	}
+
+	// Scientific requires the exponent.
+	var x float64
+	if ptr, ec := FromCharsFloat([]byte("1.5"), &x, Scientific); ptr != 0 || ec != InvalidArgument {
+		t.Errorf("FromCharsFloat(1.5, Scientific) = %d, %v, want 0, InvalidArgument", ptr, ec)
+	}
+}
+
+func TestFromCharsFloatRange(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		fmt  CharsFormat
+		ec   Errc
+		want float64
+	}{
+		{"1.7976931348623158e308", General, 0, math.MaxFloat64},
+		{"1.7976931348623159e308", General, ResultOutOfRange, 0},
+		{"4.9406564584124654e-324", General, 0, math.SmallestNonzeroFloat64},
+		{"2.4703282292062328e-324", General, 0, math.SmallestNonzeroFloat64},
+		{"2.4703282292062327e-324", General, ResultOutOfRange, 0},
+		{"1e-400", General, ResultOutOfRange, 0},
+		{"0e-400", General, 0, 0},
+		{"0e999999999999", General, 0, 0},
+		// Hex rounding carries into the next binade, or out of the range.
+		{"1.fffffffffffff7p1023", Hex, 0, math.MaxFloat64},
+		{"1.fffffffffffff8p1023", Hex, ResultOutOfRange, 0},
+		{"1.fffffffffffff8p0", Hex, 0, 2},
+		{"1p-1075", Hex, ResultOutOfRange, 0},
+		{"1.1p-1075", Hex, 0, math.SmallestNonzeroFloat64},
+		{"1p-1074", Hex, 0, math.SmallestNonzeroFloat64},
+	} {
+		got := -1.0
+		ptr, ec := FromCharsFloat([]byte(tt.s), &got, tt.fmt)
+		if tt.ec != 0 {
+			tt.want = -1 // *value is not modified on error.
+		}
+		if ptr != len(tt.s) || ec != tt.ec || got != tt.want {
+			t.Errorf("FromCharsFloat(%q) = %v, %d, %v, want %v, %d, %v", tt.s, got, ptr, ec, tt.want, len(tt.s), tt.ec)
+		}
+	}
+
+	for _, tt := range []struct {
+		s    string
+		ec   Errc
+		want float32
+	}{
+		{"3.4028235e38", 0, math.MaxFloat32},
+		{"3.4028236e38", ResultOutOfRange, 0},
+		{"1e-46", ResultOutOfRange, 0},
+		{"1.4e-45", 0, math.SmallestNonzeroFloat32},
+	} {
+		var got float32
+		ptr, ec := FromCharsFloat([]byte(tt.s), &got, General)
+		if ptr != len(tt.s) || ec != tt.ec || got != tt.want {
+			t.Errorf("FromCharsFloat[float32](%q) = %v, %d, %v, want %v, %v", tt.s, got, ptr, ec, tt.want, tt.ec)
+		}
+	}
+}
+
+func TestToCharsFloat(t *testing.T) {
+	for _, tt := range []struct {
+		value     float64
+		fmt       CharsFormat
+		precision int
+		want      string
+	}{
+		{1.5, 0, -1, "1.5"},
+		{1e21, 0, -1, "1e+21"},
+		{1e-7, 0, -1, "1e-07"},
+		{123456, 0, -1, "123456"},
+		{0.1, Fixed, -1, "0.1"},
+		{0.1, Fixed, 3, "0.100"},
+		{1234.5, Scientific, 2, "1.23e+03"},
+		{1234.5, Scientific, -1, "1.2345e+03"},
+		{1e-5, General, -1, "1e-05"},
+		{1234.5, General, 3, "1.23e+03"},
+		{1, Hex, -1, "1p+0"},
+		{-3, Hex, -1, "-1.8p+1"},
+		{0.1, Hex, 2, "1.9ap-4"},
+		// Rounding to the precision carries into the integral digit.
+		{1.96875, Hex, 0, "2p+0"},
+		{1.96875, Hex, 1, "2.0p+0"},
+		{1.9375, Hex, 1, "1.fp+0"},
+		{1, Hex, 15, "1.000000000000000p+0"},
+		{math.SmallestNonzeroFloat64, Hex, -1, "0.0000000000001p-1022"},
+		{math.Inf(-1), General, -1, "-inf"},
+		{math.NaN(), Fixed, 2, "nan"},
+		{math.Copysign(0, -1), Fixed, -1, "-0"},
+	} {
+		var buf [64]byte
+		ptr, ec := ToCharsFloat(buf[:], tt.value, tt.fmt, tt.precision)
+		if got := string(buf[:ptr]); ec != 0 || got != tt.want {
+			t.Errorf("ToCharsFloat(%v, %d, %d) = %q, %v, want %q", tt.value, tt.fmt, tt.precision, got, ec, tt.want)
+		}
+	}
+
+	var f32 [16]byte
+	if ptr, ec := ToCharsFloat(f32[:], float32(0.1), 0, -1); ec != 0 || string(f32[:ptr]) != "0.1" {
+		t.Errorf("ToCharsFloat(float32(0.1)) = %q, %v, want 0.1", f32[:ptr], ec)
+	}
+	var short [3]byte
+	if ptr, ec := ToCharsFloat(short[:], 1234.0, Fixed, -1); ptr != 3 || ec != ValueTooLarge {
+		t.Errorf("ToCharsFloat into a short buffer = %d, %v, want 3, ValueTooLarge", ptr, ec)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	values := []float64{0, 1, -1, 0.1, 1.0 / 3, math.Pi, 1e23, 5e-324, math.MaxFloat64, 2.2250738585072014e-308}
+	for _, fmt := range []CharsFormat{0, Fixed, Scientific, General, Hex} {
+		parseFmt := fmt
+		if fmt == 0 {
+			parseFmt = General
+		}
+		for _, v := range values {
+			var buf [bufSize]byte
+			ptr, ec := ToCharsFloat(buf[:], v, fmt, -1)
+			var got float64
+			n, ec2 := FromCharsFloat(buf[:ptr], &got, parseFmt)
+			if ec != 0 || ec2 != 0 || n != ptr || got != v {
+				t.Errorf("format %d: %v -> %q -> %v (%v, %v)", fmt, v, buf[:ptr], got, ec, ec2)
+			}
+		}
+	}
+}
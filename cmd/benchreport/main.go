@@ -0,0 +1,282 @@
+// Benchreport renders the results of the benchmark suites, such as
+// BenchmarkStdlib of package algorithm, as a table that compares the
+// implementations of each operation side by side.
+//
+// Usage:
+//
+//	benchreport [-subject impl] [-unit unit] [-split dir] [file ...]
+//
+// Benchreport reads the output of go test -bench from the named files, or from
+// standard input if there are none. The sub-benchmarks that differ only in
+// their impl=name part are compared: the table has a row for each operation
+// and a column for each implementation holding the median of its samples, and
+// a last column with the change from the fastest other implementation to the
+// subject implementation (gocpp by default). Negative changes mean that the
+// subject is faster.
+//
+// With -split, benchreport also writes the results of each implementation to
+// dir/impl.txt with the impl=name part removed from the names, so that
+// benchstat can compare any two of them:
+//
+//	benchreport -split out bench.txt
+//	benchstat out/slices.txt out/gocpp.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// result is a line of benchmark output.
+type result struct {
+	group  string // the name without the impl part and GOMAXPROCS suffix
+	impl   string
+	values map[string]float64 // by unit
+	line   string             // the line with the impl part removed
+}
+
+// parse reads benchmark output and returns its results and its configuration
+// lines, such as "goos: linux", in order.
+func parse(r io.Reader) (results []result, config []string, err error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		if isConfig(line) {
+			config = append(config, line)
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		res := result{values: make(map[string]float64)}
+		res.group, res.impl = splitName(fields[0])
+		for i := 2; i < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: bad value %q", fields[0], fields[i])
+			}
+			res.values[fields[i+1]] = v
+		}
+		res.line = strings.Replace(line, fields[0], removeImpl(fields[0]), 1)
+		results = append(results, res)
+	}
+	return results, config, sc.Err()
+}
+
+// isConfig reports whether line is a "key: value" configuration line, with a
+// lower case key without spaces.
+func isConfig(line string) bool {
+	key, _, ok := strings.Cut(line, ":")
+	return ok && key != "" && !strings.ContainsAny(key, " \t") && strings.ToLower(key) == key
+}
+
+// splitName returns the name of a benchmark without its impl part and its
+// GOMAXPROCS suffix, and the value of the impl part.
+func splitName(name string) (group, impl string) {
+	if i := strings.LastIndexByte(name, '-'); i >= 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+	var parts []string
+	for _, p := range strings.Split(name, "/") {
+		if v, ok := strings.CutPrefix(p, "impl="); ok {
+			impl = v
+		} else {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "/"), impl
+}
+
+// removeImpl returns name without its impl part, keeping the GOMAXPROCS
+// suffix.
+func removeImpl(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, "impl=") {
+			suffix := ""
+			if j := strings.LastIndexByte(p, '-'); j >= 0 {
+				if _, err := strconv.Atoi(p[j+1:]); err == nil {
+					suffix = p[j:]
+				}
+			}
+			parts = slices.Delete(parts, i, i+1)
+			if i == len(parts) {
+				parts[i-1] += suffix
+			}
+			break
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func median(s []float64) float64 {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// format formats v with 4 significant digits and an SI prefix.
+func format(v float64) string {
+	for _, p := range []struct {
+		scale  float64
+		prefix string
+	}{{1e9, "G"}, {1e6, "M"}, {1e3, "k"}} {
+		if math.Abs(v) >= p.scale {
+			return strconv.FormatFloat(v/p.scale, 'g', 4, 64) + p.prefix
+		}
+	}
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// report writes the table of the medians of unit, comparing subject with the
+// other implementations.
+func report(w io.Writer, results []result, subject, unit string) error {
+	var groups, impls []string
+	samples := make(map[[2]string][]float64)
+	for _, r := range results {
+		v, ok := r.values[unit]
+		if !ok || r.impl == "" {
+			continue
+		}
+		if !slices.Contains(groups, r.group) {
+			groups = append(groups, r.group)
+		}
+		if !slices.Contains(impls, r.impl) {
+			impls = append(impls, r.impl)
+		}
+		key := [2]string{r.group, r.impl}
+		samples[key] = append(samples[key], v)
+	}
+	if len(groups) == 0 {
+		return fmt.Errorf("no results with an impl= part and unit %s", unit)
+	}
+	// The subject goes last, next to the change column.
+	if i := slices.Index(impls, subject); i >= 0 {
+		impls = append(slices.Delete(impls, i, i+1), subject)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\t", unit)
+	for _, impl := range impls {
+		fmt.Fprintf(tw, "%s\t", impl)
+	}
+	fmt.Fprintf(tw, "%s vs best\t\n", subject)
+
+	logSum, n := 0.0, 0
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t", g)
+		best := math.Inf(1)
+		for _, impl := range impls {
+			s, ok := samples[[2]string{g, impl}]
+			if !ok {
+				fmt.Fprint(tw, "-\t")
+				continue
+			}
+			m := median(s)
+			fmt.Fprintf(tw, "%s\t", format(m))
+			if impl != subject {
+				best = min(best, m)
+			}
+		}
+		s, ok := samples[[2]string{g, subject}]
+		if !ok || math.IsInf(best, 1) || best == 0 {
+			fmt.Fprint(tw, "-\t\n")
+			continue
+		}
+		ratio := median(s) / best
+		logSum += math.Log(ratio)
+		n++
+		fmt.Fprintf(tw, "%+.1f%%\t\n", (ratio-1)*100)
+	}
+	if n > 0 {
+		fmt.Fprintf(tw, "geomean\t%s%+.1f%%\t\n", strings.Repeat("\t", len(impls)), (math.Exp(logSum/float64(n))-1)*100)
+	}
+	return tw.Flush()
+}
+
+// split writes the results of each implementation to dir/impl.txt, preceded
+// by the configuration lines.
+func split(dir string, results []result, config []string) error {
+	files := make(map[string][]string)
+	var impls []string
+	for _, r := range results {
+		if r.impl == "" {
+			continue
+		}
+		if _, ok := files[r.impl]; !ok {
+			impls = append(impls, r.impl)
+		}
+		files[r.impl] = append(files[r.impl], r.line)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, impl := range impls {
+		lines := append(slices.Clone(config), files[impl]...)
+		data := []byte(strings.Join(lines, "\n") + "\n")
+		if err := os.WriteFile(filepath.Join(dir, impl+".txt"), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("benchreport: ")
+	subject := flag.String("subject", "gocpp", "the `impl` to compare with the others")
+	unit := flag.String("unit", "ns/op", "the `unit` of the values to compare")
+	splitDir := flag.String("split", "", "write the results of each implementation to `dir`/impl.txt")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: benchreport [-subject impl] [-unit unit] [-split dir] [file ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var in io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		var readers []io.Reader
+		for _, name := range flag.Args() {
+			f, err := os.Open(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+			readers = append(readers, f)
+		}
+		in = io.MultiReader(readers...)
+	}
+
+	results, config, err := parse(in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *splitDir != "" {
+		if err := split(*splitDir, results, config); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := report(os.Stdout, results, *subject, *unit); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const input = `goos: linux
+pkg: gocpp/algorithm
+BenchmarkStdlib/Find/n=10/impl=gocpp-8    	100	 10.0 ns/op	 0 B/op
+BenchmarkStdlib/Find/n=10/impl=gocpp-8    	100	 12.0 ns/op	 0 B/op
+BenchmarkStdlib/Find/n=10/impl=gocpp-8    	100	 11.0 ns/op	 0 B/op
+BenchmarkStdlib/Find/n=10/impl=slices-8   	100	 20.0 ns/op	 0 B/op
+BenchmarkStdlib/Find/n=10/impl=loop-8     	100	 40.0 ns/op	 0 B/op
+BenchmarkStdlib/Sort/impl=sort/n=10-8     	100	 1000 ns/op
+BenchmarkStdlib/Sort/impl=gocpp/n=10-8    	100	 1500 ns/op
+BenchmarkOther-8                          	100	 5.0 ns/op
+PASS
+ok  	gocpp/algorithm	1.0s
+`
+
+func TestSplitName(t *testing.T) {
+	for _, tt := range []struct{ name, group, impl, removed string }{
+		{"BenchmarkX/a=1/impl=gocpp-8", "BenchmarkX/a=1", "gocpp", "BenchmarkX/a=1-8"},
+		{"BenchmarkX/impl=my-impl/a=1", "BenchmarkX/a=1", "my-impl", "BenchmarkX/a=1"},
+		{"BenchmarkX/impl=my-impl", "BenchmarkX", "my-impl", "BenchmarkX"},
+		{"BenchmarkX-16", "BenchmarkX", "", "BenchmarkX-16"},
+	} {
+		group, impl := splitName(tt.name)
+		if group != tt.group || impl != tt.impl {
+			t.Errorf("splitName(%q) = %q, %q, want %q, %q", tt.name, group, impl, tt.group, tt.impl)
+		}
+		if got := removeImpl(tt.name); got != tt.removed {
+			t.Errorf("removeImpl(%q) = %q, want %q", tt.name, got, tt.removed)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	results, config, err := parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 8 || len(config) != 2 {
+		t.Fatalf("parse: %d results, %d config lines", len(results), len(config))
+	}
+
+	var sb strings.Builder
+	if err := report(&sb, results, "gocpp", "ns/op"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	want := [][]string{
+		{"ns/op", "slices", "loop", "sort", "gocpp", "gocpp", "vs", "best"},
+		{"BenchmarkStdlib/Find/n=10", "20", "40", "-", "11", "-45.0%"},
+		{"BenchmarkStdlib/Sort/n=10", "-", "-", "1k", "1.5k", "+50.0%"},
+		{"geomean", "-9.2%"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("report:\n%s", sb.String())
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("report line %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	results, config, err := parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := split(dir, results, config); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "gocpp.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if !strings.HasPrefix(got, "goos: linux\npkg: gocpp/algorithm\n") ||
+		strings.Count(got, "BenchmarkStdlib/Find/n=10-8 ") != 3 ||
+		!strings.Contains(got, "BenchmarkStdlib/Sort/n=10-8 ") ||
+		strings.Contains(got, "impl=") {
+		t.Errorf("gocpp.txt =\n%s", got)
+	}
+}
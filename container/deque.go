@@ -0,0 +1,171 @@
+package container
+
+import "fmt"
+
+// Deque is an indexed sequence container that allows fast insertion and
+// deletion at both its beginning and its end, like std::deque. It is
+// implemented as a growable ring buffer. The zero Deque is empty and ready to
+// use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// Constructs an empty deque.
+func NewDeque[T any]() *Deque[T] {
+	return new(Deque[T])
+}
+
+// Constructs a deque holding a copy of the elements of r[first, last).
+func NewDequeFrom[T any](r []T, first, last int) *Deque[T] {
+	d := &Deque[T]{buf: make([]T, last-first)}
+	copy(d.buf, r[first:last])
+	d.size = last - first
+	return d
+}
+
+func (d *Deque[T]) index(i int) int {
+	i += d.head
+	if i >= len(d.buf) {
+		i -= len(d.buf)
+	}
+	return i
+}
+
+func (d *Deque[T]) checkIndex(method string, i int) {
+	if i < 0 || i >= d.size {
+		panic(fmt.Sprintf("container: Deque.%s: index %d out of range [0, %d)", method, i, d.size))
+	}
+}
+
+// grow makes room for at least one more element.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), 8))
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf = buf
+	d.head = 0
+}
+
+// Returns the element at position i. Panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.checkIndex("At", i)
+	return d.buf[d.index(i)]
+}
+
+// Replaces the element at position i with v. Panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	d.checkIndex("Set", i)
+	d.buf[d.index(i)] = v
+}
+
+// Returns the first element in the deque.
+func (d *Deque[T]) Front() T {
+	return d.At(0)
+}
+
+// Returns the last element in the deque.
+func (d *Deque[T]) Back() T {
+	return d.At(d.size - 1)
+}
+
+// Checks if the deque has no elements.
+func (d *Deque[T]) Empty() bool {
+	return d.size == 0
+}
+
+// Returns the number of elements in the deque.
+func (d *Deque[T]) Size() int {
+	return d.size
+}
+
+// Erases all elements from the deque.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.size = 0
+}
+
+// Appends v to the end of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.size)] = v
+	d.size++
+}
+
+// Prepends v to the beginning of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.size++
+}
+
+// Removes the last element of the deque. Panics if the deque is empty.
+func (d *Deque[T]) PopBack() {
+	d.checkIndex("PopBack", 0)
+	var zero T
+	d.buf[d.index(d.size-1)] = zero
+	d.size--
+}
+
+// Removes the first element of the deque. Panics if the deque is empty.
+func (d *Deque[T]) PopFront() {
+	d.checkIndex("PopFront", 0)
+	var zero T
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+}
+
+// Inserts v before position pos, shifting whichever side of the deque is
+// shorter. Returns pos.
+func (d *Deque[T]) Insert(pos int, v T) int {
+	if pos < 0 || pos > d.size {
+		panic(fmt.Sprintf("container: Deque.Insert: position %d out of range [0, %d]", pos, d.size))
+	}
+	if pos < d.size/2 {
+		d.PushFront(v)
+		for i := 0; i < pos; i++ {
+			d.buf[d.index(i)] = d.buf[d.index(i+1)]
+		}
+	} else {
+		d.PushBack(v)
+		for i := d.size - 1; i > pos; i-- {
+			d.buf[d.index(i)] = d.buf[d.index(i-1)]
+		}
+	}
+	d.buf[d.index(pos)] = v
+	return pos
+}
+
+// Removes the element at position pos, shifting whichever side of the deque is
+// shorter. Returns pos, which now refers to the element that followed the
+// removed one.
+func (d *Deque[T]) Erase(pos int) int {
+	d.checkIndex("Erase", pos)
+	if pos < d.size/2 {
+		for i := pos; i > 0; i-- {
+			d.buf[d.index(i)] = d.buf[d.index(i-1)]
+		}
+		d.PopFront()
+	} else {
+		for i := pos; i < d.size-1; i++ {
+			d.buf[d.index(i)] = d.buf[d.index(i+1)]
+		}
+		d.PopBack()
+	}
+	return pos
+}
+
+// Returns a copy of the elements of the deque as a slice, in order.
+func (d *Deque[T]) Slice() []T {
+	ret := make([]T, d.size)
+	n := copy(ret, d.buf[d.head:min(d.head+d.size, len(d.buf))])
+	copy(ret[n:], d.buf[:d.size-n])
+	return ret
+}
@@ -0,0 +1,301 @@
+package container
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"gocpp/algorithm"
+)
+
+// SortedUniqueT is the type of SortedUnique.
+type SortedUniqueT struct{}
+
+// SortedUnique tags constructors and insertions whose input is already sorted
+// by the container's comparator and contains no equivalent keys, like
+// std::sorted_unique. Such input is used as-is without sorting.
+var SortedUnique SortedUniqueT
+
+// FlatMap is a sorted associative container that contains key-value pairs
+// with unique keys, like std::flat_map. Keys and values are stored in two
+// parallel slices sorted by key, which makes lookup cache-friendly at the
+// expense of linear-time insertion and removal. Positions in a FlatMap are
+// indexes into Keys() and Values(), so End() is Size(); positions are
+// invalidated by any insertion or removal.
+type FlatMap[K, V any] struct {
+	keys   []K
+	values []V
+	comp   func(K, K) bool
+}
+
+// Constructs an empty flat map ordered by operator<.
+func NewFlatMap[K cmp.Ordered, V any]() *FlatMap[K, V] {
+	return NewFlatMapFunc[K, V](cmp.Less[K])
+}
+
+// Constructs an empty flat map ordered by the strict weak ordering comp.
+func NewFlatMapFunc[K, V any](comp func(K, K) bool) *FlatMap[K, V] {
+	return &FlatMap[K, V]{comp: comp}
+}
+
+// Constructs a flat map ordered by operator< holding the elements of the
+// parallel slices keys and values. Of several equivalent keys, the first one
+// is kept.
+func NewFlatMapFrom[K cmp.Ordered, V any](keys []K, values []V) *FlatMap[K, V] {
+	return NewFlatMapFromFunc(keys, values, cmp.Less[K])
+}
+
+// Constructs a flat map ordered by comp holding the elements of the parallel
+// slices keys and values. Of several equivalent keys, the first one is kept.
+func NewFlatMapFromFunc[K, V any](keys []K, values []V, comp func(K, K) bool) *FlatMap[K, V] {
+	m := NewFlatMapFunc[K, V](comp)
+	m.InsertRange(keys, values, 0, len(keys))
+	return m
+}
+
+// Constructs a flat map ordered by operator< that adopts keys and values,
+// which must already be sorted and free of equivalent keys.
+func NewFlatMapSorted[K cmp.Ordered, V any](_ SortedUniqueT, keys []K, values []V) *FlatMap[K, V] {
+	return NewFlatMapSortedFunc(SortedUnique, keys, values, cmp.Less[K])
+}
+
+// Constructs a flat map ordered by comp that adopts keys and values, which must
+// already be sorted by comp and free of equivalent keys.
+func NewFlatMapSortedFunc[K, V any](_ SortedUniqueT, keys []K, values []V, comp func(K, K) bool) *FlatMap[K, V] {
+	return &FlatMap[K, V]{keys, values, comp}
+}
+
+// Returns the function that compares keys.
+func (m *FlatMap[K, V]) KeyComp() func(K, K) bool {
+	return m.comp
+}
+
+// Returns the sorted keys of the map. The slice is owned by the map and must
+// not be modified.
+func (m *FlatMap[K, V]) Keys() []K {
+	return m.keys
+}
+
+// Returns the values of the map, in the order of their keys. The slice is
+// owned by the map; its elements may be modified in place.
+func (m *FlatMap[K, V]) Values() []V {
+	return m.values
+}
+
+// Returns the position of the first element of the map, which is 0.
+func (m *FlatMap[K, V]) Begin() int {
+	return 0
+}
+
+// Returns the position following the last element of the map, which is
+// Size().
+func (m *FlatMap[K, V]) End() int {
+	return len(m.keys)
+}
+
+// Checks if the map has no elements.
+func (m *FlatMap[K, V]) Empty() bool {
+	return len(m.keys) == 0
+}
+
+// Returns the number of elements in the map.
+func (m *FlatMap[K, V]) Size() int {
+	return len(m.keys)
+}
+
+// Erases all elements from the map.
+func (m *FlatMap[K, V]) Clear() {
+	m.keys = m.keys[:0]
+	m.values = m.values[:0]
+}
+
+// Returns the value mapped to key k. Panics if there is no such element.
+func (m *FlatMap[K, V]) At(k K) V {
+	i := m.Find(k)
+	if i == m.End() {
+		panic(fmt.Sprintf("container: FlatMap.At: key %v not found", k))
+	}
+	return m.values[i]
+}
+
+// Returns the value mapped to key k and whether such an element exists.
+func (m *FlatMap[K, V]) Get(k K) (V, bool) {
+	i := m.Find(k)
+	if i == m.End() {
+		var zero V
+		return zero, false
+	}
+	return m.values[i], true
+}
+
+// Inserts the element (k, v) if the map doesn't already contain an element
+// with an equivalent key. Returns the position of the element with key k and
+// whether the insertion took place.
+func (m *FlatMap[K, V]) Insert(k K, v V) (int, bool) {
+	i := m.LowerBound(k)
+	if i != m.End() && !m.comp(k, m.keys[i]) {
+		return i, false
+	}
+	m.keys = slices.Insert(m.keys, i, k)
+	m.values = slices.Insert(m.values, i, v)
+	return i, true
+}
+
+// Inserts the element (k, v) if the key does not exist, otherwise assigns v to
+// the element with key k. Returns the position of the element and whether an
+// insertion took place.
+func (m *FlatMap[K, V]) InsertOrAssign(k K, v V) (int, bool) {
+	i, ok := m.Insert(k, v)
+	if !ok {
+		m.values[i] = v
+	}
+	return i, ok
+}
+
+// Inserts the elements of keys[first, last) and values[first, last). The
+// elements are appended, then the map is sorted and elements with equivalent
+// keys are removed, keeping the one that was in the map first. Takes
+// O(N log N) time where N is the resulting size.
+func (m *FlatMap[K, V]) InsertRange(keys []K, values []V, first, last int) {
+	m.keys = append(m.keys, keys[first:last]...)
+	m.values = append(m.values, values[first:last]...)
+
+	perm := make([]int, len(m.keys))
+	for i := range perm {
+		perm[i] = i
+	}
+	slices.SortStableFunc(perm, func(a, b int) int {
+		return threeWay(m.keys[a], m.keys[b], m.comp)
+	})
+	perm = perm[:algorithm.UniqueFunc(perm, 0, len(perm), func(a, b int) bool {
+		return !m.comp(m.keys[a], m.keys[b]) && !m.comp(m.keys[b], m.keys[a])
+	})]
+
+	sortedKeys := make([]K, len(perm))
+	sortedValues := make([]V, len(perm))
+	for i, p := range perm {
+		sortedKeys[i] = m.keys[p]
+		sortedValues[i] = m.values[p]
+	}
+	m.keys, m.values = sortedKeys, sortedValues
+}
+
+// Inserts the elements of keys[first, last) and values[first, last), which
+// must already be sorted and free of equivalent keys. The elements are merged
+// into the map in linear time. Elements whose key is already in the map are
+// not inserted.
+func (m *FlatMap[K, V]) InsertRangeSorted(_ SortedUniqueT, keys []K, values []V, first, last int) {
+	n := len(m.keys) + last - first
+	mergedKeys := make([]K, 0, n)
+	mergedValues := make([]V, 0, n)
+	i := 0
+	for first != last || i != len(m.keys) {
+		switch {
+		case first == last || i != len(m.keys) && m.comp(m.keys[i], keys[first]):
+			mergedKeys = append(mergedKeys, m.keys[i])
+			mergedValues = append(mergedValues, m.values[i])
+			i++
+		case i == len(m.keys) || m.comp(keys[first], m.keys[i]):
+			mergedKeys = append(mergedKeys, keys[first])
+			mergedValues = append(mergedValues, values[first])
+			first++
+		default:
+			first++
+		}
+	}
+	m.keys, m.values = mergedKeys, mergedValues
+}
+
+// Removes the element at position pos and returns pos, which now refers to the
+// element that followed the removed one.
+func (m *FlatMap[K, V]) Erase(pos int) int {
+	return m.EraseRange(pos, pos+1)
+}
+
+// Removes the elements in the range [first, last) and returns first.
+func (m *FlatMap[K, V]) EraseRange(first, last int) int {
+	m.keys = slices.Delete(m.keys, first, last)
+	m.values = slices.Delete(m.values, first, last)
+	return first
+}
+
+// Removes the element with key equivalent to k, if any, and returns the
+// number of elements removed.
+func (m *FlatMap[K, V]) EraseKey(k K) int {
+	i := m.Find(k)
+	if i == m.End() {
+		return 0
+	}
+	m.Erase(i)
+	return 1
+}
+
+// Moves the underlying slices out of the map, leaving it empty.
+func (m *FlatMap[K, V]) Extract() ([]K, []V) {
+	keys, values := m.keys, m.values
+	m.keys, m.values = nil, nil
+	return keys, values
+}
+
+// Replaces the underlying slices with keys and values, which must already be
+// sorted and free of equivalent keys.
+func (m *FlatMap[K, V]) Replace(keys []K, values []V) {
+	m.keys, m.values = keys, values
+}
+
+// Exchanges the contents of the map with those of other.
+func (m *FlatMap[K, V]) Swap(other *FlatMap[K, V]) {
+	*m, *other = *other, *m
+}
+
+// Returns the number of elements with key equivalent to k, which is either 1
+// or 0.
+func (m *FlatMap[K, V]) Count(k K) int {
+	if m.Contains(k) {
+		return 1
+	}
+	return 0
+}
+
+// Finds an element with key equivalent to k. Returns End() if there is no such
+// element.
+func (m *FlatMap[K, V]) Find(k K) int {
+	i := m.LowerBound(k)
+	if i == m.End() || m.comp(k, m.keys[i]) {
+		return m.End()
+	}
+	return i
+}
+
+// Checks if there is an element with key equivalent to k in the map.
+func (m *FlatMap[K, V]) Contains(k K) bool {
+	return m.Find(k) != m.End()
+}
+
+// Returns the range [first, last) of positions of elements with key
+// equivalent to k.
+func (m *FlatMap[K, V]) EqualRange(k K) (int, int) {
+	return m.LowerBound(k), m.UpperBound(k)
+}
+
+// Returns the position of the first element that is not less than k.
+func (m *FlatMap[K, V]) LowerBound(k K) int {
+	return algorithm.LowerBoundFunc(m.keys, 0, len(m.keys), k, m.comp)
+}
+
+// Returns the position of the first element that is greater than k.
+func (m *FlatMap[K, V]) UpperBound(k K) int {
+	return algorithm.UpperBoundFunc(m.keys, 0, len(m.keys), k, m.comp)
+}
+
+// threeWay converts the strict weak ordering comp into a three-way comparison
+// for the slices package.
+func threeWay[T any](a, b T, comp func(T, T) bool) int {
+	if comp(a, b) {
+		return -1
+	}
+	if comp(b, a) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,215 @@
+package container
+
+import (
+	"cmp"
+	"slices"
+
+	"gocpp/algorithm"
+)
+
+// FlatSet is an associative container that contains a sorted set of unique
+// objects, like std::flat_set. The elements are stored in a sorted slice,
+// which makes lookup cache-friendly at the expense of linear-time insertion
+// and removal. Positions in a FlatSet are indexes into Values(), so End() is
+// Size(); positions are invalidated by any insertion or removal.
+type FlatSet[K any] struct {
+	keys []K
+	comp func(K, K) bool
+}
+
+// Constructs an empty flat set ordered by operator<.
+func NewFlatSet[K cmp.Ordered]() *FlatSet[K] {
+	return NewFlatSetFunc[K](cmp.Less[K])
+}
+
+// Constructs an empty flat set ordered by the strict weak ordering comp.
+func NewFlatSetFunc[K any](comp func(K, K) bool) *FlatSet[K] {
+	return &FlatSet[K]{comp: comp}
+}
+
+// Constructs a flat set ordered by operator< holding the elements of keys. Of
+// several equivalent elements, the first one is kept.
+func NewFlatSetFrom[K cmp.Ordered](keys []K) *FlatSet[K] {
+	return NewFlatSetFromFunc(keys, cmp.Less[K])
+}
+
+// Constructs a flat set ordered by comp holding the elements of keys. Of
+// several equivalent elements, the first one is kept.
+func NewFlatSetFromFunc[K any](keys []K, comp func(K, K) bool) *FlatSet[K] {
+	s := NewFlatSetFunc(comp)
+	s.InsertRange(keys, 0, len(keys))
+	return s
+}
+
+// Constructs a flat set ordered by operator< that adopts keys, which must
+// already be sorted and free of equivalent elements.
+func NewFlatSetSorted[K cmp.Ordered](_ SortedUniqueT, keys []K) *FlatSet[K] {
+	return NewFlatSetSortedFunc(SortedUnique, keys, cmp.Less[K])
+}
+
+// Constructs a flat set ordered by comp that adopts keys, which must already be
+// sorted by comp and free of equivalent elements.
+func NewFlatSetSortedFunc[K any](_ SortedUniqueT, keys []K, comp func(K, K) bool) *FlatSet[K] {
+	return &FlatSet[K]{keys, comp}
+}
+
+// Returns the function that compares elements.
+func (s *FlatSet[K]) KeyComp() func(K, K) bool {
+	return s.comp
+}
+
+// Returns the sorted elements of the set. The slice is owned by the set and
+// must not be modified.
+func (s *FlatSet[K]) Values() []K {
+	return s.keys
+}
+
+// Returns the position of the first element of the set, which is 0.
+func (s *FlatSet[K]) Begin() int {
+	return 0
+}
+
+// Returns the position following the last element of the set, which is
+// Size().
+func (s *FlatSet[K]) End() int {
+	return len(s.keys)
+}
+
+// Checks if the set has no elements.
+func (s *FlatSet[K]) Empty() bool {
+	return len(s.keys) == 0
+}
+
+// Returns the number of elements in the set.
+func (s *FlatSet[K]) Size() int {
+	return len(s.keys)
+}
+
+// Erases all elements from the set.
+func (s *FlatSet[K]) Clear() {
+	s.keys = s.keys[:0]
+}
+
+// Inserts k if the set doesn't already contain an equivalent element. Returns
+// the position of the element equivalent to k and whether the insertion took
+// place.
+func (s *FlatSet[K]) Insert(k K) (int, bool) {
+	i := s.LowerBound(k)
+	if i != s.End() && !s.comp(k, s.keys[i]) {
+		return i, false
+	}
+	s.keys = slices.Insert(s.keys, i, k)
+	return i, true
+}
+
+// Inserts the elements of r[first, last). The elements are appended, then the
+// set is sorted and equivalent elements are removed, keeping the one that was
+// in the set first. Takes O(N log N) time where N is the resulting size.
+func (s *FlatSet[K]) InsertRange(r []K, first, last int) {
+	s.keys = append(s.keys, r[first:last]...)
+	slices.SortStableFunc(s.keys, func(a, b K) int {
+		return threeWay(a, b, s.comp)
+	})
+	s.keys = s.keys[:algorithm.UniqueFunc(s.keys, 0, len(s.keys), func(a, b K) bool {
+		return !s.comp(a, b) && !s.comp(b, a)
+	})]
+}
+
+// Inserts the elements of r[first, last), which must already be sorted and
+// free of equivalent elements. The elements are merged into the set in linear
+// time. Elements already in the set are not inserted.
+func (s *FlatSet[K]) InsertRangeSorted(_ SortedUniqueT, r []K, first, last int) {
+	merged := make([]K, 0, len(s.keys)+last-first)
+	i := 0
+	for first != last || i != len(s.keys) {
+		switch {
+		case first == last || i != len(s.keys) && s.comp(s.keys[i], r[first]):
+			merged = append(merged, s.keys[i])
+			i++
+		case i == len(s.keys) || s.comp(r[first], s.keys[i]):
+			merged = append(merged, r[first])
+			first++
+		default:
+			first++
+		}
+	}
+	s.keys = merged
+}
+
+// Removes the element at position pos and returns pos, which now refers to the
+// element that followed the removed one.
+func (s *FlatSet[K]) Erase(pos int) int {
+	return s.EraseRange(pos, pos+1)
+}
+
+// Removes the elements in the range [first, last) and returns first.
+func (s *FlatSet[K]) EraseRange(first, last int) int {
+	s.keys = slices.Delete(s.keys, first, last)
+	return first
+}
+
+// Removes the element equivalent to k, if any, and returns the number of
+// elements removed.
+func (s *FlatSet[K]) EraseKey(k K) int {
+	i := s.Find(k)
+	if i == s.End() {
+		return 0
+	}
+	s.Erase(i)
+	return 1
+}
+
+// Moves the underlying slice out of the set, leaving it empty.
+func (s *FlatSet[K]) Extract() []K {
+	keys := s.keys
+	s.keys = nil
+	return keys
+}
+
+// Replaces the underlying slice with keys, which must already be sorted and
+// free of equivalent elements.
+func (s *FlatSet[K]) Replace(keys []K) {
+	s.keys = keys
+}
+
+// Exchanges the contents of the set with those of other.
+func (s *FlatSet[K]) Swap(other *FlatSet[K]) {
+	*s, *other = *other, *s
+}
+
+// Returns the number of elements equivalent to k, which is either 1 or 0.
+func (s *FlatSet[K]) Count(k K) int {
+	if s.Contains(k) {
+		return 1
+	}
+	return 0
+}
+
+// Finds an element equivalent to k. Returns End() if there is no such element.
+func (s *FlatSet[K]) Find(k K) int {
+	i := s.LowerBound(k)
+	if i == s.End() || s.comp(k, s.keys[i]) {
+		return s.End()
+	}
+	return i
+}
+
+// Checks if there is an element equivalent to k in the set.
+func (s *FlatSet[K]) Contains(k K) bool {
+	return s.Find(k) != s.End()
+}
+
+// Returns the range [first, last) of positions of elements equivalent to k.
+func (s *FlatSet[K]) EqualRange(k K) (int, int) {
+	return s.LowerBound(k), s.UpperBound(k)
+}
+
+// Returns the position of the first element that is not less than k.
+func (s *FlatSet[K]) LowerBound(k K) int {
+	return algorithm.LowerBoundFunc(s.keys, 0, len(s.keys), k, s.comp)
+}
+
+// Returns the position of the first element that is greater than k.
+func (s *FlatSet[K]) UpperBound(k K) int {
+	return algorithm.UpperBoundFunc(s.keys, 0, len(s.keys), k, s.comp)
+}
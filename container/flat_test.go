@@ -0,0 +1,191 @@
+package container
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// foldLess orders strings case-insensitively, so keys that differ only in
+// case are equivalent but distinguishable.
+func foldLess(a, b string) bool {
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// checkSortedUnique reports an error unless keys is strictly increasing by
+// comp, that is sorted and free of equivalent keys.
+func checkSortedUnique[K any](t *testing.T, keys []K, comp func(K, K) bool) {
+	t.Helper()
+	for i := 1; i < len(keys); i++ {
+		if !comp(keys[i-1], keys[i]) {
+			t.Fatalf("keys %v are not sorted and unique at %d", keys, i)
+		}
+	}
+}
+
+func TestFlatMapInsert(t *testing.T) {
+	tests := []struct {
+		keys       []string
+		values     []int
+		wantKeys   []string
+		wantValues []int
+	}{
+		{nil, nil, nil, nil},
+		{[]string{"b", "a", "c"}, []int{2, 1, 3}, []string{"a", "b", "c"}, []int{1, 2, 3}},
+		// Of several equivalent keys, the first one is kept.
+		{[]string{"b", "A", "a", "B"}, []int{1, 2, 3, 4}, []string{"A", "b"}, []int{2, 1}},
+	}
+	for _, tt := range tests {
+		m := NewFlatMapFromFunc(tt.keys, tt.values, foldLess)
+		if !slices.Equal(m.Keys(), tt.wantKeys) || !slices.Equal(m.Values(), tt.wantValues) {
+			t.Errorf("NewFlatMapFromFunc(%v, %v) = %v, %v, want %v, %v", tt.keys, tt.values, m.Keys(), m.Values(), tt.wantKeys, tt.wantValues)
+		}
+	}
+
+	m := NewFlatMapFunc[string, int](foldLess)
+	for i, k := range []string{"d", "b", "D", "a", "c", "B"} {
+		existed := m.Contains(k)
+		pos, inserted := m.Insert(k, i)
+		if inserted == existed || !strings.EqualFold(m.Keys()[pos], k) {
+			t.Errorf("Insert(%s, %d) = %d, %v", k, i, pos, inserted)
+		}
+		checkSortedUnique(t, m.Keys(), foldLess)
+	}
+	if !slices.Equal(m.Keys(), []string{"a", "b", "c", "d"}) || !slices.Equal(m.Values(), []int{3, 1, 4, 0}) {
+		t.Errorf("after Insert: %v, %v", m.Keys(), m.Values())
+	}
+
+	// InsertOrAssign assigns the value but keeps the key already in the map.
+	if pos, inserted := m.InsertOrAssign("B", 10); pos != 1 || inserted || m.Keys()[1] != "b" || m.At("b") != 10 {
+		t.Errorf("InsertOrAssign of an existing key = %d, %v: %v, %v", pos, inserted, m.Keys(), m.Values())
+	}
+	if pos, inserted := m.InsertOrAssign("bb", 11); pos != 2 || !inserted || m.At("BB") != 11 {
+		t.Errorf("InsertOrAssign of a new key = %d, %v: %v, %v", pos, inserted, m.Keys(), m.Values())
+	}
+	checkSortedUnique(t, m.Keys(), foldLess)
+
+	m.InsertRange([]string{"e", "A", "f", "E"}, []int{5, 6, 7, 8}, 0, 4)
+	checkSortedUnique(t, m.Keys(), foldLess)
+	if !slices.Equal(m.Keys(), []string{"a", "b", "bb", "c", "d", "e", "f"}) || !slices.Equal(m.Values(), []int{3, 10, 11, 4, 0, 5, 7}) {
+		t.Errorf("after InsertRange: %v, %v", m.Keys(), m.Values())
+	}
+
+	m.InsertRangeSorted(SortedUnique, []string{"0", "B", "cc", "z"}, []int{-1, -2, -3, -4}, 0, 4)
+	checkSortedUnique(t, m.Keys(), foldLess)
+	if !slices.Equal(m.Keys(), []string{"0", "a", "b", "bb", "c", "cc", "d", "e", "f", "z"}) || m.At("b") != 10 || m.At("cc") != -3 {
+		t.Errorf("after InsertRangeSorted: %v, %v", m.Keys(), m.Values())
+	}
+}
+
+func TestFlatMapEraseBounds(t *testing.T) {
+	m := NewFlatMapFrom([]int{10, 20, 30, 40, 50}, []string{"a", "b", "c", "d", "e"})
+	tests := []struct {
+		k                         int
+		lower, upper, find, count int
+	}{
+		{5, 0, 0, 5, 0},
+		{10, 0, 1, 0, 1},
+		{25, 2, 2, 5, 0},
+		{50, 4, 5, 4, 1},
+		{55, 5, 5, 5, 0},
+	}
+	for _, tt := range tests {
+		first, last := m.EqualRange(tt.k)
+		if m.LowerBound(tt.k) != tt.lower || m.UpperBound(tt.k) != tt.upper || first != tt.lower || last != tt.upper {
+			t.Errorf("bounds of %d = %d, %d, want %d, %d", tt.k, m.LowerBound(tt.k), m.UpperBound(tt.k), tt.lower, tt.upper)
+		}
+		if m.Find(tt.k) != tt.find || m.Count(tt.k) != tt.count {
+			t.Errorf("Find(%d), Count(%d) = %d, %d, want %d, %d", tt.k, tt.k, m.Find(tt.k), m.Count(tt.k), tt.find, tt.count)
+		}
+	}
+	if _, ok := m.Get(25); ok {
+		t.Errorf("Get of a missing key reported ok")
+	}
+
+	if n := m.EraseKey(30); n != 1 || m.EraseKey(30) != 0 {
+		t.Errorf("EraseKey(30) = %d", n)
+	}
+	if pos := m.Erase(0); pos != 0 || m.Keys()[pos] != 20 {
+		t.Errorf("Erase(0) = %d", pos)
+	}
+	if pos := m.EraseRange(1, 3); pos != 1 || pos != m.End() {
+		t.Errorf("EraseRange(1, 3) = %d", pos)
+	}
+	if !slices.Equal(m.Keys(), []int{20}) || !slices.Equal(m.Values(), []string{"b"}) {
+		t.Errorf("after erasing: %v, %v", m.Keys(), m.Values())
+	}
+
+	keys, values := m.Extract()
+	if !m.Empty() || len(keys) != 1 || len(values) != 1 {
+		t.Errorf("Extract() = %v, %v with size %d left", keys, values, m.Size())
+	}
+	m.Replace([]int{1, 2}, []string{"x", "y"})
+	if m.At(2) != "y" {
+		t.Errorf("Replace: At(2) = %s", m.At(2))
+	}
+	mustPanic(t, "At of a missing key", "", func() { m.At(3) })
+}
+
+func TestFlatSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	in := make([]int, 200)
+	for i := range in {
+		in[i] = rng.Intn(50)
+	}
+	want := sortedUnique(in)
+
+	s := NewFlatSet[int]()
+	for _, k := range in {
+		existed := s.Contains(k)
+		pos, inserted := s.Insert(k)
+		if inserted == existed || s.Values()[pos] != k {
+			t.Fatalf("Insert(%d) = %d, %v", k, pos, inserted)
+		}
+		checkSortedUnique(t, s.Values(), s.KeyComp())
+	}
+	if !slices.Equal(s.Values(), want) {
+		t.Errorf("after Insert: %v, want %v", s.Values(), want)
+	}
+	if got := NewFlatSetFrom(in); !slices.Equal(got.Values(), want) {
+		t.Errorf("NewFlatSetFrom: %v, want %v", got.Values(), want)
+	}
+
+	half := NewFlatSetFrom(in[:100])
+	half.InsertRange(in, 100, 200)
+	if !slices.Equal(half.Values(), want) {
+		t.Errorf("InsertRange: %v, want %v", half.Values(), want)
+	}
+	evens := NewFlatSetSorted(SortedUnique, []int{-2, 0, 2, 48, 60})
+	evens.InsertRangeSorted(SortedUnique, want, 0, len(want))
+	checkSortedUnique(t, evens.Values(), evens.KeyComp())
+	if evens.Size() != len(want)+2 || evens.Values()[0] != -2 || evens.Values()[evens.Size()-1] != 60 {
+		t.Errorf("InsertRangeSorted: %v", evens.Values())
+	}
+
+	fold := NewFlatSetFromFunc([]string{"b", "A", "a", "B", "c"}, foldLess)
+	if !slices.Equal(fold.Values(), []string{"A", "b", "c"}) {
+		t.Errorf("NewFlatSetFromFunc kept %v, want the first of equivalent elements", fold.Values())
+	}
+	if pos, inserted := fold.Insert("C"); pos != 2 || inserted || fold.Values()[2] != "c" {
+		t.Errorf("Insert of an equivalent element = %d, %v", pos, inserted)
+	}
+	fold.InsertRangeSorted(SortedUnique, []string{"a", "bb", "C"}, 0, 3)
+	if !slices.Equal(fold.Values(), []string{"A", "b", "bb", "c"}) {
+		t.Errorf("InsertRangeSorted kept %v, want the elements already in the set", fold.Values())
+	}
+
+	if lower, upper := fold.EqualRange("B"); lower != 1 || upper != 2 || fold.LowerBound("ba") != 2 || fold.UpperBound("z") != fold.End() {
+		t.Errorf("EqualRange(B) = %d, %d", lower, upper)
+	}
+	if n := fold.EraseKey("BB"); n != 1 || fold.Contains("bb") || fold.Count("a") != 1 {
+		t.Errorf("EraseKey(BB) = %d: %v", n, fold.Values())
+	}
+	if pos := fold.EraseRange(0, 2); pos != 0 || !slices.Equal(fold.Values(), []string{"c"}) {
+		t.Errorf("EraseRange(0, 2) = %d: %v", pos, fold.Values())
+	}
+	fold.Clear()
+	if !fold.Empty() || fold.Find("c") != fold.End() {
+		t.Errorf("Clear left %v", fold.Values())
+	}
+}
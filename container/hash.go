@@ -0,0 +1,35 @@
+package container
+
+import "hash/maphash"
+
+var hashSeed = maphash.MakeSeed()
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Returns the hash of the byte sequence b. Equal sequences have equal hashes
+// within a process, so HashBytes can hash []byte keys of the unordered
+// containers.
+func HashBytes[T ~[]byte](b T) uint64 {
+	return maphash.Bytes(hashSeed, b)
+}
+
+// Returns the hash of the string s.
+func HashString[T ~string](s T) uint64 {
+	return maphash.String(hashSeed, string(s))
+}
+
+// Returns the hash of the integer v. The bits of v are mixed, so keys that
+// differ only in their high bits still land in different buckets.
+func HashInteger[T Integer](v T) uint64 {
+	x := uint64(v)
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,263 @@
+package container
+
+import (
+	"fmt"
+	"math"
+)
+
+// hashNode is an element of a hashtable bucket chain. Nodes are relinked, not
+// copied, when the table rehashes, so pointers to them stay valid until the
+// element is erased.
+type hashNode[K, V any] struct {
+	next  *hashNode[K, V]
+	hash  uint64
+	key   K
+	value V
+}
+
+// hashtable is the separate-chaining hash table shared by the unordered
+// associative containers. Elements with equivalent keys are always adjacent in
+// their bucket chain, in insertion order. Containers and their iterators hold
+// a pointer to the table, so swapping two containers swaps table pointers and
+// leaves iterators valid.
+type hashtable[K, V any] struct {
+	buckets       []*hashNode[K, V]
+	size          int
+	maxLoadFactor float64
+	hash          func(K) uint64
+	equal         func(K, K) bool
+}
+
+func newHashtable[K, V any](hash func(K) uint64, equal func(K, K) bool) *hashtable[K, V] {
+	return &hashtable[K, V]{
+		buckets:       make([]*hashNode[K, V], 1),
+		maxLoadFactor: 1,
+		hash:          hash,
+		equal:         equal,
+	}
+}
+
+func (h *hashtable[K, V]) bucket(hash uint64) int {
+	return int(hash % uint64(len(h.buckets)))
+}
+
+// nextPrime returns the smallest prime not less than n.
+func nextPrime(n int) int {
+	if n <= 2 {
+		return 2
+	}
+	if n%2 == 0 {
+		n++
+	}
+	for ; ; n += 2 {
+		prime := true
+		for d := 3; d*d <= n; d += 2 {
+			if n%d == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			return n
+		}
+	}
+}
+
+// minBuckets returns the number of buckets needed to hold n elements without
+// exceeding the maximum load factor.
+func (h *hashtable[K, V]) minBuckets(n int) int {
+	return int(math.Ceil(float64(n) / h.maxLoadFactor))
+}
+
+// rehash sets the number of buckets to at least count, and at least enough to
+// hold the current elements, and redistributes the elements.
+func (h *hashtable[K, V]) rehash(count int) {
+	count = nextPrime(max(count, h.minBuckets(h.size), 1))
+	if count == len(h.buckets) {
+		return
+	}
+
+	buckets := make([]*hashNode[K, V], count)
+	tails := make([]*hashNode[K, V], count)
+	for _, n := range h.buckets {
+		for n != nil {
+			next := n.next
+			n.next = nil
+			b := int(n.hash % uint64(count))
+			if tails[b] == nil {
+				buckets[b] = n
+			} else {
+				tails[b].next = n
+			}
+			tails[b] = n
+			n = next
+		}
+	}
+	h.buckets = buckets
+}
+
+// grow makes room for one more element.
+func (h *hashtable[K, V]) grow() {
+	if h.size+1 > int(float64(len(h.buckets))*h.maxLoadFactor) {
+		h.rehash(max(2*len(h.buckets), h.minBuckets(h.size+1)))
+	}
+}
+
+// find returns the first node with a key equivalent to k, and its bucket.
+func (h *hashtable[K, V]) find(k K) (*hashNode[K, V], int) {
+	return h.findHash(k, h.hash(k))
+}
+
+// findHash is find for a key whose hash is already known.
+func (h *hashtable[K, V]) findHash(k K, hash uint64) (*hashNode[K, V], int) {
+	b := h.bucket(hash)
+	for n := h.buckets[b]; n != nil; n = n.next {
+		if n.hash == hash && h.equal(n.key, k) {
+			return n, b
+		}
+	}
+	return nil, b
+}
+
+// insertUnique links a new node for (k, v) unless an element with an
+// equivalent key exists. It returns the node holding the key, its bucket and
+// whether an insertion took place.
+func (h *hashtable[K, V]) insertUnique(k K, v V) (*hashNode[K, V], int, bool) {
+	hash := h.hash(k)
+	if n, b := h.findHash(k, hash); n != nil {
+		return n, b, false
+	}
+	h.grow()
+	b := h.bucket(hash)
+	n := &hashNode[K, V]{next: h.buckets[b], hash: hash, key: k, value: v}
+	h.buckets[b] = n
+	h.size++
+	return n, b, true
+}
+
+// insertEqual links a new node for (k, v) after the last element with an
+// equivalent key, or at the head of its bucket if there is none.
+func (h *hashtable[K, V]) insertEqual(k K, v V) (*hashNode[K, V], int) {
+	h.grow()
+	n := &hashNode[K, V]{hash: h.hash(k), key: k, value: v}
+	b := h.bucket(n.hash)
+
+	var last *hashNode[K, V]
+	for p := h.buckets[b]; p != nil; p = p.next {
+		if p.hash == n.hash && h.equal(p.key, k) {
+			last = p
+		} else if last != nil {
+			break
+		}
+	}
+
+	if last == nil {
+		n.next = h.buckets[b]
+		h.buckets[b] = n
+	} else {
+		n.next = last.next
+		last.next = n
+	}
+	h.size++
+	return n, b
+}
+
+// advance returns the node following n in bucket b, skipping empty buckets.
+// It returns a nil node at the end of the table.
+func (h *hashtable[K, V]) advance(n *hashNode[K, V], b int) (*hashNode[K, V], int) {
+	if n.next != nil {
+		return n.next, b
+	}
+	return h.first(b + 1)
+}
+
+// first returns the first node in bucket b or any later bucket.
+func (h *hashtable[K, V]) first(b int) (*hashNode[K, V], int) {
+	for ; b < len(h.buckets); b++ {
+		if h.buckets[b] != nil {
+			return h.buckets[b], b
+		}
+	}
+	return nil, 0
+}
+
+// unlink removes n from bucket b and returns the node that followed it.
+func (h *hashtable[K, V]) unlink(n *hashNode[K, V], b int) (*hashNode[K, V], int) {
+	next, nb := h.advance(n, b)
+	if h.buckets[b] == n {
+		h.buckets[b] = n.next
+	} else {
+		p := h.buckets[b]
+		for p.next != n {
+			p = p.next
+		}
+		p.next = n.next
+	}
+	n.next = nil
+	h.size--
+	return next, nb
+}
+
+// equalRange returns the first node with a key equivalent to k and the node
+// following the last one. Both are nil if there is no such element.
+func (h *hashtable[K, V]) equalRange(k K) (first *hashNode[K, V], fb int, last *hashNode[K, V], lb int) {
+	first, fb = h.find(k)
+	if first == nil {
+		return nil, 0, nil, 0
+	}
+	last, lb = first, fb
+	for last != nil && lb == fb && last.hash == first.hash && h.equal(last.key, k) {
+		last, lb = h.advance(last, lb)
+	}
+	return first, fb, last, lb
+}
+
+func (h *hashtable[K, V]) count(k K) int {
+	n := 0
+	first, fb, last, _ := h.equalRange(k)
+	for ; first != last; first, fb = h.advance(first, fb) {
+		n++
+	}
+	return n
+}
+
+func (h *hashtable[K, V]) eraseKey(k K) int {
+	n := 0
+	first, fb, last, _ := h.equalRange(k)
+	for first != last {
+		first, fb = h.unlink(first, fb)
+		n++
+	}
+	return n
+}
+
+func (h *hashtable[K, V]) clear() {
+	clear(h.buckets)
+	h.size = 0
+}
+
+func (h *hashtable[K, V]) bucketSize(b int) int {
+	n := 0
+	for p := h.buckets[b]; p != nil; p = p.next {
+		n++
+	}
+	return n
+}
+
+func (h *hashtable[K, V]) loadFactor() float64 {
+	return float64(h.size) / float64(len(h.buckets))
+}
+
+func (h *hashtable[K, V]) setMaxLoadFactor(method string, ml float64) {
+	if !(ml > 0) || math.IsInf(ml, 1) {
+		panic(fmt.Sprintf("container: %s.SetMaxLoadFactor: invalid max load factor %v", method, ml))
+	}
+	h.maxLoadFactor = ml
+	if h.size > int(float64(len(h.buckets))*ml) {
+		h.rehash(0)
+	}
+}
+
+func (h *hashtable[K, V]) reserve(count int) {
+	h.rehash(h.minBuckets(count))
+}
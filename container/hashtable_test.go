@@ -0,0 +1,24 @@
+package container
+
+import "testing"
+
+func TestInsertHashesOnce(t *testing.T) {
+	calls := 0
+	hash := func(k int) uint64 {
+		calls++
+		return HashInteger(k)
+	}
+	s := NewUnorderedSet(hash, func(a, b int) bool { return a == b })
+	// The table grows on many of these insertions; each key is still hashed
+	// only once.
+	for k := 0; k < 100; k++ {
+		calls = 0
+		if _, ok := s.Insert(k); !ok || calls != 1 {
+			t.Fatalf("Insert(%d) = %v and hashed the key %d times, want once", k, ok, calls)
+		}
+	}
+	calls = 0
+	if _, ok := s.Insert(42); ok || calls != 1 {
+		t.Fatalf("Insert of an existing key = %v and hashed it %d times, want once", ok, calls)
+	}
+}
@@ -0,0 +1,464 @@
+package container
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// MapIterator points to an element of an OrderedMap or OrderedMultimap, or
+// past the last one. Iterators stay valid until the element they point to is
+// erased or extracted, including across Merge. Iterators are comparable with
+// ==.
+type MapIterator[K, V any] struct {
+	n *rbNode[K, V]
+}
+
+// Returns the key of the element the iterator points to.
+func (it MapIterator[K, V]) Key() K {
+	return it.n.key
+}
+
+// Returns the mapped value of the element the iterator points to.
+func (it MapIterator[K, V]) Value() V {
+	return it.n.value
+}
+
+// Replaces the mapped value of the element the iterator points to.
+func (it MapIterator[K, V]) SetValue(v V) {
+	it.n.value = v
+}
+
+// Returns an iterator to the next element.
+func (it MapIterator[K, V]) Next() MapIterator[K, V] {
+	return MapIterator[K, V]{it.n.next()}
+}
+
+// Returns an iterator to the previous element.
+func (it MapIterator[K, V]) Prev() MapIterator[K, V] {
+	return MapIterator[K, V]{it.n.prev()}
+}
+
+// MapNode is a node handle: an element extracted from an OrderedMap or
+// OrderedMultimap that owns its key and value and can be inserted into another
+// map without copying. The zero MapNode is empty.
+type MapNode[K, V any] struct {
+	n *rbNode[K, V]
+}
+
+// Checks whether the node handle is empty.
+func (nh MapNode[K, V]) Empty() bool {
+	return nh.n == nil
+}
+
+// Returns the key stored in the node handle.
+func (nh MapNode[K, V]) Key() K {
+	return nh.n.key
+}
+
+// Replaces the key stored in the node handle.
+func (nh MapNode[K, V]) SetKey(k K) {
+	nh.n.key = k
+}
+
+// Returns the mapped value stored in the node handle.
+func (nh MapNode[K, V]) Value() V {
+	return nh.n.value
+}
+
+// Replaces the mapped value stored in the node handle.
+func (nh MapNode[K, V]) SetValue(v V) {
+	nh.n.value = v
+}
+
+// MapInsertReturn is the result of inserting a node handle into an
+// OrderedMap. If the insertion failed because the key already exists,
+// Position points to the existing element and Node still owns the node.
+type MapInsertReturn[K, V any] struct {
+	Position MapIterator[K, V]
+	Inserted bool
+	Node     MapNode[K, V]
+}
+
+// OrderedMap is a sorted associative container that contains key-value pairs
+// with unique keys, like std::map. Keys are sorted by the comparison function
+// comp. The map is implemented as a red-black tree, so search, removal and
+// insertion have logarithmic complexity. The zero OrderedMap has no comparison
+// function and is not usable; construct maps with NewOrderedMap or
+// NewOrderedMapFunc.
+type OrderedMap[K, V any] struct {
+	t rbTree[K, V]
+}
+
+// Constructs an empty map ordered by operator<.
+func NewOrderedMap[K cmp.Ordered, V any]() *OrderedMap[K, V] {
+	return NewOrderedMapFunc[K, V](cmp.Less[K])
+}
+
+// Constructs an empty map ordered by the strict weak ordering comp.
+func NewOrderedMapFunc[K, V any](comp func(K, K) bool) *OrderedMap[K, V] {
+	m := new(OrderedMap[K, V])
+	m.t.init(comp)
+	return m
+}
+
+// Returns the function that compares keys.
+func (m *OrderedMap[K, V]) KeyComp() func(K, K) bool {
+	return m.t.comp
+}
+
+// Returns an iterator to the first element of the map.
+func (m *OrderedMap[K, V]) Begin() MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.begin()}
+}
+
+// Returns an iterator to the element following the last element of the map.
+func (m *OrderedMap[K, V]) End() MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.end()}
+}
+
+// Checks if the map has no elements.
+func (m *OrderedMap[K, V]) Empty() bool {
+	return m.t.size == 0
+}
+
+// Returns the number of elements in the map.
+func (m *OrderedMap[K, V]) Size() int {
+	return m.t.size
+}
+
+// Erases all elements from the map.
+func (m *OrderedMap[K, V]) Clear() {
+	m.t.reset()
+}
+
+// Returns the value mapped to key k. Panics if there is no such element.
+func (m *OrderedMap[K, V]) At(k K) V {
+	n := m.t.find(k)
+	if n == m.t.end() {
+		panic(fmt.Sprintf("container: OrderedMap.At: key %v not found", k))
+	}
+	return n.value
+}
+
+// Returns the value mapped to key k and whether such an element exists.
+func (m *OrderedMap[K, V]) Get(k K) (V, bool) {
+	n := m.t.find(k)
+	if n == m.t.end() {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Inserts the element (k, v) if the map doesn't already contain an element
+// with an equivalent key. Returns an iterator to the element with key k and
+// whether the insertion took place.
+func (m *OrderedMap[K, V]) Insert(k K, v V) (MapIterator[K, V], bool) {
+	n, ok := m.t.insertUnique(&rbNode[K, V]{key: k, value: v})
+	return MapIterator[K, V]{n}, ok
+}
+
+// Inserts the elements of keys[first, last) and values[first, last). If
+// multiple keys in the range are equivalent, only the first one is inserted.
+func (m *OrderedMap[K, V]) InsertRange(keys []K, values []V, first, last int) {
+	for ; first != last; first++ {
+		m.t.insertHintUnique(m.t.end(), &rbNode[K, V]{key: keys[first], value: values[first]})
+	}
+}
+
+// Inserts the element (k, v) if the key does not exist, otherwise assigns v to
+// the element with key k. Returns an iterator to the element and whether an
+// insertion took place.
+func (m *OrderedMap[K, V]) InsertOrAssign(k K, v V) (MapIterator[K, V], bool) {
+	n := m.t.lowerBound(k)
+	if n != m.t.end() && !m.t.comp(k, n.key) {
+		n.value = v
+		return MapIterator[K, V]{n}, false
+	}
+	n, _ = m.t.insertHintUnique(n, &rbNode[K, V]{key: k, value: v})
+	return MapIterator[K, V]{n}, true
+}
+
+// Inserts the element (k, v) as close as possible to the position just before
+// hint, unless an element with an equivalent key exists. Takes amortized
+// constant time if the element belongs immediately before hint. Returns an
+// iterator to the element with key k.
+func (m *OrderedMap[K, V]) EmplaceHint(hint MapIterator[K, V], k K, v V) MapIterator[K, V] {
+	n, _ := m.t.insertHintUnique(hint.n, &rbNode[K, V]{key: k, value: v})
+	return MapIterator[K, V]{n}
+}
+
+// Inserts the element owned by nh if the map doesn't already contain an
+// element with an equivalent key. An empty nh is not inserted.
+func (m *OrderedMap[K, V]) InsertNode(nh MapNode[K, V]) MapInsertReturn[K, V] {
+	if nh.Empty() {
+		return MapInsertReturn[K, V]{Position: m.End()}
+	}
+	n, ok := m.t.insertUnique(nh.n)
+	if !ok {
+		return MapInsertReturn[K, V]{Position: MapIterator[K, V]{n}, Node: nh}
+	}
+	return MapInsertReturn[K, V]{Position: MapIterator[K, V]{n}, Inserted: true}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (m *OrderedMap[K, V]) Erase(pos MapIterator[K, V]) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.unlink(pos.n)}
+}
+
+// Removes the elements in the range [first, last) and returns last.
+func (m *OrderedMap[K, V]) EraseRange(first, last MapIterator[K, V]) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.eraseRange(first.n, last.n)}
+}
+
+// Removes the element with key equivalent to k, if any, and returns the
+// number of elements removed.
+func (m *OrderedMap[K, V]) EraseKey(k K) int {
+	return m.t.eraseKey(k)
+}
+
+// Unlinks the element at pos and returns a node handle that owns it.
+func (m *OrderedMap[K, V]) Extract(pos MapIterator[K, V]) MapNode[K, V] {
+	m.t.unlink(pos.n)
+	return MapNode[K, V]{pos.n}
+}
+
+// Unlinks the element with key equivalent to k and returns a node handle that
+// owns it, or an empty node handle if there is no such element.
+func (m *OrderedMap[K, V]) ExtractKey(k K) MapNode[K, V] {
+	n := m.t.find(k)
+	if n == m.t.end() {
+		return MapNode[K, V]{}
+	}
+	return m.Extract(MapIterator[K, V]{n})
+}
+
+// Moves every element of source whose key is not present in m into m. No
+// elements are copied; iterators to moved elements remain valid and now refer
+// into m.
+func (m *OrderedMap[K, V]) Merge(source *OrderedMap[K, V]) {
+	m.t.mergeUnique(&source.t)
+}
+
+// Like Merge, but takes elements from an OrderedMultimap. Of several source
+// elements with equivalent keys, at most one is moved.
+func (m *OrderedMap[K, V]) MergeMulti(source *OrderedMultimap[K, V]) {
+	m.t.mergeUnique(&source.t)
+}
+
+// Exchanges the contents of the map with those of other.
+func (m *OrderedMap[K, V]) Swap(other *OrderedMap[K, V]) {
+	m.t.swap(&other.t)
+}
+
+// Returns the number of elements with key equivalent to k, which is either 1
+// or 0.
+func (m *OrderedMap[K, V]) Count(k K) int {
+	if m.Contains(k) {
+		return 1
+	}
+	return 0
+}
+
+// Finds an element with key equivalent to k. Returns End() if there is no such
+// element.
+func (m *OrderedMap[K, V]) Find(k K) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.find(k)}
+}
+
+// Checks if there is an element with key equivalent to k in the map.
+func (m *OrderedMap[K, V]) Contains(k K) bool {
+	return m.t.find(k) != m.t.end()
+}
+
+// Returns a range containing all elements with key equivalent to k. The range
+// is defined by two iterators, the first pointing to the first element that is
+// not less than k and the second pointing to the first element greater than k.
+func (m *OrderedMap[K, V]) EqualRange(k K) (MapIterator[K, V], MapIterator[K, V]) {
+	first, last := m.t.equalRange(k)
+	return MapIterator[K, V]{first}, MapIterator[K, V]{last}
+}
+
+// Returns an iterator pointing to the first element that is not less than k.
+func (m *OrderedMap[K, V]) LowerBound(k K) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.lowerBound(k)}
+}
+
+// Returns an iterator pointing to the first element that is greater than k.
+func (m *OrderedMap[K, V]) UpperBound(k K) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.upperBound(k)}
+}
+
+// Returns the keys of the map in ascending order. The result is sorted by
+// KeyComp() and can be passed directly to the sorted-range algorithms.
+func (m *OrderedMap[K, V]) Keys() []K {
+	return m.t.keys()
+}
+
+// OrderedMultimap is a sorted associative container that contains key-value
+// pairs and permits multiple entries with the same key, like std::multimap.
+// Elements with equivalent keys keep their insertion order. The zero
+// OrderedMultimap is not usable; construct multimaps with NewOrderedMultimap or
+// NewOrderedMultimapFunc.
+type OrderedMultimap[K, V any] struct {
+	t rbTree[K, V]
+}
+
+// Constructs an empty multimap ordered by operator<.
+func NewOrderedMultimap[K cmp.Ordered, V any]() *OrderedMultimap[K, V] {
+	return NewOrderedMultimapFunc[K, V](cmp.Less[K])
+}
+
+// Constructs an empty multimap ordered by the strict weak ordering comp.
+func NewOrderedMultimapFunc[K, V any](comp func(K, K) bool) *OrderedMultimap[K, V] {
+	m := new(OrderedMultimap[K, V])
+	m.t.init(comp)
+	return m
+}
+
+// Returns the function that compares keys.
+func (m *OrderedMultimap[K, V]) KeyComp() func(K, K) bool {
+	return m.t.comp
+}
+
+// Returns an iterator to the first element of the multimap.
+func (m *OrderedMultimap[K, V]) Begin() MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.begin()}
+}
+
+// Returns an iterator to the element following the last element of the
+// multimap.
+func (m *OrderedMultimap[K, V]) End() MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.end()}
+}
+
+// Checks if the multimap has no elements.
+func (m *OrderedMultimap[K, V]) Empty() bool {
+	return m.t.size == 0
+}
+
+// Returns the number of elements in the multimap.
+func (m *OrderedMultimap[K, V]) Size() int {
+	return m.t.size
+}
+
+// Erases all elements from the multimap.
+func (m *OrderedMultimap[K, V]) Clear() {
+	m.t.reset()
+}
+
+// Inserts the element (k, v) after any elements with equivalent keys and
+// returns an iterator to it.
+func (m *OrderedMultimap[K, V]) Insert(k K, v V) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.insertEqual(&rbNode[K, V]{key: k, value: v})}
+}
+
+// Inserts the elements of keys[first, last) and values[first, last).
+func (m *OrderedMultimap[K, V]) InsertRange(keys []K, values []V, first, last int) {
+	for ; first != last; first++ {
+		m.t.insertHintEqual(m.t.end(), &rbNode[K, V]{key: keys[first], value: values[first]})
+	}
+}
+
+// Inserts the element (k, v) as close as possible to the position just before
+// hint. Takes amortized constant time if the element belongs immediately
+// before hint. Returns an iterator to the inserted element.
+func (m *OrderedMultimap[K, V]) EmplaceHint(hint MapIterator[K, V], k K, v V) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.insertHintEqual(hint.n, &rbNode[K, V]{key: k, value: v})}
+}
+
+// Inserts the element owned by nh and returns an iterator to it. If nh is
+// empty, returns End().
+func (m *OrderedMultimap[K, V]) InsertNode(nh MapNode[K, V]) MapIterator[K, V] {
+	if nh.Empty() {
+		return m.End()
+	}
+	return MapIterator[K, V]{m.t.insertEqual(nh.n)}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (m *OrderedMultimap[K, V]) Erase(pos MapIterator[K, V]) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.unlink(pos.n)}
+}
+
+// Removes the elements in the range [first, last) and returns last.
+func (m *OrderedMultimap[K, V]) EraseRange(first, last MapIterator[K, V]) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.eraseRange(first.n, last.n)}
+}
+
+// Removes all elements with key equivalent to k and returns the number of
+// elements removed.
+func (m *OrderedMultimap[K, V]) EraseKey(k K) int {
+	return m.t.eraseKey(k)
+}
+
+// Unlinks the element at pos and returns a node handle that owns it.
+func (m *OrderedMultimap[K, V]) Extract(pos MapIterator[K, V]) MapNode[K, V] {
+	m.t.unlink(pos.n)
+	return MapNode[K, V]{pos.n}
+}
+
+// Unlinks the first element with key equivalent to k and returns a node
+// handle that owns it, or an empty node handle if there is no such element.
+func (m *OrderedMultimap[K, V]) ExtractKey(k K) MapNode[K, V] {
+	n := m.t.find(k)
+	if n == m.t.end() {
+		return MapNode[K, V]{}
+	}
+	return m.Extract(MapIterator[K, V]{n})
+}
+
+// Moves every element of source into m. No elements are copied; iterators to
+// moved elements remain valid and now refer into m.
+func (m *OrderedMultimap[K, V]) Merge(source *OrderedMultimap[K, V]) {
+	m.t.mergeEqual(&source.t)
+}
+
+// Like Merge, but takes elements from an OrderedMap.
+func (m *OrderedMultimap[K, V]) MergeUnique(source *OrderedMap[K, V]) {
+	m.t.mergeEqual(&source.t)
+}
+
+// Exchanges the contents of the multimap with those of other.
+func (m *OrderedMultimap[K, V]) Swap(other *OrderedMultimap[K, V]) {
+	m.t.swap(&other.t)
+}
+
+// Returns the number of elements with key equivalent to k.
+func (m *OrderedMultimap[K, V]) Count(k K) int {
+	return m.t.count(k)
+}
+
+// Finds an element with key equivalent to k. If there are several, the first
+// one is returned. Returns End() if there is no such element.
+func (m *OrderedMultimap[K, V]) Find(k K) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.find(k)}
+}
+
+// Checks if there is an element with key equivalent to k in the multimap.
+func (m *OrderedMultimap[K, V]) Contains(k K) bool {
+	return m.t.find(k) != m.t.end()
+}
+
+// Returns a range containing all elements with key equivalent to k. The range
+// is defined by two iterators, the first pointing to the first element that is
+// not less than k and the second pointing to the first element greater than k.
+func (m *OrderedMultimap[K, V]) EqualRange(k K) (MapIterator[K, V], MapIterator[K, V]) {
+	first, last := m.t.equalRange(k)
+	return MapIterator[K, V]{first}, MapIterator[K, V]{last}
+}
+
+// Returns an iterator pointing to the first element that is not less than k.
+func (m *OrderedMultimap[K, V]) LowerBound(k K) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.lowerBound(k)}
+}
+
+// Returns an iterator pointing to the first element that is greater than k.
+func (m *OrderedMultimap[K, V]) UpperBound(k K) MapIterator[K, V] {
+	return MapIterator[K, V]{m.t.upperBound(k)}
+}
+
+// Returns the keys of the multimap in ascending order, including duplicates.
+func (m *OrderedMultimap[K, V]) Keys() []K {
+	return m.t.keys()
+}
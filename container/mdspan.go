@@ -0,0 +1,322 @@
+package container
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Extents is the shape of a multidimensional index space: the number of
+// indices in each dimension, like std::dextents. The rank of the space is the
+// number of dimensions.
+type Extents []int
+
+// Returns the number of dimensions.
+func (e Extents) Rank() int {
+	return len(e)
+}
+
+// Returns the number of indices in dimension r.
+func (e Extents) Extent(r int) int {
+	return e[r]
+}
+
+// Returns the number of multi-indices in the space, the product of the
+// extents. The space of rank 0 has one, the empty multi-index.
+func (e Extents) Size() int {
+	n := 1
+	for _, x := range e {
+		n *= x
+	}
+	return n
+}
+
+func (e Extents) check(method string) Extents {
+	for r, x := range e {
+		if x < 0 {
+			panic(fmt.Sprintf("container: %s: negative extent %d in dimension %d", method, x, r))
+		}
+	}
+	return slices.Clone(e)
+}
+
+// checkIndex panics if idx is not a multi-index of the space.
+func (e Extents) checkIndex(method string, idx []int) {
+	if len(idx) != len(e) {
+		panic(fmt.Sprintf("container: %s: %d indices for rank %d", method, len(idx), len(e)))
+	}
+	for r, i := range idx {
+		if i < 0 || i >= e[r] {
+			panic(fmt.Sprintf("container: %s: index %d out of range [0, %d) in dimension %d", method, i, e[r], r))
+		}
+	}
+}
+
+// LayoutMapping maps the multi-indices of an index space to offsets in a
+// sequence of elements, like the mappings of the layout policies of
+// std::mdspan. LayoutRight, LayoutLeft and LayoutStride return the mappings of
+// the standard policies; other types implementing the interface define custom
+// layouts.
+type LayoutMapping interface {
+	// Returns the index space of the mapping.
+	Extents() Extents
+	// Returns the offset of the element at the multi-index idx, which must
+	// be within the index space.
+	Offset(idx ...int) int
+	// Returns the length that a sequence needs to hold the elements of all
+	// multi-indices: 1 plus the largest offset, or 0 if the space is empty.
+	RequiredSpanSize() int
+	// Checks if distinct multi-indices map to distinct offsets.
+	IsUnique() bool
+	// Checks if every offset in [0, RequiredSpanSize()) is mapped to.
+	IsExhaustive() bool
+	// Checks if the offset is a linear function of the indices, with the
+	// coefficients given by Stride.
+	IsStrided() bool
+	// Returns the distance between the offsets of elements whose indices
+	// differ by one in dimension r only. Only meaningful if IsStrided.
+	Stride(r int) int
+}
+
+// StridedMapping is a mapping of an index space with a stride per dimension:
+// the offset of a multi-index is the sum of each index times the stride of
+// its dimension. It is the mapping of the layout_right, layout_left and
+// layout_stride policies.
+type StridedMapping struct {
+	extents Extents
+	strides []int
+}
+
+// Returns the mapping of the layout_right policy: the row-major layout, as in
+// C, where the last index varies fastest and the elements of consecutive
+// multi-indices are adjacent. Panics if an extent is negative.
+func LayoutRight(extents ...int) StridedMapping {
+	e := Extents(extents).check("LayoutRight")
+	strides := make([]int, len(e))
+	stride := 1
+	for r := len(e) - 1; r >= 0; r-- {
+		strides[r] = stride
+		stride *= e[r]
+	}
+	return StridedMapping{e, strides}
+}
+
+// Returns the mapping of the layout_left policy: the column-major layout, as
+// in Fortran, where the first index varies fastest. Panics if an extent is
+// negative.
+func LayoutLeft(extents ...int) StridedMapping {
+	e := Extents(extents).check("LayoutLeft")
+	strides := make([]int, len(e))
+	stride := 1
+	for r := range e {
+		strides[r] = stride
+		stride *= e[r]
+	}
+	return StridedMapping{e, strides}
+}
+
+// Returns the mapping of the layout_stride policy with the given extents and
+// strides, one per dimension. Panics if they differ in length, or if an
+// extent is negative or a stride is not positive.
+func LayoutStride(extents Extents, strides []int) StridedMapping {
+	e := extents.check("LayoutStride")
+	if len(strides) != len(e) {
+		panic(fmt.Sprintf("container: LayoutStride: %d strides for rank %d", len(strides), len(e)))
+	}
+	for r, s := range strides {
+		if s <= 0 {
+			panic(fmt.Sprintf("container: LayoutStride: stride %d in dimension %d is not positive", s, r))
+		}
+	}
+	return StridedMapping{e, slices.Clone(strides)}
+}
+
+// Returns the index space of the mapping.
+func (m StridedMapping) Extents() Extents {
+	return slices.Clone(m.extents)
+}
+
+// Returns the offset of the element at the multi-index idx. Panics if idx is
+// not within the index space.
+func (m StridedMapping) Offset(idx ...int) int {
+	m.extents.checkIndex("StridedMapping.Offset", idx)
+	return m.offset(idx)
+}
+
+func (m StridedMapping) offset(idx []int) int {
+	off := 0
+	for r, i := range idx {
+		off += i * m.strides[r]
+	}
+	return off
+}
+
+// Returns the length that a sequence needs to hold the elements of all
+// multi-indices.
+func (m StridedMapping) RequiredSpanSize() int {
+	size := 1
+	for r, x := range m.extents {
+		if x == 0 {
+			return 0
+		}
+		size += (x - 1) * m.strides[r]
+	}
+	return size
+}
+
+// Checks if distinct multi-indices map to distinct offsets. This is always
+// true for layout_right and layout_left; for layout_stride, it is a
+// precondition that is assumed, not checked, as by std::layout_stride.
+func (m StridedMapping) IsUnique() bool {
+	return true
+}
+
+// Checks if every offset in [0, RequiredSpanSize()) is mapped to, which is the
+// case for layout_right and layout_left, and for layout_stride with the
+// strides of either.
+func (m StridedMapping) IsExhaustive() bool {
+	return m.RequiredSpanSize() == m.extents.Size()
+}
+
+// Checks if the offset is a linear function of the indices, which is always
+// true.
+func (m StridedMapping) IsStrided() bool {
+	return true
+}
+
+// Returns the stride of dimension r.
+func (m StridedMapping) Stride(r int) int {
+	return m.strides[r]
+}
+
+// MdSpan is a non-owning multidimensional view of a sequence of elements, like
+// std::mdspan. A LayoutMapping maps each multi-index of its index space to the
+// offset of its element in the sequence. The zero MdSpan has rank 0 and no
+// elements; it must not be accessed.
+//
+// With a strided mapping, such as those of the standard layout policies,
+// access does not go through the LayoutMapping interface. MdSpan panics, like
+// the other containers, if a multi-index is out of range.
+type MdSpan[T any] struct {
+	data    []T
+	mapping LayoutMapping
+	extents Extents
+	strides []int // nil unless the mapping is strided
+}
+
+// Constructs an MdSpan over data with the given extents and the layout_right
+// policy, the default of std::mdspan. Panics if an extent is negative or data
+// is too short.
+func MakeMdSpan[T any](data []T, extents ...int) MdSpan[T] {
+	return MakeMdSpanWith(data, LayoutRight(extents...))
+}
+
+// Constructs an MdSpan over data with the given mapping. Panics if data is
+// shorter than the mapping's RequiredSpanSize.
+func MakeMdSpanWith[T any](data []T, m LayoutMapping) MdSpan[T] {
+	if n := m.RequiredSpanSize(); len(data) < n {
+		panic(fmt.Sprintf("container: MakeMdSpanWith: the mapping requires %d elements, but data has %d", n, len(data)))
+	}
+	s := MdSpan[T]{data: data, mapping: m, extents: m.Extents()}
+	if m.IsStrided() {
+		s.strides = make([]int, len(s.extents))
+		for r := range s.strides {
+			s.strides[r] = m.Stride(r)
+		}
+	}
+	return s
+}
+
+// Returns the number of dimensions.
+func (s MdSpan[T]) Rank() int {
+	return len(s.extents)
+}
+
+// Returns the number of indices in dimension r.
+func (s MdSpan[T]) Extent(r int) int {
+	return s.extents[r]
+}
+
+// Returns the index space.
+func (s MdSpan[T]) Extents() Extents {
+	return slices.Clone(s.extents)
+}
+
+// Returns the number of elements, the product of the extents.
+func (s MdSpan[T]) Size() int {
+	return s.extents.Size()
+}
+
+// Checks if the index space has no multi-indices.
+func (s MdSpan[T]) Empty() bool {
+	return s.Size() == 0
+}
+
+// Returns the mapping.
+func (s MdSpan[T]) Mapping() LayoutMapping {
+	return s.mapping
+}
+
+// Returns the stride of dimension r.
+func (s MdSpan[T]) Stride(r int) int {
+	return s.mapping.Stride(r)
+}
+
+// Checks if distinct multi-indices refer to distinct elements.
+func (s MdSpan[T]) IsUnique() bool {
+	return s.mapping.IsUnique()
+}
+
+// Checks if every element of Data is referred to by a multi-index.
+func (s MdSpan[T]) IsExhaustive() bool {
+	return s.mapping.IsExhaustive()
+}
+
+// Checks if the mapping is strided.
+func (s MdSpan[T]) IsStrided() bool {
+	return s.mapping.IsStrided()
+}
+
+// Returns the viewed sequence, truncated to the mapping's RequiredSpanSize.
+// With an exhaustive mapping, it can be passed to the index-range algorithms
+// to process all elements in the order of their offsets.
+func (s MdSpan[T]) Data() []T {
+	n := s.mapping.RequiredSpanSize()
+	return s.data[:n:n]
+}
+
+// Returns the offset in Data of the element at the multi-index idx. Panics if
+// idx is not within the index space.
+func (s MdSpan[T]) Offset(idx ...int) int {
+	return s.offset("Offset", idx)
+}
+
+func (s MdSpan[T]) offset(method string, idx []int) int {
+	s.extents.checkIndex("MdSpan."+method, idx)
+	if s.strides == nil {
+		return s.mapping.Offset(idx...)
+	}
+	off := 0
+	for r, i := range idx {
+		off += i * s.strides[r]
+	}
+	return off
+}
+
+// Returns the element at the multi-index idx. Panics if idx is not within the
+// index space.
+func (s MdSpan[T]) At(idx ...int) T {
+	return s.data[s.offset("At", idx)]
+}
+
+// Replaces the element at the multi-index idx with v. Panics if idx is not
+// within the index space.
+func (s MdSpan[T]) Set(v T, idx ...int) {
+	s.data[s.offset("Set", idx)] = v
+}
+
+// Returns a pointer to the element at the multi-index idx, for updating it in
+// place, as in *s.Ptr(i, j) += x. Panics if idx is not within the index
+// space.
+func (s MdSpan[T]) Ptr(idx ...int) *T {
+	return &s.data[s.offset("Ptr", idx)]
+}
@@ -0,0 +1,129 @@
+package container
+
+import (
+	"slices"
+	"testing"
+)
+
+// forEachIndex calls f with every multi-index of e, in row-major order.
+func forEachIndex(e Extents, f func(idx []int)) {
+	if e.Size() == 0 {
+		return
+	}
+	idx := make([]int, len(e))
+	for {
+		f(idx)
+		r := len(e) - 1
+		for ; r >= 0; r-- {
+			if idx[r]++; idx[r] < e[r] {
+				break
+			}
+			idx[r] = 0
+		}
+		if r < 0 {
+			return
+		}
+	}
+}
+
+func TestLayoutMappings(t *testing.T) {
+	tests := []struct {
+		name       string
+		m          StridedMapping
+		offset     func(idx []int) int
+		required   int
+		exhaustive bool
+	}{
+		{"LayoutRight", LayoutRight(2, 3, 4), func(idx []int) int { return 12*idx[0] + 4*idx[1] + idx[2] }, 24, true},
+		{"LayoutLeft", LayoutLeft(2, 3, 4), func(idx []int) int { return idx[0] + 2*idx[1] + 6*idx[2] }, 24, true},
+		{"LayoutStride of LayoutLeft", LayoutStride(Extents{2, 3}, []int{1, 2}), func(idx []int) int { return idx[0] + 2*idx[1] }, 6, true},
+		{"LayoutStride with gaps", LayoutStride(Extents{2, 3}, []int{1, 4}), func(idx []int) int { return idx[0] + 4*idx[1] }, 10, false},
+		{"LayoutStride of a column", LayoutStride(Extents{3}, []int{5}), func(idx []int) int { return 5 * idx[0] }, 11, false},
+		// The space of rank 0 has a single element, and a space with a zero
+		// extent has none.
+		{"rank 0", LayoutRight(), func([]int) int { return 0 }, 1, true},
+		{"LayoutRight zero extent", LayoutRight(3, 0), nil, 0, true},
+		{"LayoutLeft zero extent", LayoutLeft(0, 3), nil, 0, true},
+		{"LayoutStride zero extent", LayoutStride(Extents{0, 5}, []int{7, 1}), nil, 0, true},
+	}
+	for _, tt := range tests {
+		forEachIndex(tt.m.Extents(), func(idx []int) {
+			if got, want := tt.m.Offset(idx...), tt.offset(idx); got != want {
+				t.Errorf("%s: Offset(%v) = %d, want %d", tt.name, idx, got, want)
+			}
+		})
+		if tt.m.RequiredSpanSize() != tt.required || tt.m.IsExhaustive() != tt.exhaustive {
+			t.Errorf("%s: RequiredSpanSize, IsExhaustive = %d, %v, want %d, %v", tt.name, tt.m.RequiredSpanSize(), tt.m.IsExhaustive(), tt.required, tt.exhaustive)
+		}
+		if !tt.m.IsUnique() || !tt.m.IsStrided() {
+			t.Errorf("%s is not unique and strided", tt.name)
+		}
+	}
+
+	m := LayoutRight(2, 3)
+	if m.Stride(0) != 3 || m.Stride(1) != 1 || m.Extents().Rank() != 2 || m.Extents().Extent(1) != 3 {
+		t.Errorf("LayoutRight(2, 3): strides %d, %d", m.Stride(0), m.Stride(1))
+	}
+	mustPanic(t, "Offset out of range", "index 3 out of range [0, 3) in dimension 1", func() { m.Offset(1, 3) })
+	mustPanic(t, "Offset negative", "index -1 out of range [0, 2) in dimension 0", func() { m.Offset(-1, 0) })
+	mustPanic(t, "Offset of the wrong rank", "3 indices for rank 2", func() { m.Offset(0, 0, 0) })
+	mustPanic(t, "LayoutRight negative extent", "LayoutRight: negative extent -2 in dimension 1", func() { LayoutRight(1, -2) })
+	mustPanic(t, "LayoutLeft negative extent", "LayoutLeft: negative extent -1 in dimension 0", func() { LayoutLeft(-1) })
+	mustPanic(t, "LayoutStride rank", "1 strides for rank 2", func() { LayoutStride(Extents{1, 2}, []int{1}) })
+	mustPanic(t, "LayoutStride zero stride", "stride 0 in dimension 1 is not positive", func() { LayoutStride(Extents{1, 2}, []int{1, 0}) })
+}
+
+// reversed is a mapping of rank 1 that is not strided in the sense of
+// IsStrided, so that MdSpan goes through Offset.
+type reversed int
+
+func (m reversed) Extents() Extents      { return Extents{int(m)} }
+func (m reversed) RequiredSpanSize() int { return int(m) }
+func (m reversed) IsUnique() bool        { return true }
+func (m reversed) IsExhaustive() bool    { return true }
+func (m reversed) IsStrided() bool       { return false }
+func (m reversed) Stride(r int) int      { return -1 }
+func (m reversed) Offset(idx ...int) int { return int(m) - 1 - idx[0] }
+
+func TestMdSpan(t *testing.T) {
+	data := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	s := MakeMdSpan(data, 2, 3)
+	if s.Rank() != 2 || s.Extent(0) != 2 || s.Size() != 6 || s.Empty() || !s.IsExhaustive() {
+		t.Errorf("MakeMdSpan(data, 2, 3): rank %d, size %d", s.Rank(), s.Size())
+	}
+	// Data is truncated to the elements the mapping refers to.
+	if !slices.Equal(s.Data(), data[:6]) || cap(s.Data()) != 6 {
+		t.Errorf("Data() = %v", s.Data())
+	}
+	if s.At(1, 2) != 5 || s.Offset(1, 0) != 3 || s.Stride(0) != 3 {
+		t.Errorf("At(1, 2), Offset(1, 0) = %d, %d", s.At(1, 2), s.Offset(1, 0))
+	}
+	s.Set(-1, 0, 1)
+	*s.Ptr(1, 1) += 10
+	if data[1] != -1 || data[4] != 14 {
+		t.Errorf("Set and Ptr wrote %v", data)
+	}
+
+	cols := MakeMdSpanWith(data, LayoutLeft(2, 3))
+	if cols.At(1, 2) != 5 || cols.At(0, 1) != 2 {
+		t.Errorf("LayoutLeft: At(1, 2), At(0, 1) = %d, %d", cols.At(1, 2), cols.At(0, 1))
+	}
+	col := MakeMdSpanWith(data, LayoutStride(Extents{3}, []int{3}))
+	if col.At(2) != 6 || col.IsExhaustive() || len(col.Data()) != 7 {
+		t.Errorf("a column: At(2) = %d, Data() = %v", col.At(2), col.Data())
+	}
+	rev := MakeMdSpanWith(data, reversed(4))
+	if rev.At(0) != 3 || rev.Offset(3) != 0 || rev.IsStrided() {
+		t.Errorf("reversed: At(0), Offset(3) = %d, %d", rev.At(0), rev.Offset(3))
+	}
+	if e := MakeMdSpan(data, 4, 0); !e.Empty() || len(e.Data()) != 0 {
+		t.Errorf("MakeMdSpan with a zero extent: Size %d, Data() = %v", e.Size(), e.Data())
+	}
+
+	mustPanic(t, "At out of range", "MdSpan.At: index 2 out of range [0, 2) in dimension 0", func() { s.At(2, 0) })
+	mustPanic(t, "Set out of range", "MdSpan.Set: index 3 out of range [0, 3) in dimension 1", func() { s.Set(0, 0, 3) })
+	mustPanic(t, "Ptr of the wrong rank", "MdSpan.Ptr: 1 indices for rank 2", func() { s.Ptr(0) })
+	mustPanic(t, "Offset negative", "MdSpan.Offset: index -1", func() { rev.Offset(-1) })
+	mustPanic(t, "MakeMdSpan too short", "the mapping requires 9 elements, but data has 8", func() { MakeMdSpan(data, 3, 3) })
+	mustPanic(t, "MakeMdSpan negative extent", "negative extent -3", func() { MakeMdSpan(data, -3) })
+}
@@ -0,0 +1,160 @@
+package container
+
+import (
+	"cmp"
+	"gocpp/algorithm"
+)
+
+// QueueContainer is the interface an underlying container must implement to
+// be adapted by Queue: it must support appending at its back and inspecting
+// and removing elements at its front. *Deque satisfies QueueContainer.
+type QueueContainer[T any] interface {
+	Front() T
+	Back() T
+	PushBack(v T)
+	PopFront()
+	Empty() bool
+	Size() int
+}
+
+// Queue is a container adaptor that gives the functionality of a queue, a
+// FIFO (first-in, first-out) data structure, like std::queue. Elements are
+// pushed to the back of the underlying container and popped from its front.
+type Queue[T any] struct {
+	c QueueContainer[T]
+}
+
+// Constructs an empty queue backed by a Deque.
+func NewQueue[T any]() *Queue[T] {
+	return NewQueueWith[T](NewDeque[T]())
+}
+
+// Constructs a queue that adapts c. The elements already in c form the queue,
+// with the front of c as the front of the queue.
+func NewQueueWith[T any](c QueueContainer[T]) *Queue[T] {
+	return &Queue[T]{c}
+}
+
+// Returns the underlying container.
+func (q *Queue[T]) Container() QueueContainer[T] {
+	return q.c
+}
+
+// Returns the first element in the queue, the one that will be popped next.
+func (q *Queue[T]) Front() T {
+	return q.c.Front()
+}
+
+// Returns the last element in the queue, the most recently pushed one.
+func (q *Queue[T]) Back() T {
+	return q.c.Back()
+}
+
+// Checks if the queue has no elements.
+func (q *Queue[T]) Empty() bool {
+	return q.c.Empty()
+}
+
+// Returns the number of elements in the queue.
+func (q *Queue[T]) Size() int {
+	return q.c.Size()
+}
+
+// Pushes v to the back of the queue.
+func (q *Queue[T]) Push(v T) {
+	q.c.PushBack(v)
+}
+
+// Removes the element at the front of the queue.
+func (q *Queue[T]) Pop() {
+	q.c.PopFront()
+}
+
+// Exchanges the contents of the queue with those of other.
+func (q *Queue[T]) Swap(other *Queue[T]) {
+	q.c, other.c = other.c, q.c
+}
+
+// PriorityQueue is a container adaptor that provides constant time lookup of
+// the largest element, at the expense of logarithmic insertion and
+// extraction, like std::priority_queue. The elements are kept in a slice
+// arranged by PushHeapFunc and PopHeapFunc, so the order in which equivalent
+// elements leave the queue is the same as with the C++ standard library. A
+// comparator such as functional.Greater makes the smallest element appear
+// as the top.
+type PriorityQueue[T any] struct {
+	c    []T
+	comp func(T, T) bool
+}
+
+// Constructs an empty priority queue ordered by operator<, so that Top is the
+// largest element.
+func NewPriorityQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueueFunc[T](cmp.Less[T])
+}
+
+// Constructs an empty priority queue ordered by the strict weak ordering comp.
+// Top is an element for which comp(Top(), x) is false for every x.
+func NewPriorityQueueFunc[T any](comp func(T, T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{comp: comp}
+}
+
+// Constructs a priority queue ordered by operator< holding the elements of
+// r[first, last). Takes linear time.
+func NewPriorityQueueFrom[T cmp.Ordered](r []T, first, last int) *PriorityQueue[T] {
+	return NewPriorityQueueFromFunc(r, first, last, cmp.Less[T])
+}
+
+// Constructs a priority queue ordered by comp holding the elements of
+// r[first, last). Takes linear time.
+func NewPriorityQueueFromFunc[T any](r []T, first, last int, comp func(T, T) bool) *PriorityQueue[T] {
+	c := make([]T, last-first)
+	copy(c, r[first:last])
+	algorithm.MakeHeapFunc(c, 0, len(c), comp)
+	return &PriorityQueue[T]{c, comp}
+}
+
+// Returns the function that compares elements.
+func (pq *PriorityQueue[T]) ValueComp() func(T, T) bool {
+	return pq.comp
+}
+
+// Returns the top element of the priority queue.
+func (pq *PriorityQueue[T]) Top() T {
+	return pq.c[0]
+}
+
+// Checks if the priority queue has no elements.
+func (pq *PriorityQueue[T]) Empty() bool {
+	return len(pq.c) == 0
+}
+
+// Returns the number of elements in the priority queue.
+func (pq *PriorityQueue[T]) Size() int {
+	return len(pq.c)
+}
+
+// Pushes v into the priority queue.
+func (pq *PriorityQueue[T]) Push(v T) {
+	pq.c = append(pq.c, v)
+	algorithm.PushHeapFunc(pq.c, 0, len(pq.c), pq.comp)
+}
+
+// Pushes the elements of r[first, last) into the priority queue.
+func (pq *PriorityQueue[T]) PushRange(r []T, first, last int) {
+	pq.c = append(pq.c, r[first:last]...)
+	algorithm.MakeHeapFunc(pq.c, 0, len(pq.c), pq.comp)
+}
+
+// Removes the top element from the priority queue.
+func (pq *PriorityQueue[T]) Pop() {
+	algorithm.PopHeapFunc(pq.c, 0, len(pq.c), pq.comp)
+	var zero T
+	pq.c[len(pq.c)-1] = zero
+	pq.c = pq.c[:len(pq.c)-1]
+}
+
+// Exchanges the contents of the priority queue with those of other.
+func (pq *PriorityQueue[T]) Swap(other *PriorityQueue[T]) {
+	*pq, *other = *other, *pq
+}
@@ -0,0 +1,213 @@
+package container
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gocpp/functional"
+)
+
+// mustPanic reports an error unless f panics with a message containing want.
+func mustPanic(t *testing.T, name, want string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		r := recover()
+		if r == nil {
+			t.Errorf("%s did not panic", name)
+		} else if msg, _ := r.(string); !strings.Contains(msg, want) {
+			t.Errorf("%s panicked with %v, want a message containing %q", name, r, want)
+		}
+	}()
+	f()
+}
+
+func drain[T any](pq *PriorityQueue[T]) []T {
+	var ret []T
+	for !pq.Empty() {
+		ret = append(ret, pq.Top())
+		pq.Pop()
+	}
+	return ret
+}
+
+type job struct {
+	priority int
+	name     string
+}
+
+func TestPriorityQueue(t *testing.T) {
+	in := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	tests := []struct {
+		name string
+		pq   *PriorityQueue[int]
+		in   []int
+		want []int
+	}{
+		{"less", NewPriorityQueue[int](), in, []int{9, 6, 5, 4, 3, 2, 1, 1}},
+		{"greater", NewPriorityQueueFunc(functional.Greater[int]), in, []int{1, 1, 2, 3, 4, 5, 6, 9}},
+		{"by last digit", NewPriorityQueueFunc(func(a, b int) bool { return a%10 < b%10 }), []int{13, 21, 4, 35, 42}, []int{35, 4, 13, 42, 21}},
+	}
+	for _, tt := range tests {
+		for _, v := range tt.in {
+			tt.pq.Push(v)
+		}
+		if tt.pq.Size() != len(tt.in) {
+			t.Errorf("%s: Size() = %d, want %d", tt.name, tt.pq.Size(), len(tt.in))
+		}
+		if got := drain(tt.pq); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: popped %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	pq := NewPriorityQueueFromFunc(in, 2, 6, functional.Greater[int])
+	pq.PushRange(in, 0, 2)
+	if got := drain(pq); !slices.Equal(got, []int{1, 1, 3, 4, 5, 9}) {
+		t.Errorf("NewPriorityQueueFromFunc and PushRange popped %v", got)
+	}
+
+	// Equivalent elements all leave the queue, and the ordering only looks at
+	// the priority.
+	jobs := NewPriorityQueueFunc(func(a, b job) bool { return a.priority < b.priority })
+	for _, j := range []job{{1, "low"}, {3, "high"}, {2, "mid"}, {3, "urgent"}} {
+		jobs.Push(j)
+	}
+	var names []string
+	for _, j := range drain(jobs) {
+		names = append(names, j.name)
+	}
+	if names[2] != "mid" || names[3] != "low" || !slices.Contains(names[:2], "high") || !slices.Contains(names[:2], "urgent") {
+		t.Errorf("jobs popped in order %v", names)
+	}
+
+	a, b := NewPriorityQueue[int](), NewPriorityQueueFunc(functional.Greater[int])
+	a.Push(1)
+	a.Swap(b)
+	b.Push(2)
+	if !a.Empty() || b.Top() != 2 {
+		t.Errorf("Swap did not exchange the elements and comparators")
+	}
+
+	empty := NewPriorityQueue[int]()
+	mustPanic(t, "Top of an empty PriorityQueue", "", func() { empty.Top() })
+	mustPanic(t, "Pop of an empty PriorityQueue", "", func() { empty.Pop() })
+}
+
+func TestStack(t *testing.T) {
+	s := NewStack[int]()
+	for i := 0; i < 20; i++ {
+		s.Push(i)
+	}
+	for i := 19; i >= 0; i-- {
+		if s.Top() != i || s.Size() != i+1 {
+			t.Fatalf("Top() = %d with size %d, want %d", s.Top(), s.Size(), i)
+		}
+		s.Pop()
+	}
+	if !s.Empty() {
+		t.Errorf("stack is not empty after popping every element")
+	}
+	mustPanic(t, "Top of an empty Stack", "Deque.At", func() { s.Top() })
+	mustPanic(t, "Pop of an empty Stack", "Deque.PopBack", func() { s.Pop() })
+
+	// The elements of an adapted container form the stack.
+	s = NewStackWith[int](NewDequeFrom([]int{1, 2, 3}, 0, 3))
+	if s.Top() != 3 || s.Size() != 3 {
+		t.Errorf("NewStackWith: Top() = %d, Size() = %d", s.Top(), s.Size())
+	}
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue[int]()
+	for i := 0; i < 20; i++ {
+		q.Push(i)
+		if q.Back() != i {
+			t.Fatalf("Back() = %d after pushing %d", q.Back(), i)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		if q.Front() != i || q.Size() != 20-i {
+			t.Fatalf("Front() = %d with size %d, want %d", q.Front(), q.Size(), i)
+		}
+		q.Pop()
+	}
+	if !q.Empty() {
+		t.Errorf("queue is not empty after popping every element")
+	}
+	mustPanic(t, "Front of an empty Queue", "Deque.At", func() { q.Front() })
+	mustPanic(t, "Back of an empty Queue", "Deque.At", func() { q.Back() })
+	mustPanic(t, "Pop of an empty Queue", "Deque.PopFront", func() { q.Pop() })
+
+	other := NewQueueWith[int](NewDequeFrom([]int{1, 2}, 0, 2))
+	q.Swap(other)
+	if q.Front() != 1 || q.Back() != 2 || !other.Empty() {
+		t.Errorf("Swap did not exchange the containers")
+	}
+}
+
+func TestDeque(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  string // f pushes to the front, b to the back, F and B pop
+		want []int
+	}{
+		{"back only", "bbbbbbbbbbbbbbbbbbbb", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}},
+		{"front only", "ffffffffffffffffffff", []int{19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{"alternating", "fbfbfbfbfbfbfbfbfbfb", []int{18, 16, 14, 12, 10, 8, 6, 4, 2, 0, 1, 3, 5, 7, 9, 11, 13, 15, 17, 19}},
+		// Popping from the front moves the head, so the buffer wraps before
+		// it grows.
+		{"wrapped", "bbbbbbFFFFbbbbbbbbbbbbb", []int{4, 5, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22}},
+		{"wrapped front", "bbbbbbBBBBffffffffffff", []int{21, 20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 0, 1}},
+		{"pop both", "fbfbFB", []int{0, 1}},
+	}
+	for _, tt := range tests {
+		d := NewDeque[int]()
+		for i, op := range tt.ops {
+			switch op {
+			case 'f':
+				d.PushFront(i)
+			case 'b':
+				d.PushBack(i)
+			case 'F':
+				d.PopFront()
+			case 'B':
+				d.PopBack()
+			}
+		}
+		if got := d.Slice(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Slice() = %v, want %v", tt.name, got, tt.want)
+		}
+		for i, v := range tt.want {
+			if d.At(i) != v {
+				t.Errorf("%s: At(%d) = %d, want %d", tt.name, i, d.At(i), v)
+			}
+		}
+		if d.Size() != len(tt.want) || d.Front() != tt.want[0] || d.Back() != tt.want[len(tt.want)-1] {
+			t.Errorf("%s: Size, Front, Back = %d, %d, %d", tt.name, d.Size(), d.Front(), d.Back())
+		}
+	}
+
+	var d Deque[int]
+	for _, pos := range []int{0, 1, 0, 3, 2, 5} {
+		d.Insert(pos, pos*10)
+	}
+	if got := d.Slice(); !slices.Equal(got, []int{0, 0, 20, 10, 30, 50}) {
+		t.Errorf("Insert: Slice() = %v", got)
+	}
+	d.Erase(1)
+	d.Erase(4)
+	if got := d.Slice(); !slices.Equal(got, []int{0, 20, 10, 30}) {
+		t.Errorf("Erase: Slice() = %v", got)
+	}
+	d.Set(3, 7)
+	if d.Back() != 7 {
+		t.Errorf("Set(3, 7): Back() = %d", d.Back())
+	}
+	mustPanic(t, "At out of range", "index 4 out of range [0, 4)", func() { d.At(4) })
+	mustPanic(t, "Insert out of range", "position 5 out of range [0, 4]", func() { d.Insert(5, 0) })
+	d.Clear()
+	mustPanic(t, "PopFront of an empty Deque", "Deque.PopFront", func() { d.PopFront() })
+	mustPanic(t, "PopBack of an empty Deque", "Deque.PopBack", func() { d.PopBack() })
+	mustPanic(t, "Front of an empty Deque", "Deque.At", func() { d.Front() })
+}
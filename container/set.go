@@ -0,0 +1,406 @@
+package container
+
+import "cmp"
+
+// SetIterator points to an element of an OrderedSet or OrderedMultiset, or
+// past the last one. Iterators stay valid until the element they point to is
+// erased or extracted, including across Merge. Iterators are comparable with
+// ==.
+type SetIterator[K any] struct {
+	n *rbNode[K, struct{}]
+}
+
+// Returns the element the iterator points to.
+func (it SetIterator[K]) Value() K {
+	return it.n.key
+}
+
+// Returns an iterator to the next element.
+func (it SetIterator[K]) Next() SetIterator[K] {
+	return SetIterator[K]{it.n.next()}
+}
+
+// Returns an iterator to the previous element.
+func (it SetIterator[K]) Prev() SetIterator[K] {
+	return SetIterator[K]{it.n.prev()}
+}
+
+// SetNode is a node handle: an element extracted from an OrderedSet or
+// OrderedMultiset that can be inserted into another set without copying. The
+// zero SetNode is empty.
+type SetNode[K any] struct {
+	n *rbNode[K, struct{}]
+}
+
+// Checks whether the node handle is empty.
+func (nh SetNode[K]) Empty() bool {
+	return nh.n == nil
+}
+
+// Returns the value stored in the node handle.
+func (nh SetNode[K]) Value() K {
+	return nh.n.key
+}
+
+// Replaces the value stored in the node handle.
+func (nh SetNode[K]) SetValue(k K) {
+	nh.n.key = k
+}
+
+// SetInsertReturn is the result of inserting a node handle into an
+// OrderedSet. If the insertion failed because the value already exists,
+// Position points to the existing element and Node still owns the node.
+type SetInsertReturn[K any] struct {
+	Position SetIterator[K]
+	Inserted bool
+	Node     SetNode[K]
+}
+
+// OrderedSet is an associative container that contains a sorted set of unique
+// objects, like std::set. Sorting is done using the key comparison function
+// comp. The set is implemented as a red-black tree, so search, removal and
+// insertion have logarithmic complexity. The zero OrderedSet has no comparison
+// function and is not usable; construct sets with NewOrderedSet or
+// NewOrderedSetFunc.
+type OrderedSet[K any] struct {
+	t rbTree[K, struct{}]
+}
+
+// Constructs an empty set ordered by operator<.
+func NewOrderedSet[K cmp.Ordered]() *OrderedSet[K] {
+	return NewOrderedSetFunc[K](cmp.Less[K])
+}
+
+// Constructs an empty set ordered by the strict weak ordering comp.
+func NewOrderedSetFunc[K any](comp func(K, K) bool) *OrderedSet[K] {
+	s := new(OrderedSet[K])
+	s.t.init(comp)
+	return s
+}
+
+// Returns the function that compares keys.
+func (s *OrderedSet[K]) KeyComp() func(K, K) bool {
+	return s.t.comp
+}
+
+// Returns an iterator to the first element of the set.
+func (s *OrderedSet[K]) Begin() SetIterator[K] {
+	return SetIterator[K]{s.t.begin()}
+}
+
+// Returns an iterator to the element following the last element of the set.
+func (s *OrderedSet[K]) End() SetIterator[K] {
+	return SetIterator[K]{s.t.end()}
+}
+
+// Checks if the set has no elements.
+func (s *OrderedSet[K]) Empty() bool {
+	return s.t.size == 0
+}
+
+// Returns the number of elements in the set.
+func (s *OrderedSet[K]) Size() int {
+	return s.t.size
+}
+
+// Erases all elements from the set.
+func (s *OrderedSet[K]) Clear() {
+	s.t.reset()
+}
+
+// Inserts k if the set doesn't already contain an equivalent element. Returns
+// an iterator to the element equivalent to k and whether the insertion took
+// place.
+func (s *OrderedSet[K]) Insert(k K) (SetIterator[K], bool) {
+	n, ok := s.t.insertUnique(&rbNode[K, struct{}]{key: k})
+	return SetIterator[K]{n}, ok
+}
+
+// Inserts the elements of r[first, last). If multiple elements in the range
+// are equivalent, only the first one is inserted.
+func (s *OrderedSet[K]) InsertRange(r []K, first, last int) {
+	for ; first != last; first++ {
+		s.t.insertHintUnique(s.t.end(), &rbNode[K, struct{}]{key: r[first]})
+	}
+}
+
+// Inserts k as close as possible to the position just before hint, unless an
+// equivalent element exists. Takes amortized constant time if the element
+// belongs immediately before hint. Returns an iterator to the element
+// equivalent to k.
+func (s *OrderedSet[K]) EmplaceHint(hint SetIterator[K], k K) SetIterator[K] {
+	n, _ := s.t.insertHintUnique(hint.n, &rbNode[K, struct{}]{key: k})
+	return SetIterator[K]{n}
+}
+
+// Inserts the element owned by nh if the set doesn't already contain an
+// equivalent element. An empty nh is not inserted.
+func (s *OrderedSet[K]) InsertNode(nh SetNode[K]) SetInsertReturn[K] {
+	if nh.Empty() {
+		return SetInsertReturn[K]{Position: s.End()}
+	}
+	n, ok := s.t.insertUnique(nh.n)
+	if !ok {
+		return SetInsertReturn[K]{Position: SetIterator[K]{n}, Node: nh}
+	}
+	return SetInsertReturn[K]{Position: SetIterator[K]{n}, Inserted: true}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (s *OrderedSet[K]) Erase(pos SetIterator[K]) SetIterator[K] {
+	return SetIterator[K]{s.t.unlink(pos.n)}
+}
+
+// Removes the elements in the range [first, last) and returns last.
+func (s *OrderedSet[K]) EraseRange(first, last SetIterator[K]) SetIterator[K] {
+	return SetIterator[K]{s.t.eraseRange(first.n, last.n)}
+}
+
+// Removes the element equivalent to k, if any, and returns the number of
+// elements removed.
+func (s *OrderedSet[K]) EraseKey(k K) int {
+	return s.t.eraseKey(k)
+}
+
+// Unlinks the element at pos and returns a node handle that owns it.
+func (s *OrderedSet[K]) Extract(pos SetIterator[K]) SetNode[K] {
+	s.t.unlink(pos.n)
+	return SetNode[K]{pos.n}
+}
+
+// Unlinks the element equivalent to k and returns a node handle that owns it,
+// or an empty node handle if there is no such element.
+func (s *OrderedSet[K]) ExtractKey(k K) SetNode[K] {
+	n := s.t.find(k)
+	if n == s.t.end() {
+		return SetNode[K]{}
+	}
+	return s.Extract(SetIterator[K]{n})
+}
+
+// Moves every element of source that is not present in s into s. No elements
+// are copied; iterators to moved elements remain valid and now refer into s.
+func (s *OrderedSet[K]) Merge(source *OrderedSet[K]) {
+	s.t.mergeUnique(&source.t)
+}
+
+// Like Merge, but takes elements from an OrderedMultiset. Of several
+// equivalent source elements, at most one is moved.
+func (s *OrderedSet[K]) MergeMulti(source *OrderedMultiset[K]) {
+	s.t.mergeUnique(&source.t)
+}
+
+// Exchanges the contents of the set with those of other.
+func (s *OrderedSet[K]) Swap(other *OrderedSet[K]) {
+	s.t.swap(&other.t)
+}
+
+// Returns the number of elements equivalent to k, which is either 1 or 0.
+func (s *OrderedSet[K]) Count(k K) int {
+	if s.Contains(k) {
+		return 1
+	}
+	return 0
+}
+
+// Finds an element equivalent to k. Returns End() if there is no such element.
+func (s *OrderedSet[K]) Find(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.find(k)}
+}
+
+// Checks if there is an element equivalent to k in the set.
+func (s *OrderedSet[K]) Contains(k K) bool {
+	return s.t.find(k) != s.t.end()
+}
+
+// Returns a range containing all elements equivalent to k. The range is
+// defined by two iterators, the first pointing to the first element that is
+// not less than k and the second pointing to the first element greater than k.
+func (s *OrderedSet[K]) EqualRange(k K) (SetIterator[K], SetIterator[K]) {
+	first, last := s.t.equalRange(k)
+	return SetIterator[K]{first}, SetIterator[K]{last}
+}
+
+// Returns an iterator pointing to the first element that is not less than k.
+func (s *OrderedSet[K]) LowerBound(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.lowerBound(k)}
+}
+
+// Returns an iterator pointing to the first element that is greater than k.
+func (s *OrderedSet[K]) UpperBound(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.upperBound(k)}
+}
+
+// Returns the elements of the set in ascending order. The result is sorted by
+// KeyComp() and can be passed directly to the sorted-range algorithms.
+func (s *OrderedSet[K]) Values() []K {
+	return s.t.keys()
+}
+
+// OrderedMultiset is an associative container that contains a sorted set of
+// objects and permits multiple equivalent elements, like std::multiset.
+// Equivalent elements keep their insertion order. The zero OrderedMultiset is
+// not usable; construct multisets with NewOrderedMultiset or
+// NewOrderedMultisetFunc.
+type OrderedMultiset[K any] struct {
+	t rbTree[K, struct{}]
+}
+
+// Constructs an empty multiset ordered by operator<.
+func NewOrderedMultiset[K cmp.Ordered]() *OrderedMultiset[K] {
+	return NewOrderedMultisetFunc[K](cmp.Less[K])
+}
+
+// Constructs an empty multiset ordered by the strict weak ordering comp.
+func NewOrderedMultisetFunc[K any](comp func(K, K) bool) *OrderedMultiset[K] {
+	s := new(OrderedMultiset[K])
+	s.t.init(comp)
+	return s
+}
+
+// Returns the function that compares keys.
+func (s *OrderedMultiset[K]) KeyComp() func(K, K) bool {
+	return s.t.comp
+}
+
+// Returns an iterator to the first element of the multiset.
+func (s *OrderedMultiset[K]) Begin() SetIterator[K] {
+	return SetIterator[K]{s.t.begin()}
+}
+
+// Returns an iterator to the element following the last element of the
+// multiset.
+func (s *OrderedMultiset[K]) End() SetIterator[K] {
+	return SetIterator[K]{s.t.end()}
+}
+
+// Checks if the multiset has no elements.
+func (s *OrderedMultiset[K]) Empty() bool {
+	return s.t.size == 0
+}
+
+// Returns the number of elements in the multiset.
+func (s *OrderedMultiset[K]) Size() int {
+	return s.t.size
+}
+
+// Erases all elements from the multiset.
+func (s *OrderedMultiset[K]) Clear() {
+	s.t.reset()
+}
+
+// Inserts k after any equivalent elements and returns an iterator to it.
+func (s *OrderedMultiset[K]) Insert(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.insertEqual(&rbNode[K, struct{}]{key: k})}
+}
+
+// Inserts the elements of r[first, last).
+func (s *OrderedMultiset[K]) InsertRange(r []K, first, last int) {
+	for ; first != last; first++ {
+		s.t.insertHintEqual(s.t.end(), &rbNode[K, struct{}]{key: r[first]})
+	}
+}
+
+// Inserts k as close as possible to the position just before hint. Takes
+// amortized constant time if the element belongs immediately before hint.
+// Returns an iterator to the inserted element.
+func (s *OrderedMultiset[K]) EmplaceHint(hint SetIterator[K], k K) SetIterator[K] {
+	return SetIterator[K]{s.t.insertHintEqual(hint.n, &rbNode[K, struct{}]{key: k})}
+}
+
+// Inserts the element owned by nh and returns an iterator to it. If nh is
+// empty, returns End().
+func (s *OrderedMultiset[K]) InsertNode(nh SetNode[K]) SetIterator[K] {
+	if nh.Empty() {
+		return s.End()
+	}
+	return SetIterator[K]{s.t.insertEqual(nh.n)}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (s *OrderedMultiset[K]) Erase(pos SetIterator[K]) SetIterator[K] {
+	return SetIterator[K]{s.t.unlink(pos.n)}
+}
+
+// Removes the elements in the range [first, last) and returns last.
+func (s *OrderedMultiset[K]) EraseRange(first, last SetIterator[K]) SetIterator[K] {
+	return SetIterator[K]{s.t.eraseRange(first.n, last.n)}
+}
+
+// Removes all elements equivalent to k and returns the number of elements
+// removed.
+func (s *OrderedMultiset[K]) EraseKey(k K) int {
+	return s.t.eraseKey(k)
+}
+
+// Unlinks the element at pos and returns a node handle that owns it.
+func (s *OrderedMultiset[K]) Extract(pos SetIterator[K]) SetNode[K] {
+	s.t.unlink(pos.n)
+	return SetNode[K]{pos.n}
+}
+
+// Unlinks the first element equivalent to k and returns a node handle that
+// owns it, or an empty node handle if there is no such element.
+func (s *OrderedMultiset[K]) ExtractKey(k K) SetNode[K] {
+	n := s.t.find(k)
+	if n == s.t.end() {
+		return SetNode[K]{}
+	}
+	return s.Extract(SetIterator[K]{n})
+}
+
+// Moves every element of source into s. No elements are copied; iterators to
+// moved elements remain valid and now refer into s.
+func (s *OrderedMultiset[K]) Merge(source *OrderedMultiset[K]) {
+	s.t.mergeEqual(&source.t)
+}
+
+// Like Merge, but takes elements from an OrderedSet.
+func (s *OrderedMultiset[K]) MergeUnique(source *OrderedSet[K]) {
+	s.t.mergeEqual(&source.t)
+}
+
+// Exchanges the contents of the multiset with those of other.
+func (s *OrderedMultiset[K]) Swap(other *OrderedMultiset[K]) {
+	s.t.swap(&other.t)
+}
+
+// Returns the number of elements equivalent to k.
+func (s *OrderedMultiset[K]) Count(k K) int {
+	return s.t.count(k)
+}
+
+// Finds an element equivalent to k. If there are several, the first one is
+// returned. Returns End() if there is no such element.
+func (s *OrderedMultiset[K]) Find(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.find(k)}
+}
+
+// Checks if there is an element equivalent to k in the multiset.
+func (s *OrderedMultiset[K]) Contains(k K) bool {
+	return s.t.find(k) != s.t.end()
+}
+
+// Returns a range containing all elements equivalent to k. The range is
+// defined by two iterators, the first pointing to the first element that is
+// not less than k and the second pointing to the first element greater than k.
+func (s *OrderedMultiset[K]) EqualRange(k K) (SetIterator[K], SetIterator[K]) {
+	first, last := s.t.equalRange(k)
+	return SetIterator[K]{first}, SetIterator[K]{last}
+}
+
+// Returns an iterator pointing to the first element that is not less than k.
+func (s *OrderedMultiset[K]) LowerBound(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.lowerBound(k)}
+}
+
+// Returns an iterator pointing to the first element that is greater than k.
+func (s *OrderedMultiset[K]) UpperBound(k K) SetIterator[K] {
+	return SetIterator[K]{s.t.upperBound(k)}
+}
+
+// Returns the elements of the multiset in ascending order, including
+// duplicates.
+func (s *OrderedMultiset[K]) Values() []K {
+	return s.t.keys()
+}
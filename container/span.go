@@ -0,0 +1,192 @@
+package container
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// DynamicExtent is the extent of a Span whose size is not fixed, like
+// std::dynamic_extent.
+const DynamicExtent = -1
+
+// Span is a non-owning view of a contiguous sequence of elements, like
+// std::span. A span has either a static extent, a size that is fixed when it
+// is constructed and checked whenever it is converted, or a dynamic extent.
+// Go has no constant type parameters, so the static extent is a property of
+// the value rather than of the type. The zero Span is empty and has a dynamic
+// extent.
+//
+// Spans work with the index-range algorithms through Data, Begin and End:
+//
+//	algorithm.Find(s.Data(), s.Begin(), s.End(), x)
+//
+// The positions the algorithms return are then relative to the span.
+type Span[T any] struct {
+	data   []T
+	static bool
+}
+
+// Constructs a span over the elements r[first, last), with a dynamic extent.
+// Panics if [first, last) is not a valid range of r.
+func MakeSpan[T any](r []T, first, last int) Span[T] {
+	checkSpanRange("MakeSpan", len(r), first, last)
+	return Span[T]{data: r[first:last:last]}
+}
+
+// Constructs a span over the elements r[first, first + extent), with the
+// static extent extent. Panics if r has fewer than extent elements starting
+// at first.
+func MakeStaticSpan[T any](r []T, first, extent int) Span[T] {
+	if extent < 0 {
+		panic(fmt.Sprintf("container: MakeStaticSpan: negative extent %d", extent))
+	}
+	checkSpanRange("MakeStaticSpan", len(r), first, first+extent)
+	return Span[T]{data: r[first : first+extent : first+extent], static: true}
+}
+
+// Constructs a span over all elements of r, with a dynamic extent.
+func SpanOf[T any](r []T) Span[T] {
+	return Span[T]{data: r[:len(r):len(r)]}
+}
+
+func checkSpanRange(method string, n, first, last int) {
+	if first < 0 || first > last || last > n {
+		panic(fmt.Sprintf("container: %s: [%d, %d) is not a valid range of a slice of length %d", method, first, last, n))
+	}
+}
+
+func (s Span[T]) checkIndex(method string, i int) {
+	if i < 0 || i >= len(s.data) {
+		panic(fmt.Sprintf("container: Span.%s: index %d out of range [0, %d)", method, i, len(s.data)))
+	}
+}
+
+// Returns the static extent of the span, or DynamicExtent if its extent is
+// dynamic.
+func (s Span[T]) Extent() int {
+	if s.static {
+		return len(s.data)
+	}
+	return DynamicExtent
+}
+
+// Returns the number of elements in the span.
+func (s Span[T]) Size() int {
+	return len(s.data)
+}
+
+// Returns the size of the elements of the span in bytes.
+func (s Span[T]) SizeBytes() int {
+	var zero T
+	return len(s.data) * int(unsafe.Sizeof(zero))
+}
+
+// Checks if the span has no elements.
+func (s Span[T]) Empty() bool {
+	return len(s.data) == 0
+}
+
+// Returns the element at position i. Panics if i is out of range.
+func (s Span[T]) At(i int) T {
+	s.checkIndex("At", i)
+	return s.data[i]
+}
+
+// Replaces the element at position i with v. The element is replaced in the
+// viewed sequence. Panics if i is out of range.
+func (s Span[T]) Set(i int, v T) {
+	s.checkIndex("Set", i)
+	s.data[i] = v
+}
+
+// Returns the first element in the span. Panics if the span is empty.
+func (s Span[T]) Front() T {
+	s.checkIndex("Front", 0)
+	return s.data[0]
+}
+
+// Returns the last element in the span. Panics if the span is empty.
+func (s Span[T]) Back() T {
+	s.checkIndex("Back", len(s.data)-1)
+	return s.data[len(s.data)-1]
+}
+
+// Returns the viewed elements as a slice whose length and capacity are the
+// size of the span, so that appending to it cannot overwrite the elements
+// after the span.
+func (s Span[T]) Data() []T {
+	return s.data
+}
+
+// Returns an iterator to the first element of the span in Data, that is, 0.
+func (s Span[T]) Begin() int {
+	return 0
+}
+
+// Returns an iterator one past the last element of the span in Data, that is,
+// Size.
+func (s Span[T]) End() int {
+	return len(s.data)
+}
+
+// Returns a span over the first count elements of the span, with a dynamic
+// extent. Panics if count is negative or greater than Size.
+func (s Span[T]) First(count int) Span[T] {
+	checkSpanRange("Span.First", len(s.data), 0, count)
+	return Span[T]{data: s.data[:count:count]}
+}
+
+// Returns a span over the last count elements of the span, with a dynamic
+// extent. Panics if count is negative or greater than Size.
+func (s Span[T]) Last(count int) Span[T] {
+	n := len(s.data)
+	checkSpanRange("Span.Last", n, n-count, n)
+	return Span[T]{data: s.data[n-count : n : n]}
+}
+
+// Returns a span over the count elements of the span starting at offset, or
+// over the elements from offset to the end of the span if count is
+// DynamicExtent. The result has a dynamic extent. Panics if the elements are
+// not within the span.
+func (s Span[T]) Subspan(offset, count int) Span[T] {
+	if count == DynamicExtent {
+		count = len(s.data) - offset
+	}
+	if count < 0 {
+		panic(fmt.Sprintf("container: Span.Subspan: negative count %d", count))
+	}
+	checkSpanRange("Span.Subspan", len(s.data), offset, offset+count)
+	return Span[T]{data: s.data[offset : offset+count : offset+count]}
+}
+
+// Returns a span over the same elements with the given extent, which is
+// either DynamicExtent or a static extent, like the conversions between the
+// specializations of std::span. Panics if the extent is static and differs
+// from Size.
+func (s Span[T]) WithExtent(extent int) Span[T] {
+	if extent == DynamicExtent {
+		return Span[T]{data: s.data}
+	}
+	if extent != len(s.data) {
+		panic(fmt.Sprintf("container: Span.WithExtent: extent %d differs from size %d", extent, len(s.data)))
+	}
+	return Span[T]{data: s.data, static: true}
+}
+
+// Returns a span over the bytes of the elements of s, like std::as_bytes. The
+// result has a static extent if s has one. The bytes must only be read.
+func AsBytes[T any](s Span[T]) Span[byte] {
+	n := s.SizeBytes()
+	if n == 0 {
+		return Span[byte]{data: []byte{}, static: s.static}
+	}
+	b := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(s.data))), n)
+	return Span[byte]{data: b, static: s.static}
+}
+
+// Returns a span over the bytes of the elements of s that can be written, like
+// std::as_writable_bytes. The result has a static extent if s has one. T must
+// not contain pointers, since writing their bytes would corrupt them.
+func AsWritableBytes[T any](s Span[T]) Span[byte] {
+	return AsBytes(s)
+}
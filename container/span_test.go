@@ -0,0 +1,111 @@
+package container
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+func TestSpanExtent(t *testing.T) {
+	r := []int{0, 1, 2, 3, 4, 5}
+	dynamic, static := MakeSpan(r, 1, 5), MakeStaticSpan(r, 1, 4)
+	tests := []struct {
+		name   string
+		s      Span[int]
+		want   []int
+		extent int
+	}{
+		{"MakeSpan", dynamic, []int{1, 2, 3, 4}, DynamicExtent},
+		{"MakeStaticSpan", static, []int{1, 2, 3, 4}, 4},
+		{"SpanOf", SpanOf(r), r, DynamicExtent},
+		{"zero", Span[int]{}, nil, DynamicExtent},
+		// The subspans of a span have a dynamic extent, whatever its extent.
+		{"First of dynamic", dynamic.First(2), []int{1, 2}, DynamicExtent},
+		{"First of static", static.First(2), []int{1, 2}, DynamicExtent},
+		{"First all", static.First(4), []int{1, 2, 3, 4}, DynamicExtent},
+		{"Last of dynamic", dynamic.Last(3), []int{2, 3, 4}, DynamicExtent},
+		{"Last of static", static.Last(0), nil, DynamicExtent},
+		{"Subspan of dynamic", dynamic.Subspan(1, 2), []int{2, 3}, DynamicExtent},
+		{"Subspan of static", static.Subspan(1, 2), []int{2, 3}, DynamicExtent},
+		{"Subspan to the end", static.Subspan(1, DynamicExtent), []int{2, 3, 4}, DynamicExtent},
+		{"Subspan at the end", static.Subspan(4, DynamicExtent), nil, DynamicExtent},
+		{"WithExtent static", dynamic.WithExtent(4), []int{1, 2, 3, 4}, 4},
+		{"WithExtent dynamic", static.WithExtent(DynamicExtent), []int{1, 2, 3, 4}, DynamicExtent},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.s.Data(), tt.want) || tt.s.Size() != len(tt.want) || tt.s.Extent() != tt.extent || tt.s.Empty() != (len(tt.want) == 0) {
+			t.Errorf("%s = %v with extent %d, want %v with extent %d", tt.name, tt.s.Data(), tt.s.Extent(), tt.want, tt.extent)
+		}
+		if tt.s.Begin() != 0 || tt.s.End() != len(tt.want) || cap(tt.s.Data()) != len(tt.want) {
+			t.Errorf("%s: Begin, End, capacity = %d, %d, %d", tt.name, tt.s.Begin(), tt.s.End(), cap(tt.s.Data()))
+		}
+	}
+}
+
+func TestSpanAccess(t *testing.T) {
+	r := []int{0, 1, 2, 3, 4, 5}
+	s := MakeSpan(r, 1, 4)
+	if s.At(0) != 1 || s.Front() != 1 || s.Back() != 3 {
+		t.Errorf("At(0), Front, Back = %d, %d, %d", s.At(0), s.Front(), s.Back())
+	}
+
+	// Spans view the elements of r rather than copying them.
+	s.Set(1, 20)
+	s.First(1).Set(0, 10)
+	if !slices.Equal(r, []int{0, 10, 20, 3, 4, 5}) {
+		t.Errorf("Set wrote %v", r)
+	}
+	// Appending to Data does not overwrite the elements after the span.
+	_ = append(s.Data(), -1)
+	if r[4] != 4 {
+		t.Errorf("appending to Data overwrote %v", r)
+	}
+
+	mustPanic(t, "MakeSpan beyond len", "[2, 7) is not a valid range of a slice of length 6", func() { MakeSpan(r, 2, 7) })
+	mustPanic(t, "MakeSpan reversed", "[3, 2)", func() { MakeSpan(r, 3, 2) })
+	mustPanic(t, "MakeStaticSpan negative", "negative extent -1", func() { MakeStaticSpan(r, 0, -1) })
+	mustPanic(t, "MakeStaticSpan beyond len", "MakeStaticSpan: [4, 7)", func() { MakeStaticSpan(r, 4, 3) })
+	mustPanic(t, "At out of range", "Span.At: index 3 out of range [0, 3)", func() { s.At(3) })
+	mustPanic(t, "Set negative", "Span.Set: index -1 out of range [0, 3)", func() { s.Set(-1, 0) })
+	mustPanic(t, "First too many", "Span.First: [0, 4)", func() { s.First(4) })
+	mustPanic(t, "Last negative", "Span.Last: [4, 3)", func() { s.Last(-1) })
+	mustPanic(t, "Subspan beyond the end", "Span.Subspan: [2, 4)", func() { s.Subspan(2, 2) })
+	mustPanic(t, "Subspan after the end", "negative count -1", func() { s.Subspan(4, DynamicExtent) })
+	mustPanic(t, "WithExtent", "extent 2 differs from size 3", func() { s.WithExtent(2) })
+	var empty Span[int]
+	mustPanic(t, "Front of an empty span", "Span.Front", func() { empty.Front() })
+	mustPanic(t, "Back of an empty span", "Span.Back", func() { empty.Back() })
+}
+
+func TestAsBytes(t *testing.T) {
+	r := []int32{1, 2, 3, 4}
+	size := int(unsafe.Sizeof(r[0]))
+	s := MakeStaticSpan(r, 1, 2)
+	b := AsBytes(s)
+	if b.Size() != 2*size || b.SizeBytes() != s.SizeBytes() || b.Extent() != 2*size {
+		t.Errorf("AsBytes of a static span of 2 int32: Size %d, SizeBytes %d, Extent %d", b.Size(), b.SizeBytes(), b.Extent())
+	}
+	if d := AsBytes(s.WithExtent(DynamicExtent)); d.Extent() != DynamicExtent || d.Size() != 2*size {
+		t.Errorf("AsBytes of a dynamic span: Size %d, Extent %d", d.Size(), d.Extent())
+	}
+
+	// The bytes alias the elements of s, and only those.
+	r[1] = 0
+	if slices.ContainsFunc(b.Data()[:size], func(x byte) bool { return x != 0 }) {
+		t.Errorf("AsBytes does not reflect a write to the element: %v", b.Data())
+	}
+	w := AsWritableBytes(s)
+	for i := size; i < 2*size; i++ {
+		w.Set(i, 0xff)
+	}
+	if !slices.Equal(r, []int32{1, 0, -1, 4}) {
+		t.Errorf("writing every byte of the second element through AsWritableBytes gave %v", r)
+	}
+
+	if e := AsBytes(MakeStaticSpan(r, 4, 0)); e.Size() != 0 || e.Extent() != 0 {
+		t.Errorf("AsBytes of an empty static span: Size %d, Extent %d", e.Size(), e.Extent())
+	}
+	if e := AsWritableBytes(Span[int32]{}); e.Size() != 0 || e.Extent() != DynamicExtent {
+		t.Errorf("AsWritableBytes of the zero span: Size %d, Extent %d", e.Size(), e.Extent())
+	}
+}
@@ -0,0 +1,65 @@
+package container
+
+// StackContainer is the interface an underlying container must implement to
+// be adapted by Stack: it must support inspecting, appending and removing
+// elements at its back. *Deque satisfies StackContainer.
+type StackContainer[T any] interface {
+	Back() T
+	PushBack(v T)
+	PopBack()
+	Empty() bool
+	Size() int
+}
+
+// Stack is a container adaptor that gives the functionality of a stack, a
+// LIFO (last-in, first-out) data structure, like std::stack. Elements are
+// pushed to and popped from the back of the underlying container.
+type Stack[T any] struct {
+	c StackContainer[T]
+}
+
+// Constructs an empty stack backed by a Deque.
+func NewStack[T any]() *Stack[T] {
+	return NewStackWith[T](NewDeque[T]())
+}
+
+// Constructs a stack that adapts c. The elements already in c form the
+// stack, with the back of c as the top.
+func NewStackWith[T any](c StackContainer[T]) *Stack[T] {
+	return &Stack[T]{c}
+}
+
+// Returns the underlying container.
+func (s *Stack[T]) Container() StackContainer[T] {
+	return s.c
+}
+
+// Returns the top element of the stack, the most recently pushed one.
+func (s *Stack[T]) Top() T {
+	return s.c.Back()
+}
+
+// Checks if the stack has no elements.
+func (s *Stack[T]) Empty() bool {
+	return s.c.Empty()
+}
+
+// Returns the number of elements in the stack.
+func (s *Stack[T]) Size() int {
+	return s.c.Size()
+}
+
+// Pushes v onto the top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.c.PushBack(v)
+}
+
+// Removes the top element from the stack.
+func (s *Stack[T]) Pop() {
+	s.c.PopBack()
+}
+
+// Exchanges the contents of the stack with those of other.
+func (s *Stack[T]) Swap(other *Stack[T]) {
+	s.c, other.c = other.c, s.c
+}
@@ -0,0 +1,175 @@
+package container
+
+import (
+	"container/heap"
+	"flag"
+	"testing"
+
+	"gocpp/internal/benchutil"
+)
+
+var stdlibSizes = flag.String("benchsizes", benchutil.DefaultSizes, benchutil.SizesUsage)
+
+// BenchmarkStdlib compares the containers with their counterparts in
+// container/heap and with Go maps, over the element types, sizes and
+// distributions of package benchutil. See there for how to run it and
+// compare the results.
+func BenchmarkStdlib(b *testing.B) {
+	stdlibSuite(b, benchutil.Int, HashInteger[int])
+	stdlibSuite(b, benchutil.String, HashString[string])
+	stdlibSuite(b, benchutil.Struct, func(r benchutil.Record) uint64 { return HashInteger(r.Key) })
+}
+
+// stdlibImpl is one implementation of an operation of the suite. Its run
+// function must leave in, the input shared by all implementations, alone.
+type stdlibImpl[T any] struct {
+	name string
+	run  func(b *testing.B, in []T)
+}
+
+type stdlibOp[T any] struct {
+	name  string
+	impls []stdlibImpl[T]
+}
+
+func stdlibSuite[T comparable](b *testing.B, typ benchutil.Type[T], hash func(T) uint64) {
+	ops := stdlibOps(typ, hash)
+	for _, n := range benchutil.Sizes(*stdlibSizes) {
+		for _, d := range benchutil.Dists {
+			in := typ.Slice(n, d)
+			name := benchutil.Name(typ.Name, d, n)
+			for _, op := range ops {
+				for _, impl := range op.impls {
+					b.Run(op.name+"/"+name+"/impl="+impl.name, func(b *testing.B) {
+						impl.run(b, in)
+					})
+				}
+			}
+		}
+	}
+}
+
+// stdHeap adapts a slice to heap.Interface for the container/heap baselines.
+type stdHeap[T any] struct {
+	s    []T
+	less func(a, b T) bool
+}
+
+func (h *stdHeap[T]) Len() int           { return len(h.s) }
+func (h *stdHeap[T]) Less(i, j int) bool { return h.less(h.s[i], h.s[j]) }
+func (h *stdHeap[T]) Swap(i, j int)      { h.s[i], h.s[j] = h.s[j], h.s[i] }
+func (h *stdHeap[T]) Push(x any)         { h.s = append(h.s, x.(T)) }
+
+func (h *stdHeap[T]) Pop() any {
+	x := h.s[len(h.s)-1]
+	h.s = h.s[:len(h.s)-1]
+	return x
+}
+
+var stdlibSink int
+
+func stdlibOps[T comparable](typ benchutil.Type[T], hash func(T) uint64) []stdlibOp[T] {
+	less := typ.Less
+	equal := func(a, b T) bool { return a == b }
+
+	// Lookups get a map of the elements of in, built once, and look up the
+	// elements of in in turn. Their results are kept in stdlibSink so that
+	// the calls cannot be optimized away.
+	lookup := func(build func(in []T) func(x T) bool) func(b *testing.B, in []T) {
+		return func(b *testing.B, in []T) {
+			contains := build(in)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if contains(in[i%len(in)]) {
+					stdlibSink++
+				}
+			}
+		}
+	}
+
+	return []stdlibOp[T]{
+		{"PushPopPriorityQueue", []stdlibImpl[T]{
+			{"gocpp", func(b *testing.B, in []T) {
+				pq := NewPriorityQueueFunc(less)
+				for i := 0; i < b.N; i++ {
+					for _, x := range in {
+						pq.Push(x)
+					}
+					for !pq.Empty() {
+						pq.Pop()
+					}
+				}
+			}},
+			{"heap", func(b *testing.B, in []T) {
+				h := &stdHeap[T]{make([]T, 0, len(in)), less}
+				for i := 0; i < b.N; i++ {
+					for _, x := range in {
+						heap.Push(h, x)
+					}
+					for h.Len() > 0 {
+						heap.Pop(h)
+					}
+				}
+			}},
+		}},
+		{"MapInsert", []stdlibImpl[T]{
+			{"ordered", func(b *testing.B, in []T) {
+				for i := 0; i < b.N; i++ {
+					m := NewOrderedMapFunc[T, int](less)
+					for j, x := range in {
+						m.Insert(x, j)
+					}
+					stdlibSink += m.Size()
+				}
+			}},
+			{"unordered", func(b *testing.B, in []T) {
+				for i := 0; i < b.N; i++ {
+					m := NewUnorderedMap[T, int](hash, equal)
+					for j, x := range in {
+						m.Insert(x, j)
+					}
+					stdlibSink += m.Size()
+				}
+			}},
+			{"map", func(b *testing.B, in []T) {
+				for i := 0; i < b.N; i++ {
+					m := make(map[T]int)
+					for j, x := range in {
+						if _, ok := m[x]; !ok {
+							m[x] = j
+						}
+					}
+					stdlibSink += len(m)
+				}
+			}},
+		}},
+		{"MapFind", []stdlibImpl[T]{
+			{"ordered", lookup(func(in []T) func(x T) bool {
+				m := NewOrderedMapFunc[T, int](less)
+				for j, x := range in {
+					m.Insert(x, j)
+				}
+				return m.Contains
+			})},
+			{"unordered", lookup(func(in []T) func(x T) bool {
+				m := NewUnorderedMap[T, int](hash, equal)
+				for j, x := range in {
+					m.Insert(x, j)
+				}
+				return m.Contains
+			})},
+			{"map", lookup(func(in []T) func(x T) bool {
+				m := make(map[T]int)
+				for j, x := range in {
+					if _, ok := m[x]; !ok {
+						m[x] = j
+					}
+				}
+				return func(x T) bool {
+					_, ok := m[x]
+					return ok
+				}
+			})},
+		}},
+	}
+}
@@ -0,0 +1,545 @@
+package container
+
+type color bool
+
+const (
+	red   color = false
+	black color = true
+)
+
+// rbNode is a node of a red-black tree. Nodes are never copied or reused
+// while they are linked into a tree, so pointers to them stay valid until the
+// node is erased or extracted.
+type rbNode[K, V any] struct {
+	left, right, parent *rbNode[K, V]
+	color               color
+	header              bool
+	key                 K
+	value               V
+}
+
+// next returns the in-order successor of x. The successor of the rightmost
+// node is the header of the tree.
+func (x *rbNode[K, V]) next() *rbNode[K, V] {
+	if x.right != nil {
+		x = x.right
+		for x.left != nil {
+			x = x.left
+		}
+		return x
+	}
+
+	y := x.parent
+	for x == y.right {
+		x = y
+		y = y.parent
+	}
+	if x.right != y {
+		x = y
+	}
+	return x
+}
+
+// prev returns the in-order predecessor of x. The predecessor of the header
+// is the rightmost node of the tree.
+func (x *rbNode[K, V]) prev() *rbNode[K, V] {
+	if x.header {
+		return x.right
+	}
+
+	if x.left != nil {
+		x = x.left
+		for x.right != nil {
+			x = x.right
+		}
+		return x
+	}
+
+	y := x.parent
+	for x == y.left {
+		x = y
+		y = y.parent
+	}
+	return y
+}
+
+// rbTree is the red-black tree shared by the ordered associative containers.
+// The header node is the past-the-end position: header.parent is the root,
+// header.left the leftmost node and header.right the rightmost node. The root
+// links back to the header through its parent pointer.
+type rbTree[K, V any] struct {
+	header rbNode[K, V]
+	size   int
+	comp   func(K, K) bool
+}
+
+func (t *rbTree[K, V]) init(comp func(K, K) bool) {
+	t.comp = comp
+	t.header.header = true
+	t.header.color = red
+	t.reset()
+}
+
+func (t *rbTree[K, V]) reset() {
+	t.header.parent = nil
+	t.header.left = &t.header
+	t.header.right = &t.header
+	t.size = 0
+}
+
+func (t *rbTree[K, V]) root() *rbNode[K, V] {
+	return t.header.parent
+}
+
+func (t *rbTree[K, V]) begin() *rbNode[K, V] {
+	return t.header.left
+}
+
+func (t *rbTree[K, V]) end() *rbNode[K, V] {
+	return &t.header
+}
+
+func (t *rbTree[K, V]) equiv(a, b K) bool {
+	return !t.comp(a, b) && !t.comp(b, a)
+}
+
+func (t *rbTree[K, V]) rotateLeft(x *rbNode[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	if x == t.root() {
+		t.header.parent = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+}
+
+func (t *rbTree[K, V]) rotateRight(x *rbNode[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	y.parent = x.parent
+	if x == t.root() {
+		t.header.parent = y
+	} else if x == x.parent.right {
+		x.parent.right = y
+	} else {
+		x.parent.left = y
+	}
+	y.right = x
+	x.parent = y
+}
+
+// link attaches z as the left or right child of p, which must have no child
+// on that side, and rebalances the tree. If p is the header, z becomes the
+// root of an empty tree.
+func (t *rbTree[K, V]) link(z, p *rbNode[K, V], left bool) *rbNode[K, V] {
+	z.left, z.right, z.parent = nil, nil, p
+	z.color = red
+
+	switch {
+	case p == &t.header:
+		t.header.parent = z
+		t.header.left = z
+		t.header.right = z
+	case left:
+		p.left = z
+		if p == t.header.left {
+			t.header.left = z
+		}
+	default:
+		p.right = z
+		if p == t.header.right {
+			t.header.right = z
+		}
+	}
+
+	for x := z; x != t.root() && x.parent.color == red; {
+		p := x.parent
+		g := p.parent
+		if p == g.left {
+			if u := g.right; u != nil && u.color == red {
+				p.color, u.color, g.color = black, black, red
+				x = g
+				continue
+			}
+			if x == p.right {
+				x = p
+				t.rotateLeft(x)
+				p = x.parent
+			}
+			p.color, g.color = black, red
+			t.rotateRight(g)
+		} else {
+			if u := g.left; u != nil && u.color == red {
+				p.color, u.color, g.color = black, black, red
+				x = g
+				continue
+			}
+			if x == p.left {
+				x = p
+				t.rotateRight(x)
+				p = x.parent
+			}
+			p.color, g.color = black, red
+			t.rotateLeft(g)
+		}
+	}
+	t.root().color = black
+	t.size++
+	return z
+}
+
+// insertUnique links z into the tree unless a node with an equivalent key is
+// already present. It returns the node holding the key and whether z was
+// linked.
+func (t *rbTree[K, V]) insertUnique(z *rbNode[K, V]) (*rbNode[K, V], bool) {
+	y, x := &t.header, t.root()
+	left := true
+	for x != nil {
+		y = x
+		left = t.comp(z.key, x.key)
+		if left {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+
+	j := y
+	if left {
+		if j == t.begin() {
+			t.link(z, y, true)
+			return z, true
+		}
+		j = j.prev()
+	}
+	if t.comp(j.key, z.key) {
+		t.link(z, y, left)
+		return z, true
+	}
+	return j, false
+}
+
+// insertEqual links z into the tree after every node with an equivalent key.
+func (t *rbTree[K, V]) insertEqual(z *rbNode[K, V]) *rbNode[K, V] {
+	y, x := &t.header, t.root()
+	left := true
+	for x != nil {
+		y = x
+		left = t.comp(z.key, x.key)
+		if left {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	return t.link(z, y, left)
+}
+
+// linkBefore links z immediately before hint. The caller guarantees that this
+// keeps the tree ordered.
+func (t *rbTree[K, V]) linkBefore(z, hint *rbNode[K, V]) *rbNode[K, V] {
+	if hint == &t.header {
+		if t.size == 0 {
+			return t.link(z, &t.header, true)
+		}
+		return t.link(z, t.header.right, false)
+	}
+	if hint.left == nil {
+		return t.link(z, hint, true)
+	}
+	return t.link(z, hint.prev(), false)
+}
+
+// insertHintUnique is insertUnique, but links z in constant time if it
+// belongs immediately before hint.
+func (t *rbTree[K, V]) insertHintUnique(hint, z *rbNode[K, V]) (*rbNode[K, V], bool) {
+	if (hint == &t.header || t.comp(z.key, hint.key)) &&
+		(hint == t.begin() || t.comp(hint.prev().key, z.key)) {
+		return t.linkBefore(z, hint), true
+	}
+	if hint != &t.header && t.equiv(hint.key, z.key) {
+		return hint, false
+	}
+	return t.insertUnique(z)
+}
+
+// insertHintEqual is insertEqual, but links z in constant time if it belongs
+// immediately before hint.
+func (t *rbTree[K, V]) insertHintEqual(hint, z *rbNode[K, V]) *rbNode[K, V] {
+	if (hint == &t.header || !t.comp(hint.key, z.key)) &&
+		(hint == t.begin() || !t.comp(z.key, hint.prev().key)) {
+		return t.linkBefore(z, hint)
+	}
+	return t.insertEqual(z)
+}
+
+func (t *rbTree[K, V]) transplant(u, v *rbNode[K, V]) {
+	if u == t.root() {
+		t.header.parent = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+// unlink removes z from the tree without touching any other node's key or
+// value, and returns the successor of z.
+func (t *rbTree[K, V]) unlink(z *rbNode[K, V]) *rbNode[K, V] {
+	next := z.next()
+
+	if z == t.header.left {
+		t.header.left = next
+	}
+	if z == t.header.right {
+		if z.left != nil {
+			t.header.right = z.left
+			for t.header.right.right != nil {
+				t.header.right = t.header.right.right
+			}
+		} else {
+			t.header.right = z.parent
+		}
+	}
+
+	y, yColor := z, z.color
+	var x, xParent *rbNode[K, V]
+	switch {
+	case z.left == nil:
+		x, xParent = z.right, z.parent
+		t.transplant(z, z.right)
+	case z.right == nil:
+		x, xParent = z.left, z.parent
+		t.transplant(z, z.left)
+	default:
+		y = z.right
+		for y.left != nil {
+			y = y.left
+		}
+		yColor = y.color
+		x = y.right
+		if y.parent == z {
+			xParent = y
+		} else {
+			xParent = y.parent
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+	}
+
+	if yColor == black {
+		t.unlinkFixup(x, xParent)
+	}
+
+	z.left, z.right, z.parent = nil, nil, nil
+	t.size--
+	if t.size == 0 {
+		t.reset()
+	}
+	return next
+}
+
+func isBlack[K, V any](x *rbNode[K, V]) bool {
+	return x == nil || x.color == black
+}
+
+func (t *rbTree[K, V]) unlinkFixup(x, xParent *rbNode[K, V]) {
+	for x != t.root() && isBlack(x) {
+		if x == xParent.left {
+			w := xParent.right
+			if w.color == red {
+				w.color, xParent.color = black, red
+				t.rotateLeft(xParent)
+				w = xParent.right
+			}
+			if isBlack(w.left) && isBlack(w.right) {
+				w.color = red
+				x, xParent = xParent, xParent.parent
+				continue
+			}
+			if isBlack(w.right) {
+				w.left.color, w.color = black, red
+				t.rotateRight(w)
+				w = xParent.right
+			}
+			w.color, xParent.color = xParent.color, black
+			if w.right != nil {
+				w.right.color = black
+			}
+			t.rotateLeft(xParent)
+			x = t.root()
+		} else {
+			w := xParent.left
+			if w.color == red {
+				w.color, xParent.color = black, red
+				t.rotateRight(xParent)
+				w = xParent.left
+			}
+			if isBlack(w.left) && isBlack(w.right) {
+				w.color = red
+				x, xParent = xParent, xParent.parent
+				continue
+			}
+			if isBlack(w.left) {
+				w.right.color, w.color = black, red
+				t.rotateLeft(w)
+				w = xParent.left
+			}
+			w.color, xParent.color = xParent.color, black
+			if w.left != nil {
+				w.left.color = black
+			}
+			t.rotateRight(xParent)
+			x = t.root()
+		}
+	}
+	if x != nil {
+		x.color = black
+	}
+}
+
+// eraseRange unlinks every node in [first, last) and returns last.
+func (t *rbTree[K, V]) eraseRange(first, last *rbNode[K, V]) *rbNode[K, V] {
+	if first == t.begin() && last == t.end() {
+		t.reset()
+		return t.end()
+	}
+	for first != last {
+		first = t.unlink(first)
+	}
+	return last
+}
+
+// eraseKey unlinks every node with a key equivalent to k and returns the
+// number of nodes removed.
+func (t *rbTree[K, V]) eraseKey(k K) int {
+	first, last := t.equalRange(k)
+	n := 0
+	for first != last {
+		first = t.unlink(first)
+		n++
+	}
+	return n
+}
+
+// lowerBound returns the first node whose key is not less than k.
+func (t *rbTree[K, V]) lowerBound(k K) *rbNode[K, V] {
+	y, x := &t.header, t.root()
+	for x != nil {
+		if !t.comp(x.key, k) {
+			y, x = x, x.left
+		} else {
+			x = x.right
+		}
+	}
+	return y
+}
+
+// upperBound returns the first node whose key is greater than k.
+func (t *rbTree[K, V]) upperBound(k K) *rbNode[K, V] {
+	y, x := &t.header, t.root()
+	for x != nil {
+		if t.comp(k, x.key) {
+			y, x = x, x.left
+		} else {
+			x = x.right
+		}
+	}
+	return y
+}
+
+func (t *rbTree[K, V]) equalRange(k K) (*rbNode[K, V], *rbNode[K, V]) {
+	return t.lowerBound(k), t.upperBound(k)
+}
+
+// find returns a node whose key is equivalent to k, or the header.
+func (t *rbTree[K, V]) find(k K) *rbNode[K, V] {
+	j := t.lowerBound(k)
+	if j == &t.header || t.comp(k, j.key) {
+		return &t.header
+	}
+	return j
+}
+
+func (t *rbTree[K, V]) count(k K) int {
+	n := 0
+	for first, last := t.equalRange(k); first != last; first = first.next() {
+		n++
+	}
+	return n
+}
+
+// mergeUnique moves every node of source whose key is not present in t into
+// t. Nodes are relinked, never copied.
+func (t *rbTree[K, V]) mergeUnique(source *rbTree[K, V]) {
+	if source == t {
+		return
+	}
+	for x := source.begin(); x != source.end(); {
+		next := x.next()
+		if t.find(x.key) == t.end() {
+			source.unlink(x)
+			t.insertUnique(x)
+		}
+		x = next
+	}
+}
+
+// mergeEqual moves every node of source into t.
+func (t *rbTree[K, V]) mergeEqual(source *rbTree[K, V]) {
+	if source == t {
+		return
+	}
+	for x := source.begin(); x != source.end(); {
+		next := source.unlink(x)
+		t.insertEqual(x)
+		x = next
+	}
+}
+
+// swap exchanges the contents of t and u.
+func (t *rbTree[K, V]) swap(u *rbTree[K, V]) {
+	tRoot, uRoot := t.root(), u.root()
+	tLeft, tRight, uLeft, uRight := t.header.left, t.header.right, u.header.left, u.header.right
+	tSize, uSize := t.size, u.size
+
+	t.reset()
+	u.reset()
+	if uRoot != nil {
+		t.header.parent, t.header.left, t.header.right = uRoot, uLeft, uRight
+		uRoot.parent = &t.header
+	}
+	if tRoot != nil {
+		u.header.parent, u.header.left, u.header.right = tRoot, tLeft, tRight
+		tRoot.parent = &u.header
+	}
+	t.size, u.size = uSize, tSize
+	t.comp, u.comp = u.comp, t.comp
+}
+
+func (t *rbTree[K, V]) keys() []K {
+	ret := make([]K, 0, t.size)
+	for x := t.begin(); x != t.end(); x = x.next() {
+		ret = append(ret, x.key)
+	}
+	return ret
+}
@@ -0,0 +1,435 @@
+package container
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkTree checks the red-black invariants of t: the root is black, no red
+// node has a red child, every path from a node to its leaves has the same
+// number of black nodes, the keys are in order, and the header links to the
+// root, the leftmost and the rightmost node.
+func checkTree[K, V any](t *testing.T, tr *rbTree[K, V]) {
+	t.Helper()
+	root := tr.root()
+	if root == nil {
+		if tr.size != 0 || tr.header.left != &tr.header || tr.header.right != &tr.header {
+			t.Fatalf("empty tree has size %d and unreset header", tr.size)
+		}
+		return
+	}
+	if root.color != black || root.parent != &tr.header {
+		t.Fatalf("root is not black or does not link back to the header")
+	}
+
+	n := 0
+	var walk func(x *rbNode[K, V]) int
+	walk = func(x *rbNode[K, V]) int {
+		if x == nil {
+			return 1
+		}
+		n++
+		for _, c := range []*rbNode[K, V]{x.left, x.right} {
+			if c == nil {
+				continue
+			}
+			if c.parent != x {
+				t.Fatalf("child of %v does not link back to it", x.key)
+			}
+			if x.color == red && c.color == red {
+				t.Fatalf("red node %v has a red child %v", x.key, c.key)
+			}
+		}
+		if x.left != nil && tr.comp(x.key, x.left.key) || x.right != nil && tr.comp(x.right.key, x.key) {
+			t.Fatalf("children of %v are out of order", x.key)
+		}
+		l, r := walk(x.left), walk(x.right)
+		if l != r {
+			t.Fatalf("black heights below %v differ: %d and %d", x.key, l, r)
+		}
+		if x.color == black {
+			l++
+		}
+		return l
+	}
+	walk(root)
+	if n != tr.size {
+		t.Fatalf("tree has %d nodes but size %d", n, tr.size)
+	}
+
+	leftmost, rightmost := root, root
+	for leftmost.left != nil {
+		leftmost = leftmost.left
+	}
+	for rightmost.right != nil {
+		rightmost = rightmost.right
+	}
+	if tr.begin() != leftmost || tr.header.right != rightmost {
+		t.Fatalf("header does not link to the leftmost and rightmost nodes")
+	}
+
+	forward, backward := 0, 0
+	for x := tr.begin(); x != tr.end(); x = x.next() {
+		if next := x.next(); next != tr.end() && tr.comp(next.key, x.key) {
+			t.Fatalf("in-order traversal is out of order at %v", x.key)
+		}
+		forward++
+	}
+	for x := tr.end().prev(); ; x = x.prev() {
+		backward++
+		if x == tr.begin() {
+			break
+		}
+	}
+	if forward != tr.size || backward != tr.size {
+		t.Fatalf("traversals visit %d and %d nodes, want %d", forward, backward, tr.size)
+	}
+}
+
+func ascending(n int) []int {
+	r := make([]int, n)
+	for i := range r {
+		r[i] = i
+	}
+	return r
+}
+
+func treeInputs() map[string][]int {
+	rng := rand.New(rand.NewSource(1))
+	zigzag := make([]int, 64)
+	for i := range zigzag {
+		if i%2 == 0 {
+			zigzag[i] = i / 2
+		} else {
+			zigzag[i] = 63 - i/2
+		}
+	}
+	dups := make([]int, 100)
+	for i := range dups {
+		dups[i] = rng.Intn(20)
+	}
+	descending := ascending(64)
+	slices.Reverse(descending)
+	return map[string][]int{
+		"empty":      nil,
+		"one":        {7},
+		"ascending":  ascending(64),
+		"descending": descending,
+		"zigzag":     zigzag,
+		"random":     rng.Perm(200),
+		"duplicates": dups,
+	}
+}
+
+func sortedUnique(r []int) []int {
+	r = slices.Clone(r)
+	slices.Sort(r)
+	return slices.Compact(r)
+}
+
+func TestTreeInsertErase(t *testing.T) {
+	for name, keys := range treeInputs() {
+		t.Run(name, func(t *testing.T) {
+			s := NewOrderedSet[int]()
+			for i, k := range keys {
+				it, inserted := s.Insert(k)
+				if it.Value() != k || inserted == slices.Contains(keys[:i], k) {
+					t.Fatalf("Insert(%d) = %v, %v", k, it.Value(), inserted)
+				}
+				checkTree(t, &s.t)
+			}
+			want := sortedUnique(keys)
+			if got := s.Values(); !slices.Equal(got, want) {
+				t.Fatalf("Values() = %v, want %v", got, want)
+			}
+
+			rng := rand.New(rand.NewSource(2))
+			order := slices.Clone(want)
+			rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+			for i, k := range order {
+				if n := s.EraseKey(k); n != 1 {
+					t.Fatalf("EraseKey(%d) = %d, want 1", k, n)
+				}
+				checkTree(t, &s.t)
+				if s.Size() != len(order)-i-1 || s.Contains(k) {
+					t.Fatalf("after EraseKey(%d): size %d, Contains = %v", k, s.Size(), s.Contains(k))
+				}
+			}
+			if !s.Empty() || s.Begin() != s.End() {
+				t.Fatalf("set is not empty after erasing every key")
+			}
+		})
+	}
+}
+
+func TestTreeMultiInsertErase(t *testing.T) {
+	for name, keys := range treeInputs() {
+		t.Run(name, func(t *testing.T) {
+			m := NewOrderedMultimap[int, int]()
+			for i, k := range keys {
+				if it := m.Insert(k, i); it.Key() != k || it.Value() != i {
+					t.Fatalf("Insert(%d, %d) = (%d, %d)", k, i, it.Key(), it.Value())
+				}
+				checkTree(t, &m.t)
+			}
+			want := slices.Clone(keys)
+			slices.Sort(want)
+			if got := m.Keys(); !slices.Equal(got, want) {
+				t.Fatalf("Keys() = %v, want %v", got, want)
+			}
+
+			// Equivalent keys keep their insertion order.
+			for _, k := range sortedUnique(keys) {
+				var got, want []int
+				for first, last := m.EqualRange(k); first != last; first = first.Next() {
+					got = append(got, first.Value())
+				}
+				for i, kk := range keys {
+					if kk == k {
+						want = append(want, i)
+					}
+				}
+				if !slices.Equal(got, want) || m.Count(k) != len(want) {
+					t.Fatalf("values of key %d = %v, want %v", k, got, want)
+				}
+			}
+
+			for it := m.Begin(); it != m.End(); {
+				it = m.Erase(it)
+				checkTree(t, &m.t)
+			}
+			if !m.Empty() {
+				t.Fatalf("multimap is not empty after erasing every element")
+			}
+		})
+	}
+}
+
+func TestTreeHint(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []int
+		hint func(s *OrderedSet[int], k int) SetIterator[int]
+	}{
+		{"end, ascending", ascending(100), func(s *OrderedSet[int], k int) SetIterator[int] { return s.End() }},
+		{"begin, ascending", ascending(100), func(s *OrderedSet[int], k int) SetIterator[int] { return s.Begin() }},
+		{"lower bound", rand.New(rand.NewSource(3)).Perm(100), func(s *OrderedSet[int], k int) SetIterator[int] { return s.LowerBound(k) }},
+		{"upper bound", rand.New(rand.NewSource(4)).Perm(100), func(s *OrderedSet[int], k int) SetIterator[int] { return s.UpperBound(k) }},
+		{"unrelated", rand.New(rand.NewSource(5)).Perm(100), func(s *OrderedSet[int], k int) SetIterator[int] { return s.Find(50) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOrderedSet[int]()
+			for _, k := range tt.keys {
+				if it := s.EmplaceHint(tt.hint(s, k), k); it.Value() != k {
+					t.Fatalf("EmplaceHint(%d) = %d", k, it.Value())
+				}
+				checkTree(t, &s.t)
+			}
+			// An existing key is not inserted again, whatever the hint.
+			for _, k := range tt.keys {
+				if it := s.EmplaceHint(tt.hint(s, k), k); it.Value() != k || s.Size() != len(tt.keys) {
+					t.Fatalf("EmplaceHint(%d) of an existing key = %d with size %d", k, it.Value(), s.Size())
+				}
+			}
+			checkTree(t, &s.t)
+			if got := s.Values(); !slices.Equal(got, ascending(len(tt.keys))) {
+				t.Fatalf("Values() = %v", got)
+			}
+
+			ms := NewOrderedMultiset[int]()
+			for _, k := range tt.keys {
+				hint := ms.End()
+				if tt.name != "end, ascending" {
+					hint = ms.LowerBound(k)
+				}
+				ms.EmplaceHint(hint, k)
+				ms.EmplaceHint(hint, k)
+				checkTree(t, &ms.t)
+			}
+			if ms.Size() != 2*len(tt.keys) {
+				t.Fatalf("multiset size = %d, want %d", ms.Size(), 2*len(tt.keys))
+			}
+		})
+	}
+}
+
+func TestTreeEraseRange(t *testing.T) {
+	tests := []struct {
+		n, first, last int
+	}{
+		{0, 0, 0},
+		{10, 0, 10},
+		{10, 0, 0},
+		{10, 3, 7},
+		{10, 0, 5},
+		{10, 5, 10},
+		{100, 1, 99},
+		{100, 40, 41},
+	}
+	for _, tt := range tests {
+		s := NewOrderedSet[int]()
+		s.InsertRange(ascending(tt.n), 0, tt.n)
+		first, last := s.LowerBound(tt.first), s.LowerBound(tt.last)
+		if got := s.EraseRange(first, last); got != last {
+			t.Errorf("EraseRange(%d, %d) of %d elements did not return last", tt.first, tt.last, tt.n)
+		}
+		checkTree(t, &s.t)
+		want := append(ascending(tt.first), ascending(tt.n)[tt.last:]...)
+		if got := s.Values(); !slices.Equal(got, want) {
+			t.Errorf("EraseRange(%d, %d) of %d elements left %v, want %v", tt.first, tt.last, tt.n, got, want)
+		}
+	}
+}
+
+func TestTreeMerge(t *testing.T) {
+	tests := []struct {
+		dst, src, wantDst, wantSrc []int
+	}{
+		{nil, nil, nil, nil},
+		{nil, []int{1, 2}, []int{1, 2}, nil},
+		{[]int{1, 2}, nil, []int{1, 2}, nil},
+		{[]int{1, 3, 5}, []int{2, 3, 4, 5, 6}, []int{1, 2, 3, 4, 5, 6}, []int{3, 5}},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, []int{1, 2, 3}, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		dst, src := NewOrderedMap[int, string](), NewOrderedMap[int, string]()
+		for _, k := range tt.dst {
+			dst.Insert(k, "dst")
+		}
+		its := make(map[int]MapIterator[int, string])
+		for _, k := range tt.src {
+			its[k], _ = src.Insert(k, "src")
+		}
+		dst.Merge(src)
+		checkTree(t, &dst.t)
+		checkTree(t, &src.t)
+		if got := dst.Keys(); !slices.Equal(got, tt.wantDst) {
+			t.Errorf("Merge(%v, %v): dst = %v, want %v", tt.dst, tt.src, got, tt.wantDst)
+		}
+		if got := src.Keys(); !slices.Equal(got, tt.wantSrc) {
+			t.Errorf("Merge(%v, %v): src = %v, want %v", tt.dst, tt.src, got, tt.wantSrc)
+		}
+
+		// Iterators to moved elements now refer into dst; the others still
+		// refer into src.
+		for k, it := range its {
+			in := dst
+			if slices.Contains(tt.wantSrc, k) {
+				in = src
+			}
+			if it.Key() != k || it.Value() != "src" || in.Find(k) != it {
+				t.Errorf("Merge(%v, %v): iterator to %d is no longer valid", tt.dst, tt.src, k)
+			}
+		}
+	}
+
+	dst, src := NewOrderedMultiset[int](), NewOrderedMultiset[int]()
+	dst.InsertRange([]int{1, 2, 2, 3}, 0, 4)
+	src.InsertRange([]int{2, 3, 3, 4}, 0, 4)
+	dst.Merge(src)
+	checkTree(t, &dst.t)
+	if got := dst.Values(); !slices.Equal(got, []int{1, 2, 2, 2, 3, 3, 3, 4}) || !src.Empty() {
+		t.Errorf("multiset Merge: dst = %v, src size %d", got, src.Size())
+	}
+}
+
+func TestTreeIteratorStability(t *testing.T) {
+	s := NewOrderedSet[int]()
+	its := make([]SetIterator[int], 200)
+	for _, k := range rand.New(rand.NewSource(6)).Perm(len(its)) {
+		its[k], _ = s.Insert(k)
+	}
+	// Erasing elements, inserting others and rebalancing the tree leaves
+	// iterators to the remaining elements valid.
+	for k := 0; k < len(its); k += 3 {
+		s.Erase(its[k])
+	}
+	for k := len(its); k < 2*len(its); k++ {
+		s.Insert(k)
+	}
+	checkTree(t, &s.t)
+	for k, it := range its {
+		if k%3 == 0 {
+			continue
+		}
+		if it.Value() != k || s.Find(k) != it {
+			t.Fatalf("iterator to %d is no longer valid", k)
+		}
+		want := k + 1
+		if want%3 == 0 {
+			want++
+		}
+		if next := it.Next(); next.Value() != want {
+			t.Fatalf("Next of %d = %d, want %d", k, next.Value(), want)
+		}
+	}
+
+	// A node handle keeps its element across extraction and reinsertion.
+	nh := s.ExtractKey(10)
+	checkTree(t, &s.t)
+	if nh.Empty() || nh.Value() != 10 || s.Contains(10) {
+		t.Fatalf("ExtractKey(10) = %v", nh)
+	}
+	if ret := s.InsertNode(nh); !ret.Inserted || ret.Position != its[10] {
+		t.Fatalf("InsertNode did not reinsert the extracted node")
+	}
+	checkTree(t, &s.t)
+}
+
+func TestTreeSwapClear(t *testing.T) {
+	a, b := NewOrderedSet[int](), NewOrderedSetFunc(func(x, y int) bool { return x > y })
+	a.InsertRange([]int{1, 2, 3}, 0, 3)
+	it := a.Begin()
+	a.Swap(b)
+	checkTree(t, &a.t)
+	checkTree(t, &b.t)
+	if !a.Empty() || !slices.Equal(b.Values(), []int{1, 2, 3}) || b.Begin() != it {
+		t.Fatalf("Swap: a = %v, b = %v", a.Values(), b.Values())
+	}
+	// The comparison functions are exchanged with the contents.
+	a.InsertRange([]int{1, 2, 3}, 0, 3)
+	if !slices.Equal(a.Values(), []int{3, 2, 1}) {
+		t.Fatalf("Swap did not exchange the comparison functions: a = %v", a.Values())
+	}
+	b.Clear()
+	checkTree(t, &b.t)
+	if !b.Empty() || b.Begin() != b.End() {
+		t.Fatalf("Clear left %v", b.Values())
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	if _, ok := m.Get("a"); ok {
+		t.Errorf("Get of a missing key reported ok")
+	}
+	if _, ok := m.InsertOrAssign("b", 1); !ok {
+		t.Errorf("InsertOrAssign of a new key did not insert")
+	}
+	if it, ok := m.InsertOrAssign("b", 2); ok || it.Value() != 2 {
+		t.Errorf("InsertOrAssign of an existing key = %d, %v", it.Value(), ok)
+	}
+	if it, ok := m.Insert("b", 3); ok || it.Value() != 2 {
+		t.Errorf("Insert of an existing key = %d, %v", it.Value(), ok)
+	}
+	m.InsertRange([]string{"c", "a", "c"}, []int{3, 1, 4}, 0, 3)
+	checkTree(t, &m.t)
+	if got := m.Keys(); !slices.Equal(got, []string{"a", "b", "c"}) || m.At("c") != 3 {
+		t.Errorf("Keys() = %v, At(c) = %d", got, m.At("c"))
+	}
+	if first, last := m.EqualRange("b"); first.Key() != "b" || last.Key() != "c" || m.Count("b") != 1 {
+		t.Errorf("EqualRange(b) = [%s, %s)", first.Key(), last.Key())
+	}
+	if m.LowerBound("bb").Key() != "c" || m.UpperBound("c") != m.End() || m.End().Prev().Key() != "c" {
+		t.Errorf("LowerBound or UpperBound returned the wrong element")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At of a missing key did not panic")
+		}
+	}()
+	m.At("z")
+}
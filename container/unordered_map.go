@@ -0,0 +1,383 @@
+package container
+
+import "fmt"
+
+// UnorderedMapIterator points to an element of an UnorderedMap or
+// UnorderedMultimap, or past the last one. Iterators are invalidated by
+// rehashing, but the elements themselves are never moved. Iterators are
+// comparable with ==.
+type UnorderedMapIterator[K, V any] struct {
+	h *hashtable[K, V]
+	n *hashNode[K, V]
+	b int
+}
+
+// Returns the key of the element the iterator points to.
+func (it UnorderedMapIterator[K, V]) Key() K {
+	return it.n.key
+}
+
+// Returns the mapped value of the element the iterator points to.
+func (it UnorderedMapIterator[K, V]) Value() V {
+	return it.n.value
+}
+
+// Replaces the mapped value of the element the iterator points to.
+func (it UnorderedMapIterator[K, V]) SetValue(v V) {
+	it.n.value = v
+}
+
+// Returns an iterator to the next element.
+func (it UnorderedMapIterator[K, V]) Next() UnorderedMapIterator[K, V] {
+	n, b := it.h.advance(it.n, it.b)
+	return UnorderedMapIterator[K, V]{it.h, n, b}
+}
+
+// UnorderedMap is an associative container that contains key-value pairs with
+// unique keys, like std::unordered_map. Elements are organized into buckets by
+// the hash of their key, and keys are compared with the equality function, so
+// keys need not be comparable. Search, insertion and removal have average
+// constant-time complexity.
+type UnorderedMap[K, V any] struct {
+	h *hashtable[K, V]
+}
+
+// Constructs an empty map that hashes keys with hash and compares them with
+// equal. Keys that are equal must have the same hash.
+func NewUnorderedMap[K, V any](hash func(K) uint64, equal func(K, K) bool) *UnorderedMap[K, V] {
+	return &UnorderedMap[K, V]{newHashtable[K, V](hash, equal)}
+}
+
+// Returns the function that hashes the keys.
+func (m *UnorderedMap[K, V]) HashFunction() func(K) uint64 {
+	return m.h.hash
+}
+
+// Returns the function that compares keys for equality.
+func (m *UnorderedMap[K, V]) KeyEq() func(K, K) bool {
+	return m.h.equal
+}
+
+// Returns an iterator to the first element of the map.
+func (m *UnorderedMap[K, V]) Begin() UnorderedMapIterator[K, V] {
+	n, b := m.h.first(0)
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Returns an iterator to the element following the last element of the map.
+func (m *UnorderedMap[K, V]) End() UnorderedMapIterator[K, V] {
+	return UnorderedMapIterator[K, V]{h: m.h}
+}
+
+// Checks if the map has no elements.
+func (m *UnorderedMap[K, V]) Empty() bool {
+	return m.h.size == 0
+}
+
+// Returns the number of elements in the map.
+func (m *UnorderedMap[K, V]) Size() int {
+	return m.h.size
+}
+
+// Erases all elements from the map. The bucket count is unchanged.
+func (m *UnorderedMap[K, V]) Clear() {
+	m.h.clear()
+}
+
+// Returns the value mapped to key k. Panics if there is no such element.
+func (m *UnorderedMap[K, V]) At(k K) V {
+	n, _ := m.h.find(k)
+	if n == nil {
+		panic(fmt.Sprintf("container: UnorderedMap.At: key %v not found", k))
+	}
+	return n.value
+}
+
+// Returns the value mapped to key k and whether such an element exists.
+func (m *UnorderedMap[K, V]) Get(k K) (V, bool) {
+	n, _ := m.h.find(k)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Inserts the element (k, v) if the map doesn't already contain an element
+// with an equivalent key. Returns an iterator to the element with key k and
+// whether the insertion took place.
+func (m *UnorderedMap[K, V]) Insert(k K, v V) (UnorderedMapIterator[K, V], bool) {
+	n, b, ok := m.h.insertUnique(k, v)
+	return UnorderedMapIterator[K, V]{m.h, n, b}, ok
+}
+
+// Inserts the elements of keys[first, last) and values[first, last). If
+// multiple keys in the range are equivalent, only the first one is inserted.
+func (m *UnorderedMap[K, V]) InsertRange(keys []K, values []V, first, last int) {
+	for ; first != last; first++ {
+		m.h.insertUnique(keys[first], values[first])
+	}
+}
+
+// Inserts the element (k, v) if the key does not exist, otherwise assigns v to
+// the element with key k. Returns an iterator to the element and whether an
+// insertion took place.
+func (m *UnorderedMap[K, V]) InsertOrAssign(k K, v V) (UnorderedMapIterator[K, V], bool) {
+	n, b, ok := m.h.insertUnique(k, v)
+	if !ok {
+		n.value = v
+	}
+	return UnorderedMapIterator[K, V]{m.h, n, b}, ok
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (m *UnorderedMap[K, V]) Erase(pos UnorderedMapIterator[K, V]) UnorderedMapIterator[K, V] {
+	n, b := m.h.unlink(pos.n, pos.b)
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Removes the element with key equivalent to k, if any, and returns the
+// number of elements removed.
+func (m *UnorderedMap[K, V]) EraseKey(k K) int {
+	return m.h.eraseKey(k)
+}
+
+// Exchanges the contents of the map with those of other. Iterators remain
+// valid and refer to the same elements, now in the other container.
+func (m *UnorderedMap[K, V]) Swap(other *UnorderedMap[K, V]) {
+	m.h, other.h = other.h, m.h
+}
+
+// Returns the number of elements with key equivalent to k, which is either 1
+// or 0.
+func (m *UnorderedMap[K, V]) Count(k K) int {
+	if m.Contains(k) {
+		return 1
+	}
+	return 0
+}
+
+// Finds an element with key equivalent to k. Returns End() if there is no such
+// element.
+func (m *UnorderedMap[K, V]) Find(k K) UnorderedMapIterator[K, V] {
+	n, b := m.h.find(k)
+	if n == nil {
+		return m.End()
+	}
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Checks if there is an element with key equivalent to k in the map.
+func (m *UnorderedMap[K, V]) Contains(k K) bool {
+	n, _ := m.h.find(k)
+	return n != nil
+}
+
+// Returns a range containing all elements with key equivalent to k. If there
+// are no such elements, both iterators are End().
+func (m *UnorderedMap[K, V]) EqualRange(k K) (UnorderedMapIterator[K, V], UnorderedMapIterator[K, V]) {
+	first, fb, last, lb := m.h.equalRange(k)
+	return UnorderedMapIterator[K, V]{m.h, first, fb}, UnorderedMapIterator[K, V]{m.h, last, lb}
+}
+
+// Returns the number of buckets in the map.
+func (m *UnorderedMap[K, V]) BucketCount() int {
+	return len(m.h.buckets)
+}
+
+// Returns the number of elements in the bucket with index n.
+func (m *UnorderedMap[K, V]) BucketSize(n int) int {
+	return m.h.bucketSize(n)
+}
+
+// Returns the index of the bucket for key k.
+func (m *UnorderedMap[K, V]) Bucket(k K) int {
+	return m.h.bucket(m.h.hash(k))
+}
+
+// Returns the average number of elements per bucket.
+func (m *UnorderedMap[K, V]) LoadFactor() float64 {
+	return m.h.loadFactor()
+}
+
+// Returns the maximum load factor. The map automatically increases the number
+// of buckets if the load factor would exceed it.
+func (m *UnorderedMap[K, V]) MaxLoadFactor() float64 {
+	return m.h.maxLoadFactor
+}
+
+// Sets the maximum load factor to ml, rehashing if the current load factor
+// exceeds it. Panics if ml is not a positive finite number.
+func (m *UnorderedMap[K, V]) SetMaxLoadFactor(ml float64) {
+	m.h.setMaxLoadFactor("UnorderedMap", ml)
+}
+
+// Changes the number of buckets to a value not less than count and not less
+// than Size() / MaxLoadFactor(), and rehashes the map.
+func (m *UnorderedMap[K, V]) Rehash(count int) {
+	m.h.rehash(count)
+}
+
+// Sets the number of buckets to the number needed to accommodate at least
+// count elements without exceeding the maximum load factor, and rehashes the
+// map.
+func (m *UnorderedMap[K, V]) Reserve(count int) {
+	m.h.reserve(count)
+}
+
+// UnorderedMultimap is an unordered associative container that contains
+// key-value pairs and permits multiple entries with the same key, like
+// std::unordered_multimap. Elements with equivalent keys are adjacent in
+// iteration order and keep their insertion order.
+type UnorderedMultimap[K, V any] struct {
+	h *hashtable[K, V]
+}
+
+// Constructs an empty multimap that hashes keys with hash and compares them
+// with equal. Keys that are equal must have the same hash.
+func NewUnorderedMultimap[K, V any](hash func(K) uint64, equal func(K, K) bool) *UnorderedMultimap[K, V] {
+	return &UnorderedMultimap[K, V]{newHashtable[K, V](hash, equal)}
+}
+
+// Returns the function that hashes the keys.
+func (m *UnorderedMultimap[K, V]) HashFunction() func(K) uint64 {
+	return m.h.hash
+}
+
+// Returns the function that compares keys for equality.
+func (m *UnorderedMultimap[K, V]) KeyEq() func(K, K) bool {
+	return m.h.equal
+}
+
+// Returns an iterator to the first element of the multimap.
+func (m *UnorderedMultimap[K, V]) Begin() UnorderedMapIterator[K, V] {
+	n, b := m.h.first(0)
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Returns an iterator to the element following the last element of the
+// multimap.
+func (m *UnorderedMultimap[K, V]) End() UnorderedMapIterator[K, V] {
+	return UnorderedMapIterator[K, V]{h: m.h}
+}
+
+// Checks if the multimap has no elements.
+func (m *UnorderedMultimap[K, V]) Empty() bool {
+	return m.h.size == 0
+}
+
+// Returns the number of elements in the multimap.
+func (m *UnorderedMultimap[K, V]) Size() int {
+	return m.h.size
+}
+
+// Erases all elements from the multimap. The bucket count is unchanged.
+func (m *UnorderedMultimap[K, V]) Clear() {
+	m.h.clear()
+}
+
+// Inserts the element (k, v) after any elements with equivalent keys and
+// returns an iterator to it.
+func (m *UnorderedMultimap[K, V]) Insert(k K, v V) UnorderedMapIterator[K, V] {
+	n, b := m.h.insertEqual(k, v)
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Inserts the elements of keys[first, last) and values[first, last).
+func (m *UnorderedMultimap[K, V]) InsertRange(keys []K, values []V, first, last int) {
+	for ; first != last; first++ {
+		m.h.insertEqual(keys[first], values[first])
+	}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (m *UnorderedMultimap[K, V]) Erase(pos UnorderedMapIterator[K, V]) UnorderedMapIterator[K, V] {
+	n, b := m.h.unlink(pos.n, pos.b)
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Removes all elements with key equivalent to k and returns the number of
+// elements removed.
+func (m *UnorderedMultimap[K, V]) EraseKey(k K) int {
+	return m.h.eraseKey(k)
+}
+
+// Exchanges the contents of the multimap with those of other. Iterators
+// remain valid and refer to the same elements, now in the other container.
+func (m *UnorderedMultimap[K, V]) Swap(other *UnorderedMultimap[K, V]) {
+	m.h, other.h = other.h, m.h
+}
+
+// Returns the number of elements with key equivalent to k.
+func (m *UnorderedMultimap[K, V]) Count(k K) int {
+	return m.h.count(k)
+}
+
+// Finds an element with key equivalent to k. If there are several, the first
+// one in iteration order is returned. Returns End() if there is no such
+// element.
+func (m *UnorderedMultimap[K, V]) Find(k K) UnorderedMapIterator[K, V] {
+	n, b := m.h.find(k)
+	if n == nil {
+		return m.End()
+	}
+	return UnorderedMapIterator[K, V]{m.h, n, b}
+}
+
+// Checks if there is an element with key equivalent to k in the multimap.
+func (m *UnorderedMultimap[K, V]) Contains(k K) bool {
+	n, _ := m.h.find(k)
+	return n != nil
+}
+
+// Returns a range containing all elements with key equivalent to k. If there
+// are no such elements, both iterators are End().
+func (m *UnorderedMultimap[K, V]) EqualRange(k K) (UnorderedMapIterator[K, V], UnorderedMapIterator[K, V]) {
+	first, fb, last, lb := m.h.equalRange(k)
+	return UnorderedMapIterator[K, V]{m.h, first, fb}, UnorderedMapIterator[K, V]{m.h, last, lb}
+}
+
+// Returns the number of buckets in the multimap.
+func (m *UnorderedMultimap[K, V]) BucketCount() int {
+	return len(m.h.buckets)
+}
+
+// Returns the number of elements in the bucket with index n.
+func (m *UnorderedMultimap[K, V]) BucketSize(n int) int {
+	return m.h.bucketSize(n)
+}
+
+// Returns the index of the bucket for key k.
+func (m *UnorderedMultimap[K, V]) Bucket(k K) int {
+	return m.h.bucket(m.h.hash(k))
+}
+
+// Returns the average number of elements per bucket.
+func (m *UnorderedMultimap[K, V]) LoadFactor() float64 {
+	return m.h.loadFactor()
+}
+
+// Returns the maximum load factor. The multimap automatically increases the
+// number of buckets if the load factor would exceed it.
+func (m *UnorderedMultimap[K, V]) MaxLoadFactor() float64 {
+	return m.h.maxLoadFactor
+}
+
+// Sets the maximum load factor to ml, rehashing if the current load factor
+// exceeds it. Panics if ml is not a positive finite number.
+func (m *UnorderedMultimap[K, V]) SetMaxLoadFactor(ml float64) {
+	m.h.setMaxLoadFactor("UnorderedMultimap", ml)
+}
+
+// Changes the number of buckets to a value not less than count and not less
+// than Size() / MaxLoadFactor(), and rehashes the multimap.
+func (m *UnorderedMultimap[K, V]) Rehash(count int) {
+	m.h.rehash(count)
+}
+
+// Sets the number of buckets to the number needed to accommodate at least
+// count elements without exceeding the maximum load factor, and rehashes the
+// multimap.
+func (m *UnorderedMultimap[K, V]) Reserve(count int) {
+	m.h.reserve(count)
+}
@@ -0,0 +1,337 @@
+package container
+
+// UnorderedSetIterator points to an element of an UnorderedSet or
+// UnorderedMultiset, or past the last one. Iterators are invalidated by
+// rehashing, but the elements themselves are never moved. Iterators are
+// comparable with ==.
+type UnorderedSetIterator[K any] struct {
+	h *hashtable[K, struct{}]
+	n *hashNode[K, struct{}]
+	b int
+}
+
+// Returns the element the iterator points to.
+func (it UnorderedSetIterator[K]) Value() K {
+	return it.n.key
+}
+
+// Returns an iterator to the next element.
+func (it UnorderedSetIterator[K]) Next() UnorderedSetIterator[K] {
+	n, b := it.h.advance(it.n, it.b)
+	return UnorderedSetIterator[K]{it.h, n, b}
+}
+
+// UnorderedSet is an associative container that contains a set of unique
+// objects, like std::unordered_set. Elements are organized into buckets by
+// their hash and compared with the equality function, so they need not be
+// comparable. Search, insertion and removal have average constant-time
+// complexity.
+type UnorderedSet[K any] struct {
+	h *hashtable[K, struct{}]
+}
+
+// Constructs an empty set that hashes elements with hash and compares them
+// with equal. Elements that are equal must have the same hash.
+func NewUnorderedSet[K any](hash func(K) uint64, equal func(K, K) bool) *UnorderedSet[K] {
+	return &UnorderedSet[K]{newHashtable[K, struct{}](hash, equal)}
+}
+
+// Returns the function that hashes the elements.
+func (s *UnorderedSet[K]) HashFunction() func(K) uint64 {
+	return s.h.hash
+}
+
+// Returns the function that compares elements for equality.
+func (s *UnorderedSet[K]) KeyEq() func(K, K) bool {
+	return s.h.equal
+}
+
+// Returns an iterator to the first element of the set.
+func (s *UnorderedSet[K]) Begin() UnorderedSetIterator[K] {
+	n, b := s.h.first(0)
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Returns an iterator to the element following the last element of the set.
+func (s *UnorderedSet[K]) End() UnorderedSetIterator[K] {
+	return UnorderedSetIterator[K]{h: s.h}
+}
+
+// Checks if the set has no elements.
+func (s *UnorderedSet[K]) Empty() bool {
+	return s.h.size == 0
+}
+
+// Returns the number of elements in the set.
+func (s *UnorderedSet[K]) Size() int {
+	return s.h.size
+}
+
+// Erases all elements from the set. The bucket count is unchanged.
+func (s *UnorderedSet[K]) Clear() {
+	s.h.clear()
+}
+
+// Inserts k if the set doesn't already contain an equivalent element. Returns
+// an iterator to the element equivalent to k and whether the insertion took
+// place.
+func (s *UnorderedSet[K]) Insert(k K) (UnorderedSetIterator[K], bool) {
+	n, b, ok := s.h.insertUnique(k, struct{}{})
+	return UnorderedSetIterator[K]{s.h, n, b}, ok
+}
+
+// Inserts the elements of r[first, last). If multiple elements in the range
+// are equivalent, only the first one is inserted.
+func (s *UnorderedSet[K]) InsertRange(r []K, first, last int) {
+	for ; first != last; first++ {
+		s.h.insertUnique(r[first], struct{}{})
+	}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (s *UnorderedSet[K]) Erase(pos UnorderedSetIterator[K]) UnorderedSetIterator[K] {
+	n, b := s.h.unlink(pos.n, pos.b)
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Removes the element equivalent to k, if any, and returns the number of
+// elements removed.
+func (s *UnorderedSet[K]) EraseKey(k K) int {
+	return s.h.eraseKey(k)
+}
+
+// Exchanges the contents of the set with those of other. Iterators remain
+// valid and refer to the same elements, now in the other container.
+func (s *UnorderedSet[K]) Swap(other *UnorderedSet[K]) {
+	s.h, other.h = other.h, s.h
+}
+
+// Returns the number of elements equivalent to k, which is either 1 or 0.
+func (s *UnorderedSet[K]) Count(k K) int {
+	if s.Contains(k) {
+		return 1
+	}
+	return 0
+}
+
+// Finds an element equivalent to k. Returns End() if there is no such element.
+func (s *UnorderedSet[K]) Find(k K) UnorderedSetIterator[K] {
+	n, b := s.h.find(k)
+	if n == nil {
+		return s.End()
+	}
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Checks if there is an element equivalent to k in the set.
+func (s *UnorderedSet[K]) Contains(k K) bool {
+	n, _ := s.h.find(k)
+	return n != nil
+}
+
+// Returns a range containing all elements equivalent to k. If there are no
+// such elements, both iterators are End().
+func (s *UnorderedSet[K]) EqualRange(k K) (UnorderedSetIterator[K], UnorderedSetIterator[K]) {
+	first, fb, last, lb := s.h.equalRange(k)
+	return UnorderedSetIterator[K]{s.h, first, fb}, UnorderedSetIterator[K]{s.h, last, lb}
+}
+
+// Returns the number of buckets in the set.
+func (s *UnorderedSet[K]) BucketCount() int {
+	return len(s.h.buckets)
+}
+
+// Returns the number of elements in the bucket with index n.
+func (s *UnorderedSet[K]) BucketSize(n int) int {
+	return s.h.bucketSize(n)
+}
+
+// Returns the index of the bucket for element k.
+func (s *UnorderedSet[K]) Bucket(k K) int {
+	return s.h.bucket(s.h.hash(k))
+}
+
+// Returns the average number of elements per bucket.
+func (s *UnorderedSet[K]) LoadFactor() float64 {
+	return s.h.loadFactor()
+}
+
+// Returns the maximum load factor. The set automatically increases the number
+// of buckets if the load factor would exceed it.
+func (s *UnorderedSet[K]) MaxLoadFactor() float64 {
+	return s.h.maxLoadFactor
+}
+
+// Sets the maximum load factor to ml, rehashing if the current load factor
+// exceeds it. Panics if ml is not a positive finite number.
+func (s *UnorderedSet[K]) SetMaxLoadFactor(ml float64) {
+	s.h.setMaxLoadFactor("UnorderedSet", ml)
+}
+
+// Changes the number of buckets to a value not less than count and not less
+// than Size() / MaxLoadFactor(), and rehashes the set.
+func (s *UnorderedSet[K]) Rehash(count int) {
+	s.h.rehash(count)
+}
+
+// Sets the number of buckets to the number needed to accommodate at least
+// count elements without exceeding the maximum load factor, and rehashes the
+// set.
+func (s *UnorderedSet[K]) Reserve(count int) {
+	s.h.reserve(count)
+}
+
+// UnorderedMultiset is an unordered associative container that contains a set
+// of objects and permits multiple equivalent elements, like
+// std::unordered_multiset. Equivalent elements are adjacent in iteration order
+// and keep their insertion order.
+type UnorderedMultiset[K any] struct {
+	h *hashtable[K, struct{}]
+}
+
+// Constructs an empty multiset that hashes elements with hash and compares
+// them with equal. Elements that are equal must have the same hash.
+func NewUnorderedMultiset[K any](hash func(K) uint64, equal func(K, K) bool) *UnorderedMultiset[K] {
+	return &UnorderedMultiset[K]{newHashtable[K, struct{}](hash, equal)}
+}
+
+// Returns the function that hashes the elements.
+func (s *UnorderedMultiset[K]) HashFunction() func(K) uint64 {
+	return s.h.hash
+}
+
+// Returns the function that compares elements for equality.
+func (s *UnorderedMultiset[K]) KeyEq() func(K, K) bool {
+	return s.h.equal
+}
+
+// Returns an iterator to the first element of the multiset.
+func (s *UnorderedMultiset[K]) Begin() UnorderedSetIterator[K] {
+	n, b := s.h.first(0)
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Returns an iterator to the element following the last element of the
+// multiset.
+func (s *UnorderedMultiset[K]) End() UnorderedSetIterator[K] {
+	return UnorderedSetIterator[K]{h: s.h}
+}
+
+// Checks if the multiset has no elements.
+func (s *UnorderedMultiset[K]) Empty() bool {
+	return s.h.size == 0
+}
+
+// Returns the number of elements in the multiset.
+func (s *UnorderedMultiset[K]) Size() int {
+	return s.h.size
+}
+
+// Erases all elements from the multiset. The bucket count is unchanged.
+func (s *UnorderedMultiset[K]) Clear() {
+	s.h.clear()
+}
+
+// Inserts k after any equivalent elements and returns an iterator to it.
+func (s *UnorderedMultiset[K]) Insert(k K) UnorderedSetIterator[K] {
+	n, b := s.h.insertEqual(k, struct{}{})
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Inserts the elements of r[first, last).
+func (s *UnorderedMultiset[K]) InsertRange(r []K, first, last int) {
+	for ; first != last; first++ {
+		s.h.insertEqual(r[first], struct{}{})
+	}
+}
+
+// Removes the element at pos and returns an iterator following it.
+func (s *UnorderedMultiset[K]) Erase(pos UnorderedSetIterator[K]) UnorderedSetIterator[K] {
+	n, b := s.h.unlink(pos.n, pos.b)
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Removes all elements equivalent to k and returns the number of elements
+// removed.
+func (s *UnorderedMultiset[K]) EraseKey(k K) int {
+	return s.h.eraseKey(k)
+}
+
+// Exchanges the contents of the multiset with those of other. Iterators
+// remain valid and refer to the same elements, now in the other container.
+func (s *UnorderedMultiset[K]) Swap(other *UnorderedMultiset[K]) {
+	s.h, other.h = other.h, s.h
+}
+
+// Returns the number of elements equivalent to k.
+func (s *UnorderedMultiset[K]) Count(k K) int {
+	return s.h.count(k)
+}
+
+// Finds an element equivalent to k. If there are several, the first one in
+// iteration order is returned. Returns End() if there is no such element.
+func (s *UnorderedMultiset[K]) Find(k K) UnorderedSetIterator[K] {
+	n, b := s.h.find(k)
+	if n == nil {
+		return s.End()
+	}
+	return UnorderedSetIterator[K]{s.h, n, b}
+}
+
+// Checks if there is an element equivalent to k in the multiset.
+func (s *UnorderedMultiset[K]) Contains(k K) bool {
+	n, _ := s.h.find(k)
+	return n != nil
+}
+
+// Returns a range containing all elements equivalent to k. If there are no
+// such elements, both iterators are End().
+func (s *UnorderedMultiset[K]) EqualRange(k K) (UnorderedSetIterator[K], UnorderedSetIterator[K]) {
+	first, fb, last, lb := s.h.equalRange(k)
+	return UnorderedSetIterator[K]{s.h, first, fb}, UnorderedSetIterator[K]{s.h, last, lb}
+}
+
+// Returns the number of buckets in the multiset.
+func (s *UnorderedMultiset[K]) BucketCount() int {
+	return len(s.h.buckets)
+}
+
+// Returns the number of elements in the bucket with index n.
+func (s *UnorderedMultiset[K]) BucketSize(n int) int {
+	return s.h.bucketSize(n)
+}
+
+// Returns the index of the bucket for element k.
+func (s *UnorderedMultiset[K]) Bucket(k K) int {
+	return s.h.bucket(s.h.hash(k))
+}
+
+// Returns the average number of elements per bucket.
+func (s *UnorderedMultiset[K]) LoadFactor() float64 {
+	return s.h.loadFactor()
+}
+
+// Returns the maximum load factor. The multiset automatically increases the
+// number of buckets if the load factor would exceed it.
+func (s *UnorderedMultiset[K]) MaxLoadFactor() float64 {
+	return s.h.maxLoadFactor
+}
+
+// Sets the maximum load factor to ml, rehashing if the current load factor
+// exceeds it. Panics if ml is not a positive finite number.
+func (s *UnorderedMultiset[K]) SetMaxLoadFactor(ml float64) {
+	s.h.setMaxLoadFactor("UnorderedMultiset", ml)
+}
+
+// Changes the number of buckets to a value not less than count and not less
+// than Size() / MaxLoadFactor(), and rehashes the multiset.
+func (s *UnorderedMultiset[K]) Rehash(count int) {
+	s.h.rehash(count)
+}
+
+// Sets the number of buckets to the number needed to accommodate at least
+// count elements without exceeding the maximum load factor, and rehashes the
+// multiset.
+func (s *UnorderedMultiset[K]) Reserve(count int) {
+	s.h.reserve(count)
+}
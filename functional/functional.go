@@ -0,0 +1,151 @@
+package functional
+
+import "cmp"
+
+// Function object for performing comparisons. Returns true if a is less than
+// b, like std::less. Passing Less to a *Func algorithm or container orders
+// elements in ascending order.
+func Less[T cmp.Ordered](a, b T) bool {
+	return a < b
+}
+
+// Function object for performing comparisons. Returns true if a is greater
+// than b, like std::greater. Passing Greater to a *Func algorithm or container
+// orders elements in descending order, and turns a max heap into a min heap.
+func Greater[T cmp.Ordered](a, b T) bool {
+	return a > b
+}
+
+// Function object for performing comparisons. Returns true if a is less than
+// or equal to b, like std::less_equal.
+func LessEqual[T cmp.Ordered](a, b T) bool {
+	return a <= b
+}
+
+// Function object for performing comparisons. Returns true if a is greater
+// than or equal to b, like std::greater_equal.
+func GreaterEqual[T cmp.Ordered](a, b T) bool {
+	return a >= b
+}
+
+// Function object for performing comparisons. Returns true if a is equal to
+// b, like std::equal_to.
+func EqualTo[T comparable](a, b T) bool {
+	return a == b
+}
+
+// Function object for performing comparisons. Returns true if a is not equal
+// to b, like std::not_equal_to.
+func NotEqualTo[T comparable](a, b T) bool {
+	return a != b
+}
+
+// Function object that returns its argument unchanged, like std::identity.
+func Identity[T any](x T) T {
+	return x
+}
+
+// Returns a predicate that holds the negation of the result of p, like
+// std::not_fn.
+func Not[T any](p func(T) bool) func(T) bool {
+	return func(x T) bool {
+		return !p(x)
+	}
+}
+
+// Returns a binary predicate that holds the negation of the result of p, like
+// std::not_fn.
+func Not2[T1, T2 any](p func(T1, T2) bool) func(T1, T2) bool {
+	return func(x T1, y T2) bool {
+		return !p(x, y)
+	}
+}
+
+// Binds the first argument of f to a, like std::bind_front. For example,
+// BindFront(Less[int], 5) is a predicate that holds for elements greater
+// than 5.
+func BindFront[T1, T2, R any](f func(T1, T2) R, a T1) func(T2) R {
+	return func(b T2) R {
+		return f(a, b)
+	}
+}
+
+// Binds the second argument of f to b, like std::bind_back. For example,
+// BindBack(Less[int], 5) is a predicate that holds for elements less than 5.
+func BindBack[T1, T2, R any](f func(T1, T2) R, b T2) func(T1) R {
+	return func(a T1) R {
+		return f(a, b)
+	}
+}
+
+// Returns the composition of f and g, the function that computes f(g(x)).
+func Compose[T1, T2, R any](f func(T2) R, g func(T1) T2) func(T1) R {
+	return func(x T1) R {
+		return f(g(x))
+	}
+}
+
+// Returns a comparator that orders elements by the key extracted by key,
+// using operator<.
+func ComparingBy[T any, K cmp.Ordered](key func(T) K) func(T, T) bool {
+	return func(a, b T) bool {
+		return key(a) < key(b)
+	}
+}
+
+// Returns a comparator that orders elements by the key extracted by key,
+// using the comparator comp on keys.
+func ComparingByFunc[T, K any](key func(T) K, comp func(K, K) bool) func(T, T) bool {
+	return func(a, b T) bool {
+		return comp(key(a), key(b))
+	}
+}
+
+// Returns a comparator that orders elements by comp, and elements that are
+// equivalent under comp by then. It builds multi-key comparators:
+//
+//	ThenComparing(ComparingBy(lastName), ComparingBy(firstName))
+func ThenComparing[T any](comp, then func(T, T) bool) func(T, T) bool {
+	return func(a, b T) bool {
+		if comp(a, b) {
+			return true
+		}
+		if comp(b, a) {
+			return false
+		}
+		return then(a, b)
+	}
+}
+
+// Returns a comparator that imposes the reverse of the ordering of comp.
+func Reversed[T any](comp func(T, T) bool) func(T, T) bool {
+	return func(a, b T) bool {
+		return comp(b, a)
+	}
+}
+
+// Returns a predicate that holds if all of ps hold, evaluated left to right
+// and stopping at the first one that does not. And() always holds.
+func And[T any](ps ...func(T) bool) func(T) bool {
+	return func(x T) bool {
+		for _, p := range ps {
+			if !p(x) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Returns a predicate that holds if any of ps holds, evaluated left to right
+// and stopping at the first one that does. Or() never holds.
+func Or[T any](ps ...func(T) bool) func(T) bool {
+	return func(x T) bool {
+		for _, p := range ps {
+			if p(x) {
+				return true
+			}
+		}
+		return false
+	}
+}
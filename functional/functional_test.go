@@ -0,0 +1,161 @@
+package functional
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestComparisons(t *testing.T) {
+	pairs := [][2]int{{1, 2}, {2, 2}, {3, 2}}
+	tests := []struct {
+		name string
+		f    func(a, b int) bool
+		want []bool
+	}{
+		{"Less", Less[int], []bool{true, false, false}},
+		{"Greater", Greater[int], []bool{false, false, true}},
+		{"LessEqual", LessEqual[int], []bool{true, true, false}},
+		{"GreaterEqual", GreaterEqual[int], []bool{false, true, true}},
+		{"EqualTo", EqualTo[int], []bool{false, true, false}},
+		{"NotEqualTo", NotEqualTo[int], []bool{true, false, true}},
+		{"Not2(Less)", Not2(Less[int]), []bool{false, true, true}},
+		{"Reversed(Less)", Reversed(Less[int]), []bool{false, false, true}},
+		{"Reversed(Greater)", Reversed(Greater[int]), []bool{true, false, false}},
+		{"ComparingBy(negate)", ComparingBy(func(x int) int { return -x }), []bool{false, false, true}},
+		{"ComparingByFunc(Identity, Greater)", ComparingByFunc(Identity[int], Greater[int]), []bool{false, false, true}},
+	}
+	for _, tt := range tests {
+		for i, p := range pairs {
+			if got := tt.f(p[0], p[1]); got != tt.want[i] {
+				t.Errorf("%s(%d, %d) = %v, want %v", tt.name, p[0], p[1], got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+	positive := func(x int) bool { return x > 0 }
+	args := []int{-2, -1, 0, 1, 2, 6}
+	tests := []struct {
+		name string
+		p    func(int) bool
+		want []bool
+	}{
+		{"Not(even)", Not(even), []bool{false, true, false, true, false, false}},
+		{"BindFront(Less, 0)", BindFront(Less[int], 0), []bool{false, false, false, true, true, true}},
+		{"BindBack(Less, 0)", BindBack(Less[int], 0), []bool{true, true, false, false, false, false}},
+		{"BindBack(EqualTo, 2)", BindBack(EqualTo[int], 2), []bool{false, false, false, false, true, false}},
+		{"And()", And[int](), []bool{true, true, true, true, true, true}},
+		{"And(even, positive)", And(even, positive), []bool{false, false, false, false, true, true}},
+		{"Or()", Or[int](), []bool{false, false, false, false, false, false}},
+		{"Or(even, positive)", Or(even, positive), []bool{true, false, true, true, true, true}},
+		{"Compose(even, square)", Compose(even, func(x int) int { return x * x }), []bool{true, false, true, false, true, true}},
+		{"Compose(Not(even), Identity)", Compose(Not(even), Identity[int]), []bool{false, true, false, true, false, false}},
+	}
+	for _, tt := range tests {
+		for i, x := range args {
+			if got := tt.p(x); got != tt.want[i] {
+				t.Errorf("%s(%d) = %v, want %v", tt.name, x, got, tt.want[i])
+			}
+		}
+	}
+
+	if got := Compose(strconv.Itoa, func(x int) int { return x + 1 })(41); got != "42" {
+		t.Errorf("Compose(Itoa, inc)(41) = %q", got)
+	}
+	if got := Identity("x"); got != "x" {
+		t.Errorf("Identity(x) = %q", got)
+	}
+}
+
+func TestShortCircuit(t *testing.T) {
+	var calls []int
+	p := func(i int, result bool) func(int) bool {
+		return func(int) bool {
+			calls = append(calls, i)
+			return result
+		}
+	}
+	tests := []struct {
+		name  string
+		p     func(int) bool
+		want  bool
+		calls int
+	}{
+		{"And stops at false", And(p(0, true), p(1, false), p(2, true)), false, 2},
+		{"And evaluates all", And(p(0, true), p(1, true)), true, 2},
+		{"Or stops at true", Or(p(0, false), p(1, true), p(2, false)), true, 2},
+		{"Or evaluates all", Or(p(0, false), p(1, false)), false, 2},
+	}
+	for _, tt := range tests {
+		calls = nil
+		if got := tt.p(0); got != tt.want || len(calls) != tt.calls {
+			t.Errorf("%s: got %v after calls %v, want %v after %d calls", tt.name, got, calls, tt.want, tt.calls)
+		}
+		for i, c := range calls {
+			if c != i {
+				t.Errorf("%s: predicates called in order %v, want left to right", tt.name, calls)
+				break
+			}
+		}
+	}
+}
+
+type person struct {
+	last, first string
+	age         int
+}
+
+func TestComposedComparators(t *testing.T) {
+	byLast := ComparingBy(func(p person) string { return p.last })
+	byFirst := ComparingBy(func(p person) string { return p.first })
+	byAge := ComparingBy(func(p person) int { return p.age })
+
+	ada := person{"Lovelace", "Ada", 36}
+	alan := person{"Turing", "Alan", 41}
+	annie := person{"Turing", "Annie", 30}
+	ada2 := person{"Lovelace", "Ada", 20}
+
+	tests := []struct {
+		name string
+		comp func(a, b person) bool
+		a, b person
+		want bool
+	}{
+		{"last", byLast, alan, annie, false},
+		{"last, first", ThenComparing(byLast, byFirst), alan, annie, true},
+		{"last, first", ThenComparing(byLast, byFirst), annie, alan, false},
+		{"last, first", ThenComparing(byLast, byFirst), ada, alan, true},
+		{"last, first", ThenComparing(byLast, byFirst), alan, ada, false},
+		{"last, first", ThenComparing(byLast, byFirst), ada, ada2, false},
+		{"last, first, age", ThenComparing(ThenComparing(byLast, byFirst), byAge), ada2, ada, true},
+		{"last, first, age", ThenComparing(byLast, ThenComparing(byFirst, byAge)), ada2, ada, true},
+		{"last, then reversed first", ThenComparing(byLast, Reversed(byFirst)), annie, alan, true},
+		{"reversed last, first", Reversed(ThenComparing(byLast, byFirst)), alan, ada, true},
+		{"age", byAge, annie, alan, true},
+		{"age by greater", ComparingByFunc(func(p person) int { return p.age }, Greater[int]), annie, alan, false},
+		{"bound", func(a, b person) bool { return BindFront(byAge, a)(b) }, annie, alan, true},
+	}
+	for _, tt := range tests {
+		if got := tt.comp(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s(%v, %v) = %v, want %v", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	// A composed comparator is still a strict weak ordering: it is
+	// irreflexive and never holds both ways.
+	all := []person{ada, alan, annie, ada2}
+	for _, tt := range tests {
+		for _, a := range all {
+			if tt.comp(a, a) {
+				t.Errorf("%s(%v, %v) = true", tt.name, a, a)
+			}
+			for _, b := range all {
+				if tt.comp(a, b) && tt.comp(b, a) {
+					t.Errorf("%s holds both ways for %v and %v", tt.name, a, b)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Gocppvet reports common misuse of the algorithms of packages
+// gocpp/algorithm and gocpp/algorithm/safe. See package gocppvet for the
+// checks it makes.
+//
+// Usage:
+//
+//	gocppvet [-flag] [package ...]
+//
+// Gocppvet can also be run by go vet, along with its own checks:
+//
+//	go install gocpp/gocppvet/cmd/gocppvet
+//	go vet -vettool=$(which gocppvet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"gocpp/gocppvet"
+)
+
+func main() {
+	singlechecker.Main(gocppvet.Analyzer)
+}
@@ -0,0 +1,595 @@
+// Package gocppvet defines an Analyzer that reports common misuse of the
+// algorithms of packages gocpp/algorithm and gocpp/algorithm/safe.
+//
+// # Analyzer gocppvet
+//
+// gocppvet: report common misuse of the gocpp algorithms
+//
+// The analyzer reports:
+//
+//   - calls to Remove, RemoveIf, Unique and UniqueFunc whose result is not
+//     used. These algorithms do not erase anything: they return the new end
+//     of the range, and the elements after it are left over, so the result
+//     must be used to shrink the range, as in r = r[:Remove(r, 0, len(r), x)].
+//     Assigning the result to _ marks it as deliberately ignored;
+//   - results of Find, FindIf, FindIfNot, AdjacentFind, Search and SearchN,
+//     or variables holding them, compared with == or != against a negative
+//     constant such as -1, or against len(r) or End(r) when last is
+//     something else. These algorithms return last when they find nothing;
+//   - calls whose first and last arguments are constants with first > last,
+//     and calls of Rotate and RotateCopy whose middle is not within
+//     [first, last);
+//   - calls of LowerBound, UpperBound and their Func variants on a slice
+//     declared in the calling function that the function does not sort, or
+//     check for being sorted, earlier. Slices that are parameters or are
+//     declared outside of the function are not reported. Slices that the
+//     function fills in order, without sorting them, are, so such a search
+//     is best preceded by a check with slices.IsSorted;
+//   - comparators of the Func algorithms that return a <= b, a >= b, a == b,
+//     a != b or their negated forms, such as !(b < a), including
+//     functional.LessEqual, GreaterEqual, EqualTo and NotEqualTo. A
+//     comparator must be a strict weak ordering, so comp(a, a) must be false
+//     and comp(a, b) and comp(b, a) must not both be true.
+//
+// Test files are not checked. Tests break the rules above on purpose: they
+// pass invalid ranges and comparators to check the precondition checks of
+// debug builds, call the algorithms only to count the calls they make, and
+// search inputs that they build in order.
+//
+// The analyzer is in a module of its own, so that the algorithms do not
+// depend on golang.org/x/tools. It can be run with cmd/gocppvet, either on its
+// own or as a vet tool:
+//
+//	go vet -vettool=$(which gocppvet) ./...
+package gocppvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `report common misuse of the gocpp algorithms
+
+The gocppvet analyzer reports ignored results of Remove, RemoveIf, Unique
+and UniqueFunc, results of Find and similar algorithms compared against -1
+or a length other than last, constant ranges with first > last, binary
+searches of local slices that were not sorted earlier in the same function,
+and comparators of the Func algorithms that are not strict, such as a <= b.`
+
+// Analyzer reports common misuse of the gocpp algorithms.
+var Analyzer = &analysis.Analyzer{
+	Name:     "gocppvet",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/gocpp/gocppvet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// The packages whose algorithms are checked.
+var algorithmPkgs = map[string]bool{
+	"gocpp/algorithm":      true,
+	"gocpp/algorithm/safe": true,
+}
+
+// The algorithms whose result is the new end of their range.
+var newEnd = map[string]bool{
+	"Remove":     true,
+	"RemoveIf":   true,
+	"Unique":     true,
+	"UniqueFunc": true,
+}
+
+// The algorithms that return their last argument when they find nothing.
+var finders = map[string]bool{
+	"Find":             true,
+	"FindIf":           true,
+	"FindIfNot":        true,
+	"AdjacentFind":     true,
+	"AdjacentFindFunc": true,
+	"Search":           true,
+	"SearchFunc":       true,
+	"SearchN":          true,
+	"SearchNFunc":      true,
+}
+
+// The algorithms that require a sorted range.
+var binarySearches = map[string]bool{
+	"LowerBound":     true,
+	"LowerBoundFunc": true,
+	"UpperBound":     true,
+	"UpperBoundFunc": true,
+}
+
+// The comparison functions that are not strict weak orderings, by package path
+// and name, with the operator they apply.
+var nonStrictFuncs = map[string]string{
+	"gocpp/functional.LessEqual":    "<=",
+	"gocpp/functional.GreaterEqual": ">=",
+	"gocpp/functional.EqualTo":      "==",
+	"gocpp/functional.NotEqualTo":   "!=",
+}
+
+// The functions that sort their first argument, or check that it is sorted,
+// by package path and name.
+var sorters = map[string]bool{
+	"gocpp/algorithm.SortHeap":          true,
+	"gocpp/algorithm.SortHeapFunc":      true,
+	"gocpp/algorithm/safe.SortHeap":     true,
+	"gocpp/algorithm/safe.SortHeapFunc": true,
+	"slices.Sort":                       true,
+	"slices.SortFunc":                   true,
+	"slices.SortStableFunc":             true,
+	"slices.IsSorted":                   true,
+	"slices.IsSortedFunc":               true,
+	"sort.Ints":                         true,
+	"sort.Strings":                      true,
+	"sort.Float64s":                     true,
+	"sort.Slice":                        true,
+	"sort.SliceStable":                  true,
+	"sort.Sort":                         true,
+	"sort.Stable":                       true,
+	"sort.IntsAreSorted":                true,
+	"sort.StringsAreSorted":             true,
+	"sort.Float64sAreSorted":            true,
+	"sort.SliceIsSorted":                true,
+	"sort.IsSorted":                     true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	results := findResults(pass, inspect)
+	decls := funcDecls(pass)
+
+	nodeFilter := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push || isTest(pass, n) {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			checkUnused(pass, n)
+		case *ast.BinaryExpr:
+			checkFindComparison(pass, results, n)
+		case *ast.CallExpr:
+			fn := algorithmFunc(pass.TypesInfo, n)
+			if fn == nil {
+				return true
+			}
+			checkRanges(pass, fn, n)
+			checkComparator(pass, decls, fn, n)
+			if binarySearches[fn.Name()] {
+				checkSorted(pass, fn, n, stack)
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// isTest reports whether n is in a test file.
+func isTest(pass *analysis.Pass, n ast.Node) bool {
+	return strings.HasSuffix(pass.Fset.File(n.Pos()).Name(), "_test.go")
+}
+
+// algorithmFunc returns the algorithm called by call, or nil if call does not
+// call a function of the algorithm packages.
+func algorithmFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, _ := typeutil.Callee(info, call).(*types.Func)
+	if fn == nil || fn.Pkg() == nil || !algorithmPkgs[fn.Pkg().Path()] {
+		return nil
+	}
+	if fn.Type().(*types.Signature).Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+// qualifiedName returns the name of fn qualified with its package name, such
+// as algorithm.Find.
+func qualifiedName(fn *types.Func) string {
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+// arg returns the argument of call for the parameter of fn with the given
+// name, or nil if fn has no such parameter.
+func arg(fn *types.Func, call *ast.CallExpr, name string) ast.Expr {
+	params := fn.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		if params.At(i).Name() == name && i < len(call.Args) {
+			return call.Args[i]
+		}
+	}
+	return nil
+}
+
+// checkUnused reports a call of an algorithm that returns the new end of its
+// range as a statement.
+func checkUnused(pass *analysis.Pass, stmt *ast.ExprStmt) {
+	call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fn := algorithmFunc(pass.TypesInfo, call)
+	if fn == nil || !newEnd[fn.Name()] {
+		return
+	}
+	pass.Reportf(call.Pos(), "result of %s is not used: it returns the new end of the range, and the elements after it must be erased", qualifiedName(fn))
+}
+
+// findResult is the origin of a value returned by a finder.
+type findResult struct {
+	fn   *types.Func
+	last ast.Expr // the last argument of the call
+}
+
+// findResults returns the variables that are only ever assigned results of
+// finders with the same last argument.
+func findResults(pass *analysis.Pass, inspect *inspector.Inspector) map[types.Object]findResult {
+	results := make(map[types.Object]findResult)
+	invalid := make(map[types.Object]bool)
+	assign := func(lhs ast.Expr, rhs ast.Expr) {
+		id, ok := ast.Unparen(lhs).(*ast.Ident)
+		if !ok || id.Name == "_" {
+			return
+		}
+		obj := pass.TypesInfo.ObjectOf(id)
+		if obj == nil || invalid[obj] {
+			return
+		}
+		res, ok := finderCall(pass.TypesInfo, rhs)
+		if prev, seen := results[obj]; !ok || seen && !sameValue(pass.TypesInfo, prev.last, res.last) {
+			delete(results, obj)
+			invalid[obj] = true
+			return
+		}
+		results[obj] = res
+	}
+	invalidate := func(e ast.Expr) {
+		if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+			if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+				delete(results, obj)
+				invalid[obj] = true
+			}
+		}
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.UnaryExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.ASSIGN && n.Tok != token.DEFINE {
+				for _, lhs := range n.Lhs {
+					invalidate(lhs)
+				}
+				return
+			}
+			assignAll(n.Lhs, n.Rhs, assign)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, id := range n.Names {
+				lhs[i] = id
+			}
+			if len(n.Values) > 0 {
+				assignAll(lhs, n.Values, assign)
+			}
+		case *ast.IncDecStmt:
+			invalidate(n.X)
+		case *ast.RangeStmt:
+			if n.Key != nil {
+				invalidate(n.Key)
+			}
+			if n.Value != nil {
+				invalidate(n.Value)
+			}
+		case *ast.UnaryExpr:
+			// A variable whose address is taken may be assigned through
+			// the pointer.
+			if n.Op == token.AND {
+				invalidate(n.X)
+			}
+		}
+	})
+	return results
+}
+
+// assignAll calls assign for each pair of a left hand side and the value
+// assigned to it. If a single call on the right hand side returns several
+// values, the call is passed with the first left hand side only, which is the
+// one that receives the result of the algorithms of package safe; the others
+// are passed with a nil value.
+func assignAll(lhs, rhs []ast.Expr, assign func(lhs, rhs ast.Expr)) {
+	if len(lhs) == len(rhs) {
+		for i := range lhs {
+			assign(lhs[i], rhs[i])
+		}
+		return
+	}
+	for i := range lhs {
+		if i == 0 && len(rhs) == 1 {
+			assign(lhs[i], rhs[0])
+		} else {
+			assign(lhs[i], nil)
+		}
+	}
+}
+
+// finderCall reports whether e is a call of a finder, and returns its origin.
+func finderCall(info *types.Info, e ast.Expr) (findResult, bool) {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return findResult{}, false
+	}
+	fn := algorithmFunc(info, call)
+	if fn == nil || !finders[fn.Name()] {
+		return findResult{}, false
+	}
+	last := arg(fn, call, "last")
+	if last == nil {
+		return findResult{}, false
+	}
+	return findResult{fn, last}, true
+}
+
+// checkFindComparison reports a comparison of the result of a finder with a
+// value that stands for "not found" but is not the last argument of the
+// finder. Comparisons with other values, such as an expected position, are
+// left alone.
+func checkFindComparison(pass *analysis.Pass, results map[types.Object]findResult, e *ast.BinaryExpr) {
+	if e.Op != token.EQL && e.Op != token.NEQ {
+		return
+	}
+	origin := func(x ast.Expr) (findResult, bool) {
+		if id, ok := ast.Unparen(x).(*ast.Ident); ok {
+			res, ok := results[pass.TypesInfo.ObjectOf(id)]
+			return res, ok
+		}
+		return finderCall(pass.TypesInfo, x)
+	}
+	for _, sides := range [][2]ast.Expr{{e.X, e.Y}, {e.Y, e.X}} {
+		res, ok := origin(sides[0])
+		if !ok {
+			continue
+		}
+		if other, ok := origin(sides[1]); ok && sameValue(pass.TypesInfo, res.last, other.last) {
+			// Both sides are results of finders on the same range.
+			return
+		}
+		if notFound(pass.TypesInfo, sides[1]) && !sameValue(pass.TypesInfo, res.last, sides[1]) {
+			pass.Reportf(e.Pos(), "result of %s compared with %s: it returns last (%s) if nothing is found",
+				qualifiedName(res.fn), types.ExprString(sides[1]), types.ExprString(res.last))
+		}
+		return
+	}
+}
+
+// notFound reports whether e looks like a "not found" value: a negative
+// constant such as -1, or the length or end of a slice.
+func notFound(info *types.Info, e ast.Expr) bool {
+	if v := info.Types[e].Value; v != nil {
+		return constant.Sign(v) < 0
+	}
+	return strings.HasPrefix(normalize(info, e), "len(")
+}
+
+// sameValue reports whether x and y are the same constant, or the same
+// expression. End(r) is considered the same as len(r).
+func sameValue(info *types.Info, x, y ast.Expr) bool {
+	if cx, cy := info.Types[x].Value, info.Types[y].Value; cx != nil && cy != nil {
+		return constant.Compare(cx, token.EQL, cy)
+	}
+	return normalize(info, x) == normalize(info, y)
+}
+
+func normalize(info *types.Info, e ast.Expr) string {
+	if call, ok := ast.Unparen(e).(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if fn := algorithmFunc(info, call); fn != nil && fn.Name() == "End" {
+			return "len(" + types.ExprString(call.Args[0]) + ")"
+		}
+	}
+	return types.ExprString(ast.Unparen(e))
+}
+
+// intConst returns the value of e if it is an integer constant.
+func intConst(info *types.Info, e ast.Expr) (int64, bool) {
+	if e == nil {
+		return 0, false
+	}
+	v := info.Types[e].Value
+	if v == nil || v.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Int64Val(v)
+}
+
+// checkRanges reports the ranges of a call to fn that are given by constants
+// with first > last, and middle positions that are not within their range.
+func checkRanges(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) {
+	params := fn.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		lastName := params.At(i).Name()
+		if !strings.Contains(lastName, "last") {
+			continue
+		}
+		firstName := strings.Replace(lastName, "last", "first", 1)
+		first, ok1 := intConst(pass.TypesInfo, arg(fn, call, firstName))
+		last, ok2 := intConst(pass.TypesInfo, arg(fn, call, lastName))
+		if ok1 && ok2 && first > last {
+			pass.Reportf(call.Pos(), "invalid range in call to %s: %s (%d) > %s (%d)",
+				qualifiedName(fn), firstName, first, lastName, last)
+			return
+		}
+	}
+	for _, middleName := range []string{"middle", "n_first"} {
+		middle, ok := intConst(pass.TypesInfo, arg(fn, call, middleName))
+		if !ok {
+			continue
+		}
+		if first, ok := intConst(pass.TypesInfo, arg(fn, call, "first")); ok && middle < first {
+			pass.Reportf(call.Pos(), "invalid range in call to %s: %s (%d) < first (%d)",
+				qualifiedName(fn), middleName, middle, first)
+		} else if last, ok := intConst(pass.TypesInfo, arg(fn, call, "last")); ok && middle > last {
+			pass.Reportf(call.Pos(), "invalid range in call to %s: %s (%d) > last (%d)",
+				qualifiedName(fn), middleName, middle, last)
+		}
+	}
+}
+
+// funcDecls returns the declarations of the functions of the package, for
+// looking up the comparators passed by name.
+func funcDecls(pass *analysis.Pass) map[*types.Func]*ast.FuncDecl {
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			if d, ok := d.(*ast.FuncDecl); ok && d.Recv == nil {
+				if fn, ok := pass.TypesInfo.Defs[d.Name].(*types.Func); ok {
+					decls[fn] = d
+				}
+			}
+		}
+	}
+	return decls
+}
+
+// checkComparator reports a comparator argument of a call to fn that is true
+// for equal elements.
+func checkComparator(pass *analysis.Pass, decls map[*types.Func]*ast.FuncDecl, fn *types.Func, call *ast.CallExpr) {
+	comp := arg(fn, call, "comp")
+	if comp == nil {
+		return
+	}
+	var body *ast.BlockStmt
+	switch c := ast.Unparen(comp).(type) {
+	case *ast.FuncLit:
+		body = c.Body
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr:
+		if f, ok := typeutil.Callee(pass.TypesInfo, &ast.CallExpr{Fun: c}).(*types.Func); ok {
+			if op, ok := nonStrictFuncs[f.Origin().FullName()]; ok {
+				reportComparator(pass, fn, comp, op)
+				return
+			}
+			if d := decls[f.Origin()]; d != nil {
+				body = d.Body
+			}
+		}
+	}
+	if body == nil || len(body.List) != 1 {
+		return
+	}
+	ret, ok := body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return
+	}
+	if op, ok := nonStrict(ret.Results[0]); ok {
+		reportComparator(pass, fn, comp, op)
+	}
+}
+
+// reportComparator reports the comparator comp passed to fn, which uses the
+// operator op.
+func reportComparator(pass *analysis.Pass, fn *types.Func, comp ast.Expr, op string) {
+	why := "false for equal elements"
+	if op == "!=" || op == "!(==)" {
+		why = "never true both ways"
+	}
+	pass.Reportf(comp.Pos(), "comparator passed to %s uses %s: it must be a strict weak ordering, %s",
+		qualifiedName(fn), op, why)
+}
+
+// nonStrict reports whether the comparison e is true for equal operands, or
+// for both orders of distinct ones, and returns its operator.
+func nonStrict(e ast.Expr) (string, bool) {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.LEQ, token.GEQ, token.EQL, token.NEQ:
+			return e.Op.String(), true
+		}
+	case *ast.UnaryExpr:
+		if x, ok := ast.Unparen(e.X).(*ast.BinaryExpr); ok && e.Op == token.NOT {
+			switch x.Op {
+			case token.LSS, token.GTR, token.EQL, token.NEQ:
+				return "!(" + x.Op.String() + ")", true
+			}
+		}
+	}
+	return "", false
+}
+
+// checkSorted reports a binary search of a slice declared in the enclosing
+// function that the function does not sort, or check for being sorted,
+// before the search.
+func checkSorted(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr, stack []ast.Node) {
+	v := sliceVar(pass.TypesInfo, arg(fn, call, "r"))
+	if v == nil {
+		return
+	}
+	var body *ast.BlockStmt
+	for i := len(stack) - 1; i >= 0 && body == nil; i-- {
+		switch f := stack[i].(type) {
+		case *ast.FuncDecl:
+			body = f.Body
+		case *ast.FuncLit:
+			body = f.Body
+		}
+	}
+	if body == nil || v.Pos() < body.Pos() || v.Pos() >= body.End() {
+		// The slice is a parameter, or declared outside of the function.
+		return
+	}
+	sorted := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if sorted || !ok || c.Pos() >= call.Pos() || len(c.Args) == 0 {
+			return !sorted
+		}
+		f, ok := typeutil.Callee(pass.TypesInfo, c).(*types.Func)
+		if ok && f.Pkg() != nil && sorters[f.Pkg().Path()+"."+f.Name()] && sliceVar(pass.TypesInfo, c.Args[0]) == v {
+			sorted = true
+		}
+		return !sorted
+	})
+	if !sorted {
+		pass.Reportf(call.Pos(), "%s on %s, which is not sorted earlier in this function: the range must be sorted",
+			qualifiedName(fn), v.Name())
+	}
+}
+
+// sliceVar returns the variable that e denotes, looking through slicing and
+// conversions such as sort.IntSlice(s), or nil if e is not a variable.
+func sliceVar(info *types.Info, e ast.Expr) *types.Var {
+	for e != nil {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			v, _ := info.ObjectOf(x).(*types.Var)
+			return v
+		case *ast.SliceExpr:
+			e = x.X
+		case *ast.CallExpr:
+			if len(x.Args) != 1 || !info.Types[x.Fun].IsType() {
+				return nil
+			}
+			e = x.Args[0]
+		default:
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package gocppvet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"gocpp/gocppvet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), gocppvet.Analyzer, "a")
+}
@@ -0,0 +1,140 @@
+package a
+
+import (
+	"slices"
+	"sort"
+
+	"gocpp/algorithm"
+	"gocpp/algorithm/safe"
+	"gocpp/functional"
+)
+
+func unused(r []int) []int {
+	algorithm.Remove(r, 0, len(r), 1)                                           // want `result of algorithm.Remove is not used`
+	algorithm.RemoveIf(r, 0, len(r), func(x int) bool { return x > 0 })         // want `result of algorithm.RemoveIf is not used`
+	algorithm.Unique(r, 0, len(r))                                              // want `result of algorithm.Unique is not used`
+	(algorithm.UniqueFunc(r, 0, len(r), func(a, b int) bool { return a == b })) // want `result of algorithm.UniqueFunc is not used`
+	safe.Remove(r, 0, len(r), 1)                                                // want `result of safe.Remove is not used`
+	algorithm.Copy(r, r, 0, 0, 0)
+
+	r = r[:algorithm.Remove(r, 0, len(r), 1)]
+	_ = algorithm.Unique(r, 0, len(r))
+	return r[:algorithm.Unique(r, 0, len(r))]
+}
+
+func find(r []int, n int) bool {
+	if algorithm.Find(r, 0, n, 1) == -1 { // want `result of algorithm.Find compared with -1: it returns last \(n\) if nothing is found`
+		return false
+	}
+	if algorithm.Find(r, 0, n, 1) != n {
+		return true
+	}
+	if algorithm.Find(r, 0, len(r), 1) == algorithm.End(r) {
+		return false
+	}
+
+	i := algorithm.FindIf(r, 0, len(r), func(x int) bool { return x > 0 })
+	if i != -1 { // want `result of algorithm.FindIf compared with -1`
+		return true
+	}
+	if len(r) == i || i < len(r) || i == 0 {
+		return true
+	}
+
+	j := algorithm.Search(r, r, 2, 5, 0, 1)
+	if j == 5 {
+		return false
+	}
+	if j == len(r) { // want `result of algorithm.Search compared with len\(r\): it returns last \(5\)`
+		return false
+	}
+	if algorithm.End(r) != algorithm.Search(r, r, 0, n, 0, 1) { // want `compared with algorithm.End\(r\): it returns last \(n\)`
+		return false
+	}
+
+	k, err := safe.Find(r, 0, n, 1)
+	if err != nil || k == len(r) || k == 2 { // want `result of safe.Find compared with len\(r\)`
+		return false
+	}
+
+	// Variables that are assigned anything else are not tracked.
+	m := algorithm.Find(r, 0, n, 1)
+	m++
+	if m == -1 {
+		return false
+	}
+	p := algorithm.Find(r, 0, n, 1)
+	if p == 0 {
+		p = -1
+	}
+	if p == -1 {
+		return false
+	}
+	return algorithm.Find(r, 0, n, 1) == algorithm.Find(r, 0, n, 2)
+}
+
+const size = 3
+
+func ranges(r []int) {
+	algorithm.Find(r, 5, 2, 0)            // want `invalid range in call to algorithm.Find: first \(5\) > last \(2\)`
+	algorithm.Search(r, r, 0, 4, size, 1) // want `invalid range in call to algorithm.Search: s_first \(3\) > s_last \(1\)`
+	algorithm.Mismatch(r, r, 4, 2, 0)     // want `invalid range in call to algorithm.Mismatch: first1 \(4\) > last1 \(2\)`
+	algorithm.Rotate(r, 0, 4, size)       // want `invalid range in call to algorithm.Rotate: middle \(4\) > last \(3\)`
+	algorithm.Rotate(r, 2, 1, size)       // want `invalid range in call to algorithm.Rotate: middle \(1\) < first \(2\)`
+	algorithm.Find(r, 2, 2, 0)
+	algorithm.Rotate(r, 0, 1, len(r))
+}
+
+func bounds(param []int) {
+	_ = algorithm.LowerBound(param, 0, len(param), 1)
+
+	r := []int{3, 1, 2}
+	_ = algorithm.LowerBound(r, 0, len(r), 1) // want `algorithm.LowerBound on r, which is not sorted earlier in this function`
+	sort.Ints(r)
+	_ = algorithm.LowerBound(r, 0, len(r), 1)
+	_ = algorithm.UpperBound(r[1:], 0, 2, 1)
+
+	s := []int{3, 1, 2}
+	_ = algorithm.UpperBound(s, 0, len(s), 1) // want `algorithm.UpperBound on s`
+	slices.Sort(s[:2])
+	_ = algorithm.UpperBound(s, 0, len(s), 1)
+
+	h := []int{3, 1, 2}
+	algorithm.SortHeap(h, 0, len(h))
+	_ = algorithm.LowerBoundFunc(h, 0, len(h), 1, func(a, b int) bool { return a < b })
+
+	c := []int{3, 1, 2}
+	if sort.IsSorted(sort.IntSlice(c)) {
+		_ = algorithm.LowerBound(c, 0, len(c), 1)
+	}
+
+	f := func() {
+		_ = algorithm.LowerBound(r, 0, len(r), 1)
+		l := []int{1}
+		_ = algorithm.LowerBound(l, 0, 1, 1) // want `algorithm.LowerBound on l`
+	}
+	f()
+}
+
+func lessEqual(a, b int) bool { return a <= b }
+
+func less(a, b int) bool { return a < b }
+
+func comparators(r []int) {
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return a <= b })   // want `comparator passed to algorithm.SortHeapFunc uses <=`
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return (a >= b) }) // want `uses >=`
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return !(b < a) }) // want `uses !\(<\)`
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return a == b })   // want `uses ==`
+	algorithm.SortHeapFunc(r, 0, len(r), lessEqual)                               // want `comparator passed to algorithm.SortHeapFunc uses <=`
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return a != b })   // want `uses !=: it must be a strict weak ordering, never true both ways`
+	algorithm.SortHeapFunc(r, 0, len(r), functional.LessEqual[int])               // want `comparator passed to algorithm.SortHeapFunc uses <=`
+	algorithm.SortHeapFunc(r, 0, len(r), functional.GreaterEqual[int])            // want `uses >=`
+	algorithm.SortHeapFunc(r, 0, len(r), functional.EqualTo[int])                 // want `uses ==`
+	algorithm.SortHeapFunc(r, 0, len(r), functional.NotEqualTo)                   // want `uses !=`
+	algorithm.SortHeapFunc(r, 0, len(r), less)
+	algorithm.SortHeapFunc(r, 0, len(r), functional.Less[int])
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return a > b })
+
+	// Binary predicates are not comparators.
+	_ = algorithm.UniqueFunc(r, 0, len(r), func(a, b int) bool { return a == b })
+}
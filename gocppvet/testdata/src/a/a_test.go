@@ -0,0 +1,20 @@
+package a
+
+import (
+	"testing"
+
+	"gocpp/algorithm"
+)
+
+// Test files are not checked.
+func TestNotChecked(t *testing.T) {
+	r := []int{3, 1, 2}
+	algorithm.Unique(r, 0, len(r))
+	if algorithm.Find(r, 0, 2, 1) == len(r) {
+		t.Error()
+	}
+	algorithm.Find(r, 2, 1, 0)
+	algorithm.Rotate(r, 0, 4, 3)
+	_ = algorithm.LowerBound(r, 0, len(r), 1)
+	algorithm.SortHeapFunc(r, 0, len(r), func(a, b int) bool { return a <= b })
+}
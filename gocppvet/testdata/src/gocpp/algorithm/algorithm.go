@@ -0,0 +1,35 @@
+// Package algorithm declares the functions of gocpp/algorithm that the tests
+// of the analyzer call.
+package algorithm
+
+func End[T any](c []T) int { return len(c) }
+
+func Find[T comparable](r []T, first, last int, value T) int { return last }
+
+func FindIf[T any](r []T, first, last int, p func(T) bool) int { return last }
+
+func Search[T comparable](r1, r2 []T, first, last, s_first, s_last int) int { return last }
+
+func Mismatch[T comparable](r1, r2 []T, first1, last1, first2 int) (int, int) { return last1, first2 }
+
+func Copy[T any](r1, r2 []T, first, last, d_first int) int { return d_first }
+
+func Remove[T comparable](r []T, first, last int, value T) int { return first }
+
+func RemoveIf[T any](r []T, first, last int, p func(T) bool) int { return first }
+
+func Unique[T comparable](r []T, first, last int) int { return first }
+
+func UniqueFunc[T any](r []T, first, last int, p func(T, T) bool) int { return first }
+
+func Rotate[T any](r []T, first, middle, last int) int { return first }
+
+func LowerBound[T any](r []T, first, last int, value T) int { return first }
+
+func LowerBoundFunc[T any](r []T, first, last int, value T, comp func(T, T) bool) int { return first }
+
+func UpperBound[T any](r []T, first, last int, value T) int { return last }
+
+func SortHeap[T any](r []T, first, last int) {}
+
+func SortHeapFunc[T any](r []T, first, last int, comp func(T, T) bool) {}
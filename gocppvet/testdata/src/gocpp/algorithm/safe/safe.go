@@ -0,0 +1,7 @@
+// Package safe declares the functions of gocpp/algorithm/safe that the tests
+// of the analyzer call.
+package safe
+
+func Find[T comparable](r []T, first, last int, value T) (int, error) { return last, nil }
+
+func Remove[T comparable](r []T, first, last int, value T) (int, error) { return first, nil }
@@ -0,0 +1,15 @@
+// Package functional declares the functions of gocpp/functional that the
+// tests of the analyzer pass as comparators.
+package functional
+
+import "cmp"
+
+func Less[T cmp.Ordered](a, b T) bool { return a < b }
+
+func LessEqual[T cmp.Ordered](a, b T) bool { return a <= b }
+
+func GreaterEqual[T cmp.Ordered](a, b T) bool { return a >= b }
+
+func EqualTo[T comparable](a, b T) bool { return a == b }
+
+func NotEqualTo[T comparable](a, b T) bool { return a != b }
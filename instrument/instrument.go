@@ -0,0 +1,241 @@
+// Package instrument wraps comparators, predicates, generators and operations
+// so that the calls an algorithm makes to them are counted and, optionally,
+// recorded. Tests and benchmarks use it to check the complexity guarantees
+// that the C++ standard states for each algorithm, such as "at most
+// log2(last - first) + O(1) comparisons".
+//
+// A Recorder is not safe for concurrent use.
+package instrument
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Kind classifies the functions passed to an algorithm.
+type Kind int
+
+const (
+	// Comparison is a comparator, such as the comp of SortHeapFunc.
+	Comparison Kind = iota
+	// Predicate is a unary or binary predicate, such as the p of FindIf.
+	Predicate
+	// Generator is a generator, such as the g of Generate.
+	Generator
+	// Operation is a unary or binary operation, such as the unary_op of
+	// Transform.
+	Operation
+
+	numKinds
+)
+
+var kindNames = [numKinds]string{"comparison", "predicate", "generator", "operation"}
+
+// Returns the name of the kind.
+func (k Kind) String() string {
+	if k < 0 || k >= numKinds {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// Call is a recorded call to a wrapped function.
+type Call struct {
+	Kind   Kind
+	Args   []any
+	Result any
+}
+
+// Formats the call as kind(args) = result.
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = fmt.Sprint(a)
+	}
+	return fmt.Sprintf("%v(%s) = %v", c.Kind, strings.Join(args, ", "), c.Result)
+}
+
+// Recorder counts the calls to the functions wrapped with it, by kind, and
+// records them if recording is enabled. The zero Recorder counts without
+// recording.
+type Recorder struct {
+	counts [numKinds]int
+	record bool
+	calls  []Call
+}
+
+// Constructs a Recorder that only counts calls.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Constructs a Recorder that counts and records calls, with their arguments
+// and results, in the order they are made.
+func NewRecording() *Recorder {
+	return &Recorder{record: true}
+}
+
+// add records a call of kind k. The wrappers check r.record before calling
+// it, so that a Recorder that only counts does not box the arguments.
+func (r *Recorder) add(k Kind, result any, args ...any) {
+	r.calls = append(r.calls, Call{k, args, result})
+}
+
+// Returns the number of calls of kind k.
+func (r *Recorder) Count(k Kind) int {
+	return r.counts[k]
+}
+
+// Returns the number of calls of all kinds.
+func (r *Recorder) Total() int {
+	n := 0
+	for _, c := range r.counts {
+		n += c
+	}
+	return n
+}
+
+// Returns the recorded calls, or nil if the Recorder does not record.
+func (r *Recorder) Calls() []Call {
+	return r.calls
+}
+
+// Sets the counts to zero and forgets the recorded calls.
+func (r *Recorder) Reset() {
+	r.counts = [numKinds]int{}
+	r.calls = r.calls[:0]
+}
+
+// Formats the nonzero counts, followed by the recorded calls one per line.
+func (r *Recorder) String() string {
+	var sb strings.Builder
+	for k, c := range r.counts {
+		if c != 0 {
+			if sb.Len() > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%d %v", c, Kind(k))
+		}
+	}
+	if sb.Len() == 0 {
+		sb.WriteString("no calls")
+	}
+	for _, c := range r.calls {
+		fmt.Fprintf(&sb, "\n\t%v", c)
+	}
+	return sb.String()
+}
+
+// Reports the number of calls of each kind made during n iterations of a
+// benchmark, per iteration, as the custom metrics "comparisons/op",
+// "predicates/op" and so on. b is usually the *testing.B of the benchmark and
+// n its b.N. Kinds that were not called are not reported.
+func (r *Recorder) ReportMetrics(b interface{ ReportMetric(float64, string) }, n int) {
+	for k, c := range r.counts {
+		if c != 0 {
+			b.ReportMetric(float64(c)/float64(n), Kind(k).String()+"s/op")
+		}
+	}
+}
+
+// Returns a comparator that calls comp and counts a Comparison.
+func Compare[T any](r *Recorder, comp func(T, T) bool) func(T, T) bool {
+	return func(a, b T) bool {
+		res := comp(a, b)
+		r.counts[Comparison]++
+		if r.record {
+			r.add(Comparison, res, a, b)
+		}
+		return res
+	}
+}
+
+// Returns a predicate that calls p and counts a Predicate.
+func Pred[T any](r *Recorder, p func(T) bool) func(T) bool {
+	return func(a T) bool {
+		res := p(a)
+		r.counts[Predicate]++
+		if r.record {
+			r.add(Predicate, res, a)
+		}
+		return res
+	}
+}
+
+// Returns a binary predicate that calls p and counts a Predicate.
+func BinaryPred[T1, T2 any](r *Recorder, p func(T1, T2) bool) func(T1, T2) bool {
+	return func(a T1, b T2) bool {
+		res := p(a, b)
+		r.counts[Predicate]++
+		if r.record {
+			r.add(Predicate, res, a, b)
+		}
+		return res
+	}
+}
+
+// Returns a generator that calls g and counts a Generator.
+func Gen[T any](r *Recorder, g func() T) func() T {
+	return func() T {
+		res := g()
+		r.counts[Generator]++
+		if r.record {
+			r.add(Generator, res)
+		}
+		return res
+	}
+}
+
+// Returns a unary operation that calls op and counts an Operation.
+func UnaryOp[T1, T2 any](r *Recorder, op func(T1) T2) func(T1) T2 {
+	return func(a T1) T2 {
+		res := op(a)
+		r.counts[Operation]++
+		if r.record {
+			r.add(Operation, res, a)
+		}
+		return res
+	}
+}
+
+// Returns a binary operation that calls op and counts an Operation.
+func BinaryOp[T1, T2, T3 any](r *Recorder, op func(T1, T2) T3) func(T1, T2) T3 {
+	return func(a T1, b T2) T3 {
+		res := op(a, b)
+		r.counts[Operation]++
+		if r.record {
+			r.add(Operation, res, a, b)
+		}
+		return res
+	}
+}
+
+// Returns the number of positions at which before and after hold different
+// elements. Go cannot intercept assignments to the elements of a slice, so
+// this is the number of assignments an algorithm must at least have made to
+// turn before into after; an algorithm that writes a position more than once,
+// or writes an equal value, makes more. With distinct elements it is exact
+// for algorithms that write each position at most once. Panics if the slices
+// differ in length.
+func Assignments[T comparable](before, after []T) int {
+	if len(before) != len(after) {
+		panic(fmt.Sprintf("instrument: Assignments: length mismatch %d != %d", len(before), len(after)))
+	}
+	n := 0
+	for i := range before {
+		if before[i] != after[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// Returns floor(log2(n)) for n > 0, and 0 for n <= 0, for writing complexity
+// bounds such as Log2(n) + 1.
+func Log2(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return bits.Len(uint(n)) - 1
+}
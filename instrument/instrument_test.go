@@ -0,0 +1,88 @@
+package instrument
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	r := New()
+	less := Compare(r, func(a, b int) bool { return a < b })
+	even := Pred(r, func(x int) bool { return x%2 == 0 })
+	if !less(1, 2) || less(2, 1) || !even(4) {
+		t.Fatal("wrapped functions return wrong results")
+	}
+	if r.Count(Comparison) != 2 || r.Count(Predicate) != 1 || r.Total() != 3 {
+		t.Errorf("counts = %v", r)
+	}
+	if r.Calls() != nil {
+		t.Errorf("Calls() = %v for a Recorder that does not record", r.Calls())
+	}
+	r.Reset()
+	if r.Total() != 0 || r.String() != "no calls" {
+		t.Errorf("after Reset: %v", r)
+	}
+}
+
+func TestRecording(t *testing.T) {
+	r := NewRecording()
+	next := 0
+	g := Gen(r, func() int {
+		next++
+		return next
+	})
+	neg := UnaryOp(r, func(x int) int { return -x })
+	sum := BinaryOp(r, func(x int, y float64) float64 { return float64(x) + y })
+	eq := BinaryPred(r, func(a, b string) bool { return a == b })
+	neg(g())
+	sum(1, 0.5)
+	eq("a", "b")
+
+	want := []string{"generator() = 1", "operation(1) = -1", "operation(1, 0.5) = 1.5", "predicate(a, b) = false"}
+	calls := r.Calls()
+	if len(calls) != len(want) {
+		t.Fatalf("Calls() = %v, want %v", calls, want)
+	}
+	for i, c := range calls {
+		if c.String() != want[i] {
+			t.Errorf("call %d = %v, want %v", i, c, want[i])
+		}
+	}
+	if s := r.String(); !strings.HasPrefix(s, "1 predicate, 1 generator, 2 operation\n") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// metrics collects the metrics reported to it, like a *testing.B.
+type metrics map[string]float64
+
+func (m metrics) ReportMetric(n float64, unit string) {
+	m[unit] = n
+}
+
+func TestReportMetrics(t *testing.T) {
+	r := New()
+	less := Compare(r, func(a, b int) bool { return a < b })
+	for i := 0; i < 6; i++ {
+		less(i, 1)
+	}
+	m := metrics{}
+	r.ReportMetrics(m, 3)
+	if len(m) != 1 || m["comparisons/op"] != 2 {
+		t.Errorf("ReportMetrics reported %v, want 2 comparisons/op", m)
+	}
+}
+
+func TestAssignments(t *testing.T) {
+	if got := Assignments([]int{1, 2, 3, 4}, []int{1, 3, 2, 4}); got != 2 {
+		t.Errorf("Assignments = %d, want 2", got)
+	}
+}
+
+func TestLog2(t *testing.T) {
+	for n, want := range map[int]int{-1: 0, 0: 0, 1: 0, 2: 1, 3: 1, 4: 2, 1023: 9, 1024: 10} {
+		if got := Log2(n); got != want {
+			t.Errorf("Log2(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package alias reports whether slices share memory. It is shared by the
+// precondition checks of algorithm and the error-returning variants of
+// algorithm/safe.
+package alias
+
+import "unsafe"
+
+// Overlap reports whether the slices a and b share any element. Slices of a
+// zero-size element type never overlap.
+func Overlap[T any](a, b []T) bool {
+	size := unsafe.Sizeof(*new(T))
+	if len(a) == 0 || len(b) == 0 || size == 0 {
+		return false
+	}
+	loA := uintptr(unsafe.Pointer(unsafe.SliceData(a)))
+	loB := uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+	return loA < loB+uintptr(len(b))*size && loB < loA+uintptr(len(a))*size
+}
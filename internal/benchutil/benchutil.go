@@ -0,0 +1,164 @@
+// Package benchutil generates the inputs of the benchmark suites of the
+// algorithms and containers, so that all of them run over the same element
+// types, sizes and distributions.
+//
+// The sub-benchmarks of a suite are named with key=value parts, such as
+//
+//	BenchmarkStdlib/Find/type=int/dist=random/n=1000/impl=slices
+//
+// so that benchstat and cmd/benchreport can compare the implementations:
+//
+//	go test -run '^$' -bench Stdlib -count 10 ./algorithm ./container > bench.txt
+//	go run ./cmd/benchreport bench.txt
+package benchutil
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// DefaultSizes and SizesUsage are the default value and the usage message of
+// the -benchsizes flag. Each test package with a suite registers the flag
+// itself, since a flag registered here would be defined in every binary that
+// imports the package:
+//
+//	var stdlibSizes = flag.String("benchsizes", benchutil.DefaultSizes, benchutil.SizesUsage)
+const (
+	DefaultSizes = "10,1000,100000"
+	SizesUsage   = "comma-separated `sizes` of the inputs of the benchmark suites; the suites support up to 10000000"
+)
+
+// Returns the sizes of the inputs in s, the value of a -benchsizes flag.
+// Panics if s does not hold a list of positive integers.
+func Sizes(s string) []int {
+	var ns []int
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n <= 0 {
+			panic(fmt.Sprintf("benchutil: invalid size %q in -benchsizes", f))
+		}
+		ns = append(ns, n)
+	}
+	return ns
+}
+
+// Dist is the distribution of the keys of a generated input.
+type Dist int
+
+const (
+	// Sorted keys are 0, 1, 2 and so on.
+	Sorted Dist = iota
+	// Reversed keys are n - 1, n - 2 and so on down to 0.
+	Reversed
+	// Random keys are drawn uniformly from [0, n).
+	Random
+	// FewUnique keys are drawn uniformly from [0, 8).
+	FewUnique
+)
+
+// Dists lists every distribution, for iterating over them.
+var Dists = []Dist{Sorted, Reversed, Random, FewUnique}
+
+var distNames = []string{"sorted", "reversed", "random", "few-unique"}
+
+// Returns the name of the distribution.
+func (d Dist) String() string {
+	if d < 0 || int(d) >= len(distNames) {
+		return fmt.Sprintf("Dist(%d)", int(d))
+	}
+	return distNames[d]
+}
+
+// Returns n keys in [0, n) with distribution d. The random distributions are
+// seeded with n, so an input of a given size is the same in every run.
+func Keys(n int, d Dist) []int {
+	rng := rand.New(rand.NewSource(int64(n)))
+	keys := make([]int, n)
+	for i := range keys {
+		switch d {
+		case Sorted:
+			keys[i] = i
+		case Reversed:
+			keys[i] = n - 1 - i
+		case Random:
+			keys[i] = rng.Intn(n)
+		case FewUnique:
+			keys[i] = rng.Intn(8)
+		}
+	}
+	return keys
+}
+
+// Record is a 64-byte element type, standing for the structs that are larger
+// than a machine word. Records are ordered by Key.
+type Record struct {
+	Key     int64
+	Payload [56]byte
+}
+
+// Returns a Record with key k and a payload derived from it.
+func MakeRecord(k int) Record {
+	r := Record{Key: int64(k)}
+	r.Payload[0] = byte(k)
+	return r
+}
+
+// Returns a string for key k. The strings of the keys are ordered like the
+// keys.
+func MakeString(k int) string {
+	return fmt.Sprintf("key-%012d", k)
+}
+
+// Type describes an element type of the suites: how to make an element from
+// a key, how to order elements, both as a less function and as a three-way
+// comparison, and an element that is not equal to the element of any key.
+type Type[T any] struct {
+	Name    string
+	Make    func(k int) T
+	Less    func(a, b T) bool
+	Compare func(a, b T) int
+	Absent  T
+}
+
+// Returns n elements whose keys have distribution d.
+func (t Type[T]) Slice(n int, d Dist) []T {
+	s := make([]T, n)
+	for i, k := range Keys(n, d) {
+		s[i] = t.Make(k)
+	}
+	return s
+}
+
+// The element types of the suites.
+var (
+	Int = Type[int]{
+		Name:    "int",
+		Make:    func(k int) int { return k },
+		Less:    func(a, b int) bool { return a < b },
+		Compare: cmp.Compare[int],
+		Absent:  -1,
+	}
+	String = Type[string]{
+		Name:    "string",
+		Make:    MakeString,
+		Less:    func(a, b string) bool { return a < b },
+		Compare: cmp.Compare[string],
+		Absent:  "",
+	}
+	Struct = Type[Record]{
+		Name:    "struct64",
+		Make:    MakeRecord,
+		Less:    func(a, b Record) bool { return a.Key < b.Key },
+		Compare: func(a, b Record) int { return cmp.Compare(a.Key, b.Key) },
+		Absent:  Record{Key: -1},
+	}
+)
+
+// Returns the name of the sub-benchmark of an operation on n elements of the
+// named type with distribution d.
+func Name(typ string, d Dist, n int) string {
+	return fmt.Sprintf("type=%s/dist=%v/n=%d", typ, d, n)
+}
@@ -0,0 +1,281 @@
+// Package proptest provides helpers for the property-based and fuzz tests of
+// the algorithms and containers: a generator of random inputs, a decoder that
+// turns fuzz inputs into the same kind of data, and checks of properties that
+// are shared by many algorithms, such as "the output preserves the relative
+// order of the input".
+//
+// Both Gen and Decoder favour the edge cases of index ranges: empty slices,
+// empty ranges (first == last), full ranges and subranges at an offset.
+package proptest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Range is a slice together with the range [First, Last) of its indexes that
+// an algorithm operates on.
+type Range[T any] struct {
+	R           []T
+	First, Last int
+}
+
+// Returns the elements of the range.
+func (r Range[T]) Elems() []T {
+	return r.R[r.First:r.Last]
+}
+
+// Returns the number of elements of the range.
+func (r Range[T]) Len() int {
+	return r.Last - r.First
+}
+
+// Returns a copy of the range that does not share the slice with r.
+func (r Range[T]) Clone() Range[T] {
+	return Range[T]{append([]T(nil), r.R...), r.First, r.Last}
+}
+
+// Formats the range as the slice followed by the indexes.
+func (r Range[T]) String() string {
+	return fmt.Sprintf("%v[%d:%d]", r.R, r.First, r.Last)
+}
+
+// Gen generates random inputs from a seed, so that a failure can be
+// reproduced.
+type Gen struct {
+	rand *rand.Rand
+}
+
+// Constructs a generator seeded with seed.
+func New(seed int64) *Gen {
+	return &Gen{rand.New(rand.NewSource(seed))}
+}
+
+// Returns a random int in [0, n). Panics if n <= 0.
+func (g *Gen) Intn(n int) int {
+	return g.rand.Intn(n)
+}
+
+// Returns a random bool.
+func (g *Gen) Bool() bool {
+	return g.rand.Intn(2) == 0
+}
+
+// Returns a random length in [0, max], with 0 and 1 more likely than the
+// other lengths.
+func (g *Gen) Len(max int) int {
+	switch g.rand.Intn(8) {
+	case 0:
+		return 0
+	case 1:
+		return min(1, max)
+	}
+	return g.rand.Intn(max + 1)
+}
+
+// Returns n random ints in [0, alphabet). A small alphabet makes equal
+// elements likely.
+func (g *Gen) Ints(n, alphabet int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = g.rand.Intn(alphabet)
+	}
+	return s
+}
+
+// Returns a random subrange [first, last) of [0, n): the full range, an
+// empty range or a range at a random offset.
+func (g *Gen) Subrange(n int) (first, last int) {
+	switch g.rand.Intn(4) {
+	case 0:
+		return 0, n
+	case 1:
+		first = g.rand.Intn(n + 1)
+		return first, first
+	}
+	first = g.rand.Intn(n + 1)
+	return first, first + g.rand.Intn(n-first+1)
+}
+
+// Returns a Range of at most max ints in [0, alphabet).
+func (g *Gen) Range(max, alphabet int) Range[int] {
+	r := g.Ints(g.Len(max), alphabet)
+	first, last := g.Subrange(len(r))
+	return Range[int]{r, first, last}
+}
+
+// Returns a Range of at most max ints in [0, alphabet) whose elements
+// [First, Last) are sorted.
+func (g *Gen) SortedRange(max, alphabet int) Range[int] {
+	r := g.Range(max, alphabet)
+	sortInts(r.Elems())
+	return r
+}
+
+// Decoder decodes the input of a fuzz target into test data. Every input
+// decodes to valid data; once the input is exhausted, the decoder returns
+// zeroes.
+type Decoder struct {
+	data []byte
+}
+
+// Constructs a decoder of data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data}
+}
+
+// Returns an int in [0, n) taken from the next byte of the input. Panics if
+// n <= 0.
+func (d *Decoder) Intn(n int) int {
+	if len(d.data) == 0 {
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return int(b) % n
+}
+
+// Returns a bool taken from the next byte of the input.
+func (d *Decoder) Bool() bool {
+	return d.Intn(2) == 1
+}
+
+// Returns a slice of at most max ints in [0, alphabet). The first byte gives
+// the length and each following byte one element.
+func (d *Decoder) Ints(max, alphabet int) []int {
+	s := make([]int, d.Intn(max+1))
+	for i := range s {
+		s[i] = d.Intn(alphabet)
+	}
+	return s
+}
+
+// Returns a subrange [first, last) of [0, n).
+func (d *Decoder) Subrange(n int) (first, last int) {
+	first = d.Intn(n + 1)
+	return first, first + d.Intn(n-first+1)
+}
+
+// Returns a Range of at most max ints in [0, alphabet).
+func (d *Decoder) Range(max, alphabet int) Range[int] {
+	r := d.Ints(max, alphabet)
+	first, last := d.Subrange(len(r))
+	return Range[int]{r, first, last}
+}
+
+// Returns a Range of at most max ints in [0, alphabet) whose elements
+// [First, Last) are sorted.
+func (d *Decoder) SortedRange(max, alphabet int) Range[int] {
+	r := d.Range(max, alphabet)
+	sortInts(r.Elems())
+	return r
+}
+
+// sortInts sorts s by insertion; the inputs are small.
+func sortInts(s []int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j] < s[j-1]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// Calls f with iterations generators seeded 0, 1, 2 and so on, and stops at
+// the first iteration that fails t, logging its seed.
+func Check(t testing.TB, iterations int, f func(g *Gen)) {
+	t.Helper()
+	for seed := int64(0); seed < int64(iterations); seed++ {
+		f(New(seed))
+		if t.Failed() {
+			t.Logf("failed with seed %d", seed)
+			return
+		}
+	}
+}
+
+// Returns an error if got and want differ in length or in any element.
+func Equal[T comparable](got, want []T) error {
+	if len(got) != len(want) {
+		return fmt.Errorf("got %v, want %v: length %d != %d", got, want, len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return fmt.Errorf("got %v, want %v: element %d differs", got, want, i)
+		}
+	}
+	return nil
+}
+
+// Returns an error if s has two adjacent elements for which eq holds.
+func NoAdjacentEqualFunc[T any](s []T, eq func(T, T) bool) error {
+	for i := 1; i < len(s); i++ {
+		if eq(s[i-1], s[i]) {
+			return fmt.Errorf("%v: elements %d and %d are equal", s, i-1, i)
+		}
+	}
+	return nil
+}
+
+// Returns an error if s has two adjacent equal elements.
+func NoAdjacentEqual[T comparable](s []T) error {
+	return NoAdjacentEqualFunc(s, func(a, b T) bool { return a == b })
+}
+
+// Returns an error if s is not sorted with respect to less.
+func SortedFunc[T any](s []T, less func(T, T) bool) error {
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[i-1]) {
+			return fmt.Errorf("%v: element %d is less than element %d", s, i, i-1)
+		}
+	}
+	return nil
+}
+
+// Returns an error if sub is not a subsequence of s, that is, if sub cannot
+// be obtained by deleting elements of s without changing the order of the
+// others.
+func Subsequence[T comparable](sub, s []T) error {
+	i := 0
+	for _, x := range s {
+		if i < len(sub) && sub[i] == x {
+			i++
+		}
+	}
+	if i < len(sub) {
+		return fmt.Errorf("%v is not a subsequence of %v: element %d out of order or missing", sub, s, i)
+	}
+	return nil
+}
+
+// Returns an error if a is not a permutation of b, that is, if some value
+// occurs a different number of times in each.
+func Permutation[T comparable](a, b []T) error {
+	counts := make(map[T]int)
+	for _, x := range a {
+		counts[x]++
+	}
+	for _, x := range b {
+		counts[x]--
+	}
+	for x, n := range counts {
+		if n != 0 {
+			return fmt.Errorf("%v is not a permutation of %v: %v occurs %d more times", a, b, x, n)
+		}
+	}
+	return nil
+}
+
+// Returns an error if after differs from before outside of the indexes
+// [first, last), the range an algorithm was allowed to modify.
+func Unchanged[T comparable](before, after []T, first, last int) error {
+	if len(before) != len(after) {
+		return fmt.Errorf("length changed from %d to %d", len(before), len(after))
+	}
+	for i := range before {
+		if (i < first || i >= last) && before[i] != after[i] {
+			return fmt.Errorf("%v changed to %v at index %d, outside [%d, %d)", before, after, i, first, last)
+		}
+	}
+	return nil
+}
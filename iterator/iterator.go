@@ -0,0 +1,121 @@
+// Package iterator provides output sinks, the counterpart of C++ output
+// iterators, for algorithms whose output size is not known in advance.
+package iterator
+
+import "slices"
+
+// Sink is a destination that accepts values one at a time, like an output
+// iterator: Put(v) has the effect of *it++ = v.
+type Sink[T any] interface {
+	Put(v T)
+}
+
+// SinkFunc adapts an ordinary function to a Sink.
+type SinkFunc[T any] func(v T)
+
+// Calls f(v).
+func (f SinkFunc[T]) Put(v T) {
+	f(v)
+}
+
+// BackInsertIterator is a Sink that appends to a slice, like
+// std::back_insert_iterator.
+type BackInsertIterator[T any] struct {
+	s *[]T
+}
+
+// Constructs a BackInsertIterator that appends to the slice pointed to by s,
+// like std::back_inserter.
+func BackInserter[T any](s *[]T) *BackInsertIterator[T] {
+	return &BackInsertIterator[T]{s}
+}
+
+// Appends v to the slice.
+func (it *BackInsertIterator[T]) Put(v T) {
+	*it.s = append(*it.s, v)
+}
+
+// FrontInsertable is implemented by containers that can insert at the front,
+// such as container.Deque.
+type FrontInsertable[T any] interface {
+	PushFront(v T)
+}
+
+// FrontInsertIterator is a Sink that inserts at the front of a container, like
+// std::front_insert_iterator. The values end up in reverse order.
+type FrontInsertIterator[T any] struct {
+	c FrontInsertable[T]
+}
+
+// Constructs a FrontInsertIterator for the container c, like
+// std::front_inserter.
+func FrontInserter[T any](c FrontInsertable[T]) *FrontInsertIterator[T] {
+	return &FrontInsertIterator[T]{c}
+}
+
+// Inserts v at the front of the container.
+func (it *FrontInsertIterator[T]) Put(v T) {
+	it.c.PushFront(v)
+}
+
+// InsertIterator is a Sink that inserts into a slice at a position, like
+// std::insert_iterator. Successive values are inserted one after the other, so
+// they keep their order. Each insertion shifts the elements that follow, which
+// takes linear time.
+type InsertIterator[T any] struct {
+	s   *[]T
+	pos int
+}
+
+// Constructs an InsertIterator that inserts into the slice pointed to by s
+// starting at index pos, like std::inserter.
+func Inserter[T any](s *[]T, pos int) *InsertIterator[T] {
+	return &InsertIterator[T]{s, pos}
+}
+
+// Inserts v at the current position and advances the position past it.
+func (it *InsertIterator[T]) Put(v T) {
+	*it.s = slices.Insert(*it.s, it.pos, v)
+	it.pos++
+}
+
+// Returns the index at which the next value will be inserted.
+func (it *InsertIterator[T]) Pos() int {
+	return it.pos
+}
+
+// DiscardIterator is a Sink that ignores every value, like writing to
+// std::ignore. It is useful to run an algorithm only for the side effects of
+// its predicate, or for its count.
+type DiscardIterator[T any] struct{}
+
+// Returns a Sink that discards values.
+func Discard[T any]() DiscardIterator[T] {
+	return DiscardIterator[T]{}
+}
+
+// Does nothing.
+func (DiscardIterator[T]) Put(T) {}
+
+// CountingIterator is a Sink that forwards values to another Sink and counts
+// them.
+type CountingIterator[T any] struct {
+	out Sink[T]
+	n   int
+}
+
+// Constructs a CountingIterator that forwards values to out.
+func Counter[T any](out Sink[T]) *CountingIterator[T] {
+	return &CountingIterator[T]{out: out}
+}
+
+// Forwards v to the underlying Sink.
+func (it *CountingIterator[T]) Put(v T) {
+	it.out.Put(v)
+	it.n++
+}
+
+// Returns the number of values put so far.
+func (it *CountingIterator[T]) Count() int {
+	return it.n
+}
@@ -0,0 +1,210 @@
+package iterator
+
+import (
+	"bufio"
+	"encoding"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Source is a sequence of values that can be read once, from first to last,
+// like an input iterator.
+type Source[T any] interface {
+	// Returns the next value and true, or the zero value and false if the
+	// sequence is exhausted.
+	Next() (T, bool)
+}
+
+// IstreamIterator is a Source that parses whitespace-separated values of type
+// T from an io.Reader, like std::istream_iterator. Integers, floating-point
+// numbers, booleans and strings are parsed with package strconv; a string
+// value is a single whitespace-separated word. Types that implement
+// encoding.TextUnmarshaler parse themselves from each word, and all other types
+// are parsed with fmt.Fscan, so they can implement fmt.Scanner. Either way the
+// whole word must be consumed.
+//
+// The sequence ends at the end of the input or at the first word that cannot
+// be parsed, like an istream whose failbit is set. Err reports which.
+type IstreamIterator[T any] struct {
+	s   *bufio.Scanner
+	err error
+}
+
+// Constructs an IstreamIterator that reads values from r.
+func NewIstreamIterator[T any](r io.Reader) *IstreamIterator[T] {
+	s := bufio.NewScanner(r)
+	s.Split(bufio.ScanWords)
+	return &IstreamIterator[T]{s: s}
+}
+
+// Reads and returns the next value. Returns false at the end of the input or
+// if an error occurred.
+func (it *IstreamIterator[T]) Next() (T, bool) {
+	var v T
+	if it.err != nil || !it.s.Scan() {
+		return v, false
+	}
+	if err := parse(it.s.Text(), &v); err != nil {
+		it.err = fmt.Errorf("iterator: cannot parse %q as %T: %w", it.s.Text(), v, err)
+		return v, false
+	}
+	return v, true
+}
+
+// Returns the first error that ended the sequence, or nil if the sequence
+// ended at the end of the input or has not ended yet.
+func (it *IstreamIterator[T]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.s.Err()
+}
+
+// parse parses word into the value pointed to by p.
+func parse(word string, p any) error {
+	var err error
+	switch p := p.(type) {
+	case *string:
+		*p = word
+	case *int:
+		*p, err = strconv.Atoi(word)
+	case *int64:
+		*p, err = strconv.ParseInt(word, 10, 64)
+	case *int32:
+		var v int64
+		v, err = strconv.ParseInt(word, 10, 32)
+		*p = int32(v)
+	case *uint:
+		var v uint64
+		v, err = strconv.ParseUint(word, 10, 0)
+		*p = uint(v)
+	case *uint64:
+		*p, err = strconv.ParseUint(word, 10, 64)
+	case *uint32:
+		var v uint64
+		v, err = strconv.ParseUint(word, 10, 32)
+		*p = uint32(v)
+	case *float64:
+		*p, err = strconv.ParseFloat(word, 64)
+	case *float32:
+		var v float64
+		v, err = strconv.ParseFloat(word, 32)
+		*p = float32(v)
+	case *bool:
+		*p, err = strconv.ParseBool(word)
+	case encoding.TextUnmarshaler:
+		err = p.UnmarshalText([]byte(word))
+	default:
+		r := strings.NewReader(word)
+		if _, err = fmt.Fscan(r, p); err == nil && r.Len() != 0 {
+			err = fmt.Errorf("unexpected trailing characters %q", word[len(word)-r.Len():])
+		}
+	}
+	return err
+}
+
+// IstreambufIterator is a Source of the bytes of an io.Reader, read without
+// any parsing, like std::istreambuf_iterator.
+type IstreambufIterator struct {
+	r   *bufio.Reader
+	err error
+}
+
+// Constructs an IstreambufIterator that reads bytes from r.
+func NewIstreambufIterator(r io.Reader) *IstreambufIterator {
+	return &IstreambufIterator{r: bufio.NewReader(r)}
+}
+
+// Reads and returns the next byte. Returns false at the end of the input or
+// if an error occurred.
+func (it *IstreambufIterator) Next() (byte, bool) {
+	if it.err != nil {
+		return 0, false
+	}
+	b, err := it.r.ReadByte()
+	if err != nil {
+		it.err = err
+		return 0, false
+	}
+	return b, true
+}
+
+// Returns the first error that ended the sequence, or nil if the sequence
+// ended at the end of the input or has not ended yet.
+func (it *IstreambufIterator) Err() error {
+	if it.err == io.EOF {
+		return nil
+	}
+	return it.err
+}
+
+// OstreamIterator is a Sink that writes each value to an io.Writer, formatted
+// as by fmt.Fprint and followed by a delimiter, like std::ostream_iterator.
+// Since Put cannot fail, the first write error is recorded and later values
+// are dropped; Err reports it. Writes are not buffered, so w should usually be
+// a *bufio.Writer.
+type OstreamIterator[T any] struct {
+	w     io.Writer
+	delim string
+	err   error
+}
+
+// Constructs an OstreamIterator that writes values to w, each followed by
+// delim.
+func NewOstreamIterator[T any](w io.Writer, delim string) *OstreamIterator[T] {
+	return &OstreamIterator[T]{w: w, delim: delim}
+}
+
+// Writes v followed by the delimiter.
+func (it *OstreamIterator[T]) Put(v T) {
+	if it.err == nil {
+		_, it.err = fmt.Fprint(it.w, v, it.delim)
+	}
+}
+
+// Returns the first error that occurred while writing, if any.
+func (it *OstreamIterator[T]) Err() error {
+	return it.err
+}
+
+// OstreambufIterator is a Sink that writes bytes to an io.Writer without any
+// formatting, like std::ostreambuf_iterator. Since Put cannot fail, the first
+// write error is recorded and later bytes are dropped; Err reports it.
+type OstreambufIterator struct {
+	w   io.ByteWriter
+	err error
+}
+
+// Constructs an OstreambufIterator that writes bytes to w. If w does not
+// implement io.ByteWriter, bytes are written one at a time with w.Write.
+func NewOstreambufIterator(w io.Writer) *OstreambufIterator {
+	bw, ok := w.(io.ByteWriter)
+	if !ok {
+		bw = byteWriter{w}
+	}
+	return &OstreambufIterator{w: bw}
+}
+
+// Writes b.
+func (it *OstreambufIterator) Put(b byte) {
+	if it.err == nil {
+		it.err = it.w.WriteByte(b)
+	}
+}
+
+// Returns the first error that occurred while writing, if any.
+func (it *OstreambufIterator) Err() error {
+	return it.err
+}
+
+// byteWriter adapts an io.Writer to an io.ByteWriter.
+type byteWriter struct {
+	w io.Writer
+}
+
+func (w byteWriter) WriteByte(b byte) error {
+	_, err := w.w.Write([]byte{b})
+	return err
+}
@@ -0,0 +1,172 @@
+package stringalgo
+
+import (
+	"unicode/utf8"
+	"unsafe"
+)
+
+// bytesOf returns the bytes of s without copying them. The algorithms only
+// read them.
+func bytesOf(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// Checks if s begins with prefix.
+func StartsWithString(s, prefix string) bool {
+	return StartsWith(bytesOf(s), bytesOf(prefix))
+}
+
+// Checks if s ends with suffix.
+func EndsWithString(s, suffix string) bool {
+	return EndsWith(bytesOf(s), bytesOf(suffix))
+}
+
+// Splits s around each occurrence of sep, like Split. If sep is empty, splits
+// s after each UTF-8 sequence.
+func SplitString(s, sep string) []string {
+	if sep == "" {
+		ret := make([]string, 0, utf8.RuneCountInString(s))
+		for s != "" {
+			_, n := utf8.DecodeRuneInString(s)
+			ret = append(ret, s[:n])
+			s = s[n:]
+		}
+		return ret
+	}
+
+	b := bytesOf(s)
+	var ret []string
+	first := 0
+	for _, part := range Split(b, bytesOf(sep)) {
+		ret = append(ret, s[first:first+len(part)])
+		first += len(part) + len(sep)
+	}
+	return ret
+}
+
+// Splits s around each rune for which predicate p returns true, like
+// SplitFunc.
+func SplitFuncString(s string, p func(rune) bool) []string {
+	var ret []string
+	first := 0
+	for i, c := range s {
+		if p(c) {
+			// An invalid byte is decoded as utf8.RuneError, whose encoding is
+			// longer than the byte.
+			_, n := utf8.DecodeRuneInString(s[i:])
+			ret = append(ret, s[first:i])
+			first = i + n
+		}
+	}
+	return append(ret, s[first:])
+}
+
+// Splits s around each run of runes for which predicate p returns true, and
+// returns the non-empty substrings between them, like FieldsFunc.
+func FieldsFuncString(s string, p func(rune) bool) []string {
+	var ret []string
+	first := -1
+	for i, c := range s {
+		switch {
+		case p(c) && first >= 0:
+			ret = append(ret, s[first:i])
+			first = -1
+		case !p(c) && first < 0:
+			first = i
+		}
+	}
+	if first >= 0 {
+		ret = append(ret, s[first:])
+	}
+	return ret
+}
+
+// Concatenates parts into a new string, with sep between each pair of adjacent
+// ones.
+func JoinString(parts []string, sep string) string {
+	b := make([][]byte, len(parts))
+	for i, part := range parts {
+		b[i] = bytesOf(part)
+	}
+	return string(Join(b, bytesOf(sep)))
+}
+
+// Returns s without the leading runes for which predicate p returns true.
+func TrimLeftIfString(s string, p func(rune) bool) string {
+	for i, c := range s {
+		if !p(c) {
+			return s[i:]
+		}
+	}
+	return ""
+}
+
+// Returns s without the trailing runes for which predicate p returns true.
+func TrimRightIfString(s string, p func(rune) bool) string {
+	for s != "" {
+		c, n := utf8.DecodeLastRuneInString(s)
+		if !p(c) {
+			break
+		}
+		s = s[:len(s)-n]
+	}
+	return s
+}
+
+// Returns s without the leading and trailing runes for which predicate p
+// returns true.
+func TrimIfString(s string, p func(rune) bool) string {
+	return TrimRightIfString(TrimLeftIfString(s, p), p)
+}
+
+// Returns a copy of s with the non-overlapping occurrences of old replaced by
+// new, like ReplaceAll. If old is empty, new is inserted before each UTF-8
+// sequence and at the end.
+func ReplaceAllString(s, old, new string) string {
+	if old == "" {
+		return new + JoinString(append(SplitString(s, ""), ""), new)
+	}
+	return string(ReplaceAll(bytesOf(s), bytesOf(old), bytesOf(new)))
+}
+
+// Checks if the strings a and b are equal under simple Unicode case folding.
+func EqualFoldString(a, b string) bool {
+	return CompareFoldString(a, b) == 0
+}
+
+// Compares the strings a and b lexicographically by runes under simple
+// Unicode case folding, like CompareFold.
+func CompareFoldString(a, b string) int {
+	for a != "" && b != "" {
+		x, n := utf8.DecodeRuneInString(a)
+		y, m := utf8.DecodeRuneInString(b)
+		if x, y = foldRune(x), foldRune(y); x != y {
+			if x < y {
+				return -1
+			}
+			return +1
+		}
+		a, b = a[n:], b[m:]
+	}
+	switch {
+	case a != "":
+		return +1
+	case b != "":
+		return -1
+	}
+	return 0
+}
+
+// Searches for the first occurrence of sub in s under simple Unicode case
+// folding. Returns the byte offset of the occurrence, or len(s) if there is
+// none.
+func FindFoldString(s, sub string) int {
+	k := FindFold([]rune(s), []rune(sub))
+	for i := range s {
+		if k == 0 {
+			return i
+		}
+		k--
+	}
+	return len(s)
+}
@@ -0,0 +1,117 @@
+package stringalgo
+
+import (
+	"slices"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestStartsEndsWithString(t *testing.T) {
+	if !StartsWithString("gopher", "go") || StartsWithString("go", "gopher") || !EndsWithString("gopher", "her") || EndsWithString("gopher", "go") {
+		t.Errorf("StartsWithString or EndsWithString is wrong")
+	}
+}
+
+func TestSplitString(t *testing.T) {
+	for _, tt := range []struct {
+		s, sep string
+		want   []string
+	}{
+		{"a,b,,c", ",", []string{"a", "b", "", "c"}},
+		{"a::b", "::", []string{"a", "b"}},
+		{"", ",", []string{""}},
+		{"aé\xff", "", []string{"a", "é", "\xff"}},
+	} {
+		if got := SplitString(tt.s, tt.sep); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitString(%q, %q) = %q, want %q", tt.s, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestSplitFuncString(t *testing.T) {
+	isX := func(c rune) bool { return c == 'x' }
+	isInvalid := func(c rune) bool { return c == utf8.RuneError }
+	for _, tt := range []struct {
+		s    string
+		p    func(rune) bool
+		want []string
+	}{
+		{"axbxxc", isX, []string{"a", "b", "", "c"}},
+		{"éxé", isX, []string{"é", "é"}},
+		{"", isX, []string{""}},
+		// An invalid byte is one rune, utf8.RuneError, one byte wide.
+		{"\xffab", isInvalid, []string{"", "ab"}},
+		{"\xffa\xff", isInvalid, []string{"", "a", ""}},
+		{"a\xff\xffb", isInvalid, []string{"a", "", "b"}},
+	} {
+		if got := SplitFuncString(tt.s, tt.p); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitFuncString(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFieldsFuncString(t *testing.T) {
+	if got := FieldsFuncString("  a  bé ", isSpace); !slices.Equal(got, []string{"a", "bé"}) {
+		t.Errorf("FieldsFuncString = %q", got)
+	}
+	if got := FieldsFuncString("   ", isSpace); len(got) != 0 {
+		t.Errorf("FieldsFuncString of spaces = %q, want none", got)
+	}
+}
+
+func TestJoinString(t *testing.T) {
+	if got := JoinString([]string{"a", "", "b"}, ", "); got != "a, , b" {
+		t.Errorf("JoinString = %q", got)
+	}
+	if got := JoinString(nil, ", "); got != "" {
+		t.Errorf("JoinString of no parts = %q", got)
+	}
+}
+
+func TestTrimString(t *testing.T) {
+	if got := TrimLeftIfString("  a ", isSpace); got != "a " {
+		t.Errorf("TrimLeftIfString = %q", got)
+	}
+	if got := TrimRightIfString(" a  ", isSpace); got != " a" {
+		t.Errorf("TrimRightIfString = %q", got)
+	}
+	if got := TrimIfString("  é  ", isSpace); got != "é" {
+		t.Errorf("TrimIfString = %q", got)
+	}
+	if got := TrimIfString("   ", isSpace); got != "" {
+		t.Errorf("TrimIfString of spaces = %q", got)
+	}
+}
+
+func TestReplaceAllString(t *testing.T) {
+	for _, tt := range []struct {
+		s, old, new, want string
+	}{
+		{"banana", "na", "NA", "baNANA"},
+		{"aaa", "aa", "b", "ba"},
+		{"aé", "", "-", "-a-é-"},
+	} {
+		if got := ReplaceAllString(tt.s, tt.old, tt.new); got != tt.want {
+			t.Errorf("ReplaceAllString(%q, %q, %q) = %q, want %q", tt.s, tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestFoldString(t *testing.T) {
+	if !EqualFoldString("Gopher", "gOPHER") || EqualFoldString("Gopher", "Gophers") {
+		t.Errorf("EqualFoldString is wrong")
+	}
+	if got := CompareFoldString("ab", "AC"); got != -1 {
+		t.Errorf("CompareFoldString(ab, AC) = %d, want -1", got)
+	}
+	if got := CompareFoldString("abc", "AB"); got != +1 {
+		t.Errorf("CompareFoldString(abc, AB) = %d, want +1", got)
+	}
+	// The offset is in bytes: é takes two.
+	if got := FindFoldString("éxHELLO", "hello"); got != 3 {
+		t.Errorf("FindFoldString = %d, want 3", got)
+	}
+	if got := FindFoldString("abc", "x"); got != 3 {
+		t.Errorf("FindFoldString of a missing string = %d, want 3", got)
+	}
+}
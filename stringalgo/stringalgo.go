@@ -0,0 +1,215 @@
+// Package stringalgo provides string algorithms in the spirit of
+// Boost.StringAlgo: splitting, joining, trimming, prefix and suffix tests,
+// replacement of subsequences, and case-insensitive comparison and search.
+//
+// The algorithms are generic over sequences of any element type, such as
+// []byte, []rune or slices of token IDs, and work on whole sequences; pass
+// r[first:last] to work on a range. Sequences returned by the splitting and
+// trimming algorithms share the storage of their argument. The functions with
+// the String suffix take and return strings, and treat them as sequences of
+// bytes, or of runes where a predicate or case folding is involved.
+package stringalgo
+
+import (
+	"unicode"
+
+	"gocpp/algorithm"
+)
+
+// Checks if r begins with the sequence prefix.
+func StartsWith[S ~[]E, E comparable](r, prefix S) bool {
+	return len(prefix) <= len(r) && algorithm.Equal2(r, prefix, 0, len(prefix), 0, len(prefix))
+}
+
+// Checks if r begins with the sequence prefix. Elements are compared using the
+// given binary predicate p.
+func StartsWithFunc[S ~[]E, E any](r, prefix S, p func(E, E) bool) bool {
+	return len(prefix) <= len(r) && algorithm.EqualFunc2(r, prefix, 0, len(prefix), 0, len(prefix), p)
+}
+
+// Checks if r ends with the sequence suffix.
+func EndsWith[S ~[]E, E comparable](r, suffix S) bool {
+	n := len(r) - len(suffix)
+	return n >= 0 && algorithm.Equal2(r, suffix, n, len(r), 0, len(suffix))
+}
+
+// Checks if r ends with the sequence suffix. Elements are compared using the
+// given binary predicate p.
+func EndsWithFunc[S ~[]E, E any](r, suffix S, p func(E, E) bool) bool {
+	n := len(r) - len(suffix)
+	return n >= 0 && algorithm.EqualFunc2(r, suffix, n, len(r), 0, len(suffix), p)
+}
+
+// Splits r around each occurrence of the sequence sep, and returns the
+// subsequences between them; there is one more of them than there are
+// occurrences, and they may be empty. If sep is empty, splits r after each
+// element.
+func Split[S ~[]E, E comparable](r, sep S) []S {
+	if len(sep) == 0 {
+		ret := make([]S, len(r))
+		for i := range r {
+			ret[i] = r[i : i+1 : i+1]
+		}
+		return ret
+	}
+
+	var ret []S
+	first := 0
+	for {
+		it := algorithm.Search(r, sep, first, len(r), 0, len(sep))
+		ret = append(ret, r[first:it:it])
+		if it == len(r) {
+			return ret
+		}
+		first = it + len(sep)
+	}
+}
+
+// Splits r around each element for which predicate p returns true, and
+// returns the subsequences between them; there is one more of them than there
+// are such elements, and they may be empty.
+func SplitFunc[S ~[]E, E any](r S, p func(E) bool) []S {
+	var ret []S
+	first := 0
+	for {
+		it := algorithm.FindIf(r, first, len(r), p)
+		ret = append(ret, r[first:it:it])
+		if it == len(r) {
+			return ret
+		}
+		first = it + 1
+	}
+}
+
+// Splits r around each run of elements for which predicate p returns true, and
+// returns the non-empty subsequences between them, like SplitFunc with
+// adjacent delimiters compressed into one and empty tokens dropped.
+func FieldsFunc[S ~[]E, E any](r S, p func(E) bool) []S {
+	var ret []S
+	first := algorithm.FindIfNot(r, 0, len(r), p)
+	for first != len(r) {
+		it := algorithm.FindIf(r, first, len(r), p)
+		ret = append(ret, r[first:it:it])
+		first = algorithm.FindIfNot(r, it, len(r), p)
+	}
+	return ret
+}
+
+// Concatenates the sequences of parts into a new sequence, with sep between
+// each pair of adjacent ones.
+func Join[S ~[]E, E any](parts []S, sep S) S {
+	if len(parts) == 0 {
+		return S{}
+	}
+	n := len(sep) * (len(parts) - 1)
+	for _, part := range parts {
+		n += len(part)
+	}
+	ret := make(S, 0, n)
+	ret = append(ret, parts[0]...)
+	for _, part := range parts[1:] {
+		ret = append(ret, sep...)
+		ret = append(ret, part...)
+	}
+	return ret
+}
+
+// Returns the subsequence of r without the leading elements for which
+// predicate p returns true.
+func TrimLeftIf[S ~[]E, E any](r S, p func(E) bool) S {
+	first := algorithm.FindIfNot(r, 0, len(r), p)
+	return r[first:]
+}
+
+// Returns the subsequence of r without the trailing elements for which
+// predicate p returns true.
+func TrimRightIf[S ~[]E, E any](r S, p func(E) bool) S {
+	last := len(r)
+	for last > 0 && p(r[last-1]) {
+		last--
+	}
+	return r[:last]
+}
+
+// Returns the subsequence of r without the leading and trailing elements for
+// which predicate p returns true.
+func TrimIf[S ~[]E, E any](r S, p func(E) bool) S {
+	return TrimRightIf(TrimLeftIf(r, p), p)
+}
+
+// Returns a new sequence with the non-overlapping occurrences of the sequence
+// old in r, searched from the beginning, replaced by the sequence new. If old
+// is empty, new is inserted before each element and at the end.
+func ReplaceAll[S ~[]E, E comparable](r, old, new S) S {
+	if len(old) == 0 {
+		ret := make(S, 0, len(r)+len(new)*(len(r)+1))
+		for _, x := range r {
+			ret = append(ret, new...)
+			ret = append(ret, x)
+		}
+		return append(ret, new...)
+	}
+
+	ret := make(S, 0, len(r))
+	first := 0
+	for {
+		it := algorithm.Search(r, old, first, len(r), 0, len(old))
+		ret = append(ret, r[first:it]...)
+		if it == len(r) {
+			return ret
+		}
+		ret = append(ret, new...)
+		first = it + len(old)
+	}
+}
+
+// foldRune returns the canonical case of c: the smallest rune that is
+// equivalent to c under simple Unicode case folding.
+func foldRune(c rune) rune {
+	m := c
+	for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+		m = min(m, f)
+	}
+	return m
+}
+
+// Checks if the runes a and b are equal under simple Unicode case folding, as
+// in 'k' == 'K' == 'K' (the Kelvin sign). It is the predicate of the
+// case-insensitive algorithms, and can be passed to the Func variants.
+func EqualFoldRune(a, b rune) bool {
+	return a == b || foldRune(a) == foldRune(b)
+}
+
+// Checks if the sequences of runes a and b are equal under simple Unicode
+// case folding.
+func EqualFold[S ~[]rune](a, b S) bool {
+	return algorithm.EqualFunc2(a, b, 0, len(a), 0, len(b), EqualFoldRune)
+}
+
+// Compares the sequences of runes a and b lexicographically under simple
+// Unicode case folding. Returns -1 if a is less than b, +1 if a is greater
+// than b, and 0 if they are equal.
+func CompareFold[S ~[]rune](a, b S) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if x, y := foldRune(a[i]), foldRune(b[i]); x != y {
+			if x < y {
+				return -1
+			}
+			return +1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return +1
+	}
+	return 0
+}
+
+// Searches for the first occurrence of the sequence of runes s in r under
+// simple Unicode case folding. Returns the position of the occurrence, or
+// len(r) if there is none; an empty sequence is found at 0.
+func FindFold[S ~[]rune](r, s S) int {
+	return algorithm.SearchFunc(r, s, 0, len(r), 0, len(s), EqualFoldRune)
+}
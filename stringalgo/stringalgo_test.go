@@ -0,0 +1,134 @@
+package stringalgo
+
+import (
+	"slices"
+	"testing"
+)
+
+func isSpace(c rune) bool { return c == ' ' }
+
+func TestStartsEndsWith(t *testing.T) {
+	r := []int{1, 2, 3}
+	if !StartsWith(r, []int{1, 2}) || StartsWith(r, []int{2}) || !StartsWith(r, nil) || StartsWith(r, []int{1, 2, 3, 4}) {
+		t.Errorf("StartsWith is wrong")
+	}
+	if !EndsWith(r, []int{2, 3}) || EndsWith(r, []int{2}) || !EndsWith(r, nil) || EndsWith(r, []int{0, 1, 2, 3}) {
+		t.Errorf("EndsWith is wrong")
+	}
+	if !StartsWithFunc([]rune("Hello"), []rune("HE"), EqualFoldRune) || !EndsWithFunc([]rune("Hello"), []rune("LO"), EqualFoldRune) {
+		t.Errorf("StartsWithFunc or EndsWithFunc with EqualFoldRune is wrong")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tt := range []struct {
+		r, sep []int
+		want   [][]int
+	}{
+		{[]int{1, 0, 2, 0, 0, 3}, []int{0}, [][]int{{1}, {2}, {}, {3}}},
+		{[]int{1, 0, 2}, []int{1, 0}, [][]int{{}, {2}}},
+		{[]int{1, 2}, []int{3}, [][]int{{1, 2}}},
+		{[]int{}, []int{3}, [][]int{{}}},
+		{[]int{1, 2}, nil, [][]int{{1}, {2}}},
+	} {
+		got := Split(tt.r, tt.sep)
+		if !slices.EqualFunc(got, tt.want, slices.Equal[[]int]) {
+			t.Errorf("Split(%v, %v) = %v, want %v", tt.r, tt.sep, got, tt.want)
+		}
+	}
+
+	// The subsequences share the storage of r, but appending to one does not
+	// overwrite the next.
+	r := []int{1, 0, 2}
+	parts := Split(r, []int{0})
+	_ = append(parts[0], 9)
+	if r[1] != 0 {
+		t.Errorf("appending to a part of Split overwrote the separator: %v", r)
+	}
+}
+
+func TestSplitFunc(t *testing.T) {
+	got := SplitFunc([]rune(" a  b "), isSpace)
+	want := [][]rune{{}, []rune("a"), {}, []rune("b"), {}}
+	if !slices.EqualFunc(got, want, slices.Equal[[]rune]) {
+		t.Errorf("SplitFunc = %q, want %q", got, want)
+	}
+	got = FieldsFunc([]rune(" a  b "), isSpace)
+	want = [][]rune{[]rune("a"), []rune("b")}
+	if !slices.EqualFunc(got, want, slices.Equal[[]rune]) {
+		t.Errorf("FieldsFunc = %q, want %q", got, want)
+	}
+	if got := FieldsFunc([]rune("   "), isSpace); len(got) != 0 {
+		t.Errorf("FieldsFunc of separators = %q, want none", got)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if got := Join([][]int{{1}, {}, {2, 3}}, []int{0}); !slices.Equal(got, []int{1, 0, 0, 2, 3}) {
+		t.Errorf("Join = %v", got)
+	}
+	if got := Join(nil, []int{0}); got == nil || len(got) != 0 {
+		t.Errorf("Join of no parts = %#v, want an empty sequence", got)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	r := []rune("  a b  ")
+	if got := string(TrimLeftIf(r, isSpace)); got != "a b  " {
+		t.Errorf("TrimLeftIf = %q", got)
+	}
+	if got := string(TrimRightIf(r, isSpace)); got != "  a b" {
+		t.Errorf("TrimRightIf = %q", got)
+	}
+	if got := string(TrimIf(r, isSpace)); got != "a b" {
+		t.Errorf("TrimIf = %q", got)
+	}
+	if got := TrimIf([]rune("   "), isSpace); len(got) != 0 {
+		t.Errorf("TrimIf of spaces = %q", string(got))
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	for _, tt := range []struct {
+		r, old, new, want string
+	}{
+		{"aaa", "aa", "b", "ba"},
+		{"abcabc", "bc", "", "aa"},
+		{"abc", "x", "y", "abc"},
+		{"ab", "", "-", "-a-b-"},
+		{"", "", "-", "-"},
+	} {
+		if got := string(ReplaceAll([]byte(tt.r), []byte(tt.old), []byte(tt.new))); got != tt.want {
+			t.Errorf("ReplaceAll(%q, %q, %q) = %q, want %q", tt.r, tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	if !EqualFoldRune('k', 'K') || !EqualFoldRune('K', 'K') || EqualFoldRune('k', 'l') {
+		t.Errorf("EqualFoldRune is wrong for the Kelvin sign")
+	}
+	if !EqualFold([]rune("Straße"), []rune("STRAßE")) || EqualFold([]rune("ab"), []rune("abc")) {
+		t.Errorf("EqualFold is wrong")
+	}
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"abc", "ABC", 0},
+		{"abc", "ABD", -1},
+		{"abd", "ABC", +1},
+		{"ab", "ABC", -1},
+		{"abc", "AB", +1},
+	} {
+		if got := CompareFold([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("CompareFold(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if got := FindFold([]rune("xxHeLLo"), []rune("hello")); got != 2 {
+		t.Errorf("FindFold = %d, want 2", got)
+	}
+	if got := FindFold([]rune("abc"), []rune("d")); got != 3 {
+		t.Errorf("FindFold of a missing sequence = %d, want 3", got)
+	}
+}
@@ -0,0 +1,119 @@
+package utility
+
+import "fmt"
+
+// Expected holds either an expected value of type T or an unexpected value of
+// type E, like std::expected. The zero Expected holds the zero value of T.
+type Expected[T, E any] struct {
+	value T
+	err   E
+	bad   bool
+}
+
+// Creates an Expected that holds the expected value v.
+func MakeExpected[T, E any](v T) Expected[T, E] {
+	return Expected[T, E]{value: v}
+}
+
+// Creates an Expected that holds the unexpected value e, like
+// std::unexpected.
+func Unexpected[T, E any](e E) Expected[T, E] {
+	return Expected[T, E]{err: e, bad: true}
+}
+
+// Creates an Expected from the conventional Go result pair: it holds err if
+// err is not nil, and v otherwise.
+func ExpectedFrom[T any](v T, err error) Expected[T, error] {
+	if err != nil {
+		return Unexpected[T](err)
+	}
+	return MakeExpected[T, error](v)
+}
+
+// Checks whether the Expected holds an expected value.
+func (x Expected[T, E]) HasValue() bool {
+	return !x.bad
+}
+
+// Returns the expected value. Panics with the unexpected value if there is
+// none, like std::bad_expected_access.
+func (x Expected[T, E]) Value() T {
+	if x.bad {
+		panic(fmt.Sprintf("utility: bad expected access: %v", x.err))
+	}
+	return x.value
+}
+
+// Returns the unexpected value. Panics if the Expected holds an expected
+// value.
+func (x Expected[T, E]) Err() E {
+	if !x.bad {
+		panic("utility: Expected holds a value, not an error")
+	}
+	return x.err
+}
+
+// Returns the expected value if there is one, or d otherwise.
+func (x Expected[T, E]) ValueOr(d T) T {
+	if x.bad {
+		return d
+	}
+	return x.value
+}
+
+// Returns the unexpected value if there is one, or d otherwise.
+func (x Expected[T, E]) ErrOr(d E) E {
+	if x.bad {
+		return x.err
+	}
+	return d
+}
+
+// Exchanges the contents of the Expected with those of other.
+func (x *Expected[T, E]) Swap(other *Expected[T, E]) {
+	*x, *other = *other, *x
+}
+
+// Formats the expected value, or the unexpected value as unexpected(e).
+func (x Expected[T, E]) String() string {
+	if x.bad {
+		return fmt.Sprintf("unexpected(%v)", x.err)
+	}
+	return fmt.Sprint(x.value)
+}
+
+// Returns the result of f applied to the expected value if x has one, or x's
+// unexpected value otherwise, like std::expected::and_then.
+func ExpectedAndThen[T, U, E any](x Expected[T, E], f func(T) Expected[U, E]) Expected[U, E] {
+	if x.bad {
+		return Unexpected[U](x.err)
+	}
+	return f(x.value)
+}
+
+// Returns an Expected holding f applied to the expected value if x has one, or
+// x's unexpected value otherwise, like std::expected::transform.
+func ExpectedTransform[T, U, E any](x Expected[T, E], f func(T) U) Expected[U, E] {
+	if x.bad {
+		return Unexpected[U](x.err)
+	}
+	return MakeExpected[U, E](f(x.value))
+}
+
+// Returns x's expected value if it has one, or the result of f applied to the
+// unexpected value otherwise, like std::expected::or_else.
+func ExpectedOrElse[T, E, G any](x Expected[T, E], f func(E) Expected[T, G]) Expected[T, G] {
+	if x.bad {
+		return f(x.err)
+	}
+	return MakeExpected[T, G](x.value)
+}
+
+// Returns x's expected value if it has one, or an Expected holding f applied
+// to the unexpected value otherwise, like std::expected::transform_error.
+func ExpectedTransformError[T, E, G any](x Expected[T, E], f func(E) G) Expected[T, G] {
+	if x.bad {
+		return Unexpected[T](f(x.err))
+	}
+	return MakeExpected[T, G](x.value)
+}
@@ -0,0 +1,98 @@
+package utility
+
+import "fmt"
+
+// Optional manages an optional contained value, a value that may or may not be
+// present, like std::optional. The zero Optional contains no value.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Creates an Optional that contains v.
+func MakeOptional[T any](v T) Optional[T] {
+	return Optional[T]{v, true}
+}
+
+// Creates an Optional that contains no value, like std::nullopt. It is the
+// same as the zero Optional.
+func Nullopt[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Checks whether the Optional contains a value.
+func (o Optional[T]) HasValue() bool {
+	return o.ok
+}
+
+// Returns the contained value. Panics if the Optional contains no value, like
+// std::bad_optional_access.
+func (o Optional[T]) Value() T {
+	if !o.ok {
+		panic("utility: bad optional access")
+	}
+	return o.value
+}
+
+// Returns the contained value if the Optional has one, or d otherwise.
+func (o Optional[T]) ValueOr(d T) T {
+	if o.ok {
+		return o.value
+	}
+	return d
+}
+
+// Returns the contained value and whether there is one, in the comma-ok form.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// Returns the Optional itself if it contains a value, or the result of f
+// otherwise.
+func (o Optional[T]) OrElse(f func() Optional[T]) Optional[T] {
+	if o.ok {
+		return o
+	}
+	return f()
+}
+
+// Replaces the contained value with v.
+func (o *Optional[T]) Emplace(v T) {
+	*o = MakeOptional(v)
+}
+
+// Destroys any contained value, leaving the Optional empty.
+func (o *Optional[T]) Reset() {
+	*o = Optional[T]{}
+}
+
+// Exchanges the contents of the Optional with those of other.
+func (o *Optional[T]) Swap(other *Optional[T]) {
+	*o, *other = *other, *o
+}
+
+// Formats the contained value, or "nullopt" if there is none.
+func (o Optional[T]) String() string {
+	if !o.ok {
+		return "nullopt"
+	}
+	return fmt.Sprint(o.value)
+}
+
+// Returns the result of f applied to the contained value if o has one, or an
+// empty Optional otherwise, like std::optional::and_then.
+func OptionalAndThen[T, U any](o Optional[T], f func(T) Optional[U]) Optional[U] {
+	if !o.ok {
+		return Optional[U]{}
+	}
+	return f(o.value)
+}
+
+// Returns an Optional containing f applied to the contained value if o has
+// one, or an empty Optional otherwise, like std::optional::transform.
+func OptionalTransform[T, U any](o Optional[T], f func(T) U) Optional[U] {
+	if !o.ok {
+		return Optional[U]{}
+	}
+	return MakeOptional(f(o.value))
+}
@@ -0,0 +1,992 @@
+// Code generated by tuple_gen.go; DO NOT EDIT.
+
+package utility
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+)
+
+// unmarshalArray decodes a JSON array of exactly len(dst) elements into the
+// values pointed to by dst.
+func unmarshalArray(data []byte, name string, dst ...any) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(dst) {
+		return fmt.Errorf("utility: cannot unmarshal array of %d elements into %s", len(raw), name)
+	}
+	for i, r := range raw {
+		if err := json.Unmarshal(r, dst[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tuple2 is a fixed-size collection of 2 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 2-element
+// array.
+type Tuple2[T0, T1 any] struct {
+	V0 T0
+	V1 T1
+}
+
+// Creates a Tuple2 object, deducing the target type from the types of
+// arguments.
+func MakeTuple2[T0, T1 any](v0 T0, v1 T1) Tuple2[T0, T1] {
+	return Tuple2[T0, T1]{v0, v1}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1 := t.Get()
+func (t Tuple2[T0, T1]) Get() (T0, T1) {
+	return t.V0, t.V1
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple2[T0, T1]) Tie(p0 *T0, p1 *T1) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple2[T0, T1]) Swap(other *Tuple2[T0, T1]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple2[T0, T1]) String() string {
+	return fmt.Sprintf("(%v, %v)", t.V0, t.V1)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple2[T0, T1]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{t.V0, t.V1})
+}
+
+// Decodes the tuple from a JSON array of exactly 2 elements.
+func (t *Tuple2[T0, T1]) UnmarshalJSON(data []byte) error {
+	var ret Tuple2[T0, T1]
+	if err := unmarshalArray(data, "Tuple2", &ret.V0, &ret.V1); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple2[T0, T1]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 2 elements.
+func (t *Tuple2[T0, T1]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply2[T0, T1, R any](f func(T0, T1) R, t Tuple2[T0, T1]) R {
+	return f(t.V0, t.V1)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple2[T0, T1 cmp.Ordered](t, u Tuple2[T0, T1]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V1, u.V1)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple2[T0, T1 cmp.Ordered](t, u Tuple2[T0, T1]) bool {
+	return CompareTuple2(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple2 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple2Hasher[T0, T1 any](hash0 func(T0) uint64, hash1 func(T1) uint64) func(Tuple2[T0, T1]) uint64 {
+	return func(t Tuple2[T0, T1]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		return h
+	}
+}
+
+// Tuple3 is a fixed-size collection of 3 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 3-element
+// array.
+type Tuple3[T0, T1, T2 any] struct {
+	V0 T0
+	V1 T1
+	V2 T2
+}
+
+// Creates a Tuple3 object, deducing the target type from the types of
+// arguments.
+func MakeTuple3[T0, T1, T2 any](v0 T0, v1 T1, v2 T2) Tuple3[T0, T1, T2] {
+	return Tuple3[T0, T1, T2]{v0, v1, v2}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1, v2 := t.Get()
+func (t Tuple3[T0, T1, T2]) Get() (T0, T1, T2) {
+	return t.V0, t.V1, t.V2
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple3[T0, T1, T2]) Tie(p0 *T0, p1 *T1, p2 *T2) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+	if p2 != nil {
+		*p2 = t.V2
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple3[T0, T1, T2]) Swap(other *Tuple3[T0, T1, T2]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple3[T0, T1, T2]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", t.V0, t.V1, t.V2)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple3[T0, T1, T2]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]any{t.V0, t.V1, t.V2})
+}
+
+// Decodes the tuple from a JSON array of exactly 3 elements.
+func (t *Tuple3[T0, T1, T2]) UnmarshalJSON(data []byte) error {
+	var ret Tuple3[T0, T1, T2]
+	if err := unmarshalArray(data, "Tuple3", &ret.V0, &ret.V1, &ret.V2); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple3[T0, T1, T2]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 3 elements.
+func (t *Tuple3[T0, T1, T2]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply3[T0, T1, T2, R any](f func(T0, T1, T2) R, t Tuple3[T0, T1, T2]) R {
+	return f(t.V0, t.V1, t.V2)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple3[T0, T1, T2 cmp.Ordered](t, u Tuple3[T0, T1, T2]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V1, u.V1); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V2, u.V2)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple3[T0, T1, T2 cmp.Ordered](t, u Tuple3[T0, T1, T2]) bool {
+	return CompareTuple3(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple3 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple3Hasher[T0, T1, T2 any](hash0 func(T0) uint64, hash1 func(T1) uint64, hash2 func(T2) uint64) func(Tuple3[T0, T1, T2]) uint64 {
+	return func(t Tuple3[T0, T1, T2]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		h = HashCombine(h, hash2(t.V2))
+		return h
+	}
+}
+
+// Tuple4 is a fixed-size collection of 4 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 4-element
+// array.
+type Tuple4[T0, T1, T2, T3 any] struct {
+	V0 T0
+	V1 T1
+	V2 T2
+	V3 T3
+}
+
+// Creates a Tuple4 object, deducing the target type from the types of
+// arguments.
+func MakeTuple4[T0, T1, T2, T3 any](v0 T0, v1 T1, v2 T2, v3 T3) Tuple4[T0, T1, T2, T3] {
+	return Tuple4[T0, T1, T2, T3]{v0, v1, v2, v3}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1, v2, v3 := t.Get()
+func (t Tuple4[T0, T1, T2, T3]) Get() (T0, T1, T2, T3) {
+	return t.V0, t.V1, t.V2, t.V3
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple4[T0, T1, T2, T3]) Tie(p0 *T0, p1 *T1, p2 *T2, p3 *T3) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+	if p2 != nil {
+		*p2 = t.V2
+	}
+	if p3 != nil {
+		*p3 = t.V3
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple4[T0, T1, T2, T3]) Swap(other *Tuple4[T0, T1, T2, T3]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple4[T0, T1, T2, T3]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v)", t.V0, t.V1, t.V2, t.V3)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple4[T0, T1, T2, T3]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]any{t.V0, t.V1, t.V2, t.V3})
+}
+
+// Decodes the tuple from a JSON array of exactly 4 elements.
+func (t *Tuple4[T0, T1, T2, T3]) UnmarshalJSON(data []byte) error {
+	var ret Tuple4[T0, T1, T2, T3]
+	if err := unmarshalArray(data, "Tuple4", &ret.V0, &ret.V1, &ret.V2, &ret.V3); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple4[T0, T1, T2, T3]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 4 elements.
+func (t *Tuple4[T0, T1, T2, T3]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply4[T0, T1, T2, T3, R any](f func(T0, T1, T2, T3) R, t Tuple4[T0, T1, T2, T3]) R {
+	return f(t.V0, t.V1, t.V2, t.V3)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple4[T0, T1, T2, T3 cmp.Ordered](t, u Tuple4[T0, T1, T2, T3]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V1, u.V1); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V2, u.V2); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V3, u.V3)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple4[T0, T1, T2, T3 cmp.Ordered](t, u Tuple4[T0, T1, T2, T3]) bool {
+	return CompareTuple4(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple4 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple4Hasher[T0, T1, T2, T3 any](hash0 func(T0) uint64, hash1 func(T1) uint64, hash2 func(T2) uint64, hash3 func(T3) uint64) func(Tuple4[T0, T1, T2, T3]) uint64 {
+	return func(t Tuple4[T0, T1, T2, T3]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		h = HashCombine(h, hash2(t.V2))
+		h = HashCombine(h, hash3(t.V3))
+		return h
+	}
+}
+
+// Tuple5 is a fixed-size collection of 5 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 5-element
+// array.
+type Tuple5[T0, T1, T2, T3, T4 any] struct {
+	V0 T0
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+}
+
+// Creates a Tuple5 object, deducing the target type from the types of
+// arguments.
+func MakeTuple5[T0, T1, T2, T3, T4 any](v0 T0, v1 T1, v2 T2, v3 T3, v4 T4) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{v0, v1, v2, v3, v4}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1, v2, v3, v4 := t.Get()
+func (t Tuple5[T0, T1, T2, T3, T4]) Get() (T0, T1, T2, T3, T4) {
+	return t.V0, t.V1, t.V2, t.V3, t.V4
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple5[T0, T1, T2, T3, T4]) Tie(p0 *T0, p1 *T1, p2 *T2, p3 *T3, p4 *T4) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+	if p2 != nil {
+		*p2 = t.V2
+	}
+	if p3 != nil {
+		*p3 = t.V3
+	}
+	if p4 != nil {
+		*p4 = t.V4
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple5[T0, T1, T2, T3, T4]) Swap(other *Tuple5[T0, T1, T2, T3, T4]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple5[T0, T1, T2, T3, T4]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v, %v)", t.V0, t.V1, t.V2, t.V3, t.V4)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple5[T0, T1, T2, T3, T4]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([5]any{t.V0, t.V1, t.V2, t.V3, t.V4})
+}
+
+// Decodes the tuple from a JSON array of exactly 5 elements.
+func (t *Tuple5[T0, T1, T2, T3, T4]) UnmarshalJSON(data []byte) error {
+	var ret Tuple5[T0, T1, T2, T3, T4]
+	if err := unmarshalArray(data, "Tuple5", &ret.V0, &ret.V1, &ret.V2, &ret.V3, &ret.V4); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple5[T0, T1, T2, T3, T4]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 5 elements.
+func (t *Tuple5[T0, T1, T2, T3, T4]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply5[T0, T1, T2, T3, T4, R any](f func(T0, T1, T2, T3, T4) R, t Tuple5[T0, T1, T2, T3, T4]) R {
+	return f(t.V0, t.V1, t.V2, t.V3, t.V4)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple5[T0, T1, T2, T3, T4 cmp.Ordered](t, u Tuple5[T0, T1, T2, T3, T4]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V1, u.V1); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V2, u.V2); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V3, u.V3); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V4, u.V4)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple5[T0, T1, T2, T3, T4 cmp.Ordered](t, u Tuple5[T0, T1, T2, T3, T4]) bool {
+	return CompareTuple5(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple5 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple5Hasher[T0, T1, T2, T3, T4 any](hash0 func(T0) uint64, hash1 func(T1) uint64, hash2 func(T2) uint64, hash3 func(T3) uint64, hash4 func(T4) uint64) func(Tuple5[T0, T1, T2, T3, T4]) uint64 {
+	return func(t Tuple5[T0, T1, T2, T3, T4]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		h = HashCombine(h, hash2(t.V2))
+		h = HashCombine(h, hash3(t.V3))
+		h = HashCombine(h, hash4(t.V4))
+		return h
+	}
+}
+
+// Tuple6 is a fixed-size collection of 6 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 6-element
+// array.
+type Tuple6[T0, T1, T2, T3, T4, T5 any] struct {
+	V0 T0
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+	V5 T5
+}
+
+// Creates a Tuple6 object, deducing the target type from the types of
+// arguments.
+func MakeTuple6[T0, T1, T2, T3, T4, T5 any](v0 T0, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{v0, v1, v2, v3, v4, v5}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1, v2, v3, v4, v5 := t.Get()
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) Get() (T0, T1, T2, T3, T4, T5) {
+	return t.V0, t.V1, t.V2, t.V3, t.V4, t.V5
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) Tie(p0 *T0, p1 *T1, p2 *T2, p3 *T3, p4 *T4, p5 *T5) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+	if p2 != nil {
+		*p2 = t.V2
+	}
+	if p3 != nil {
+		*p3 = t.V3
+	}
+	if p4 != nil {
+		*p4 = t.V4
+	}
+	if p5 != nil {
+		*p5 = t.V5
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple6[T0, T1, T2, T3, T4, T5]) Swap(other *Tuple6[T0, T1, T2, T3, T4, T5]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v, %v, %v)", t.V0, t.V1, t.V2, t.V3, t.V4, t.V5)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([6]any{t.V0, t.V1, t.V2, t.V3, t.V4, t.V5})
+}
+
+// Decodes the tuple from a JSON array of exactly 6 elements.
+func (t *Tuple6[T0, T1, T2, T3, T4, T5]) UnmarshalJSON(data []byte) error {
+	var ret Tuple6[T0, T1, T2, T3, T4, T5]
+	if err := unmarshalArray(data, "Tuple6", &ret.V0, &ret.V1, &ret.V2, &ret.V3, &ret.V4, &ret.V5); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 6 elements.
+func (t *Tuple6[T0, T1, T2, T3, T4, T5]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply6[T0, T1, T2, T3, T4, T5, R any](f func(T0, T1, T2, T3, T4, T5) R, t Tuple6[T0, T1, T2, T3, T4, T5]) R {
+	return f(t.V0, t.V1, t.V2, t.V3, t.V4, t.V5)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple6[T0, T1, T2, T3, T4, T5 cmp.Ordered](t, u Tuple6[T0, T1, T2, T3, T4, T5]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V1, u.V1); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V2, u.V2); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V3, u.V3); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V4, u.V4); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V5, u.V5)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple6[T0, T1, T2, T3, T4, T5 cmp.Ordered](t, u Tuple6[T0, T1, T2, T3, T4, T5]) bool {
+	return CompareTuple6(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple6 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple6Hasher[T0, T1, T2, T3, T4, T5 any](hash0 func(T0) uint64, hash1 func(T1) uint64, hash2 func(T2) uint64, hash3 func(T3) uint64, hash4 func(T4) uint64, hash5 func(T5) uint64) func(Tuple6[T0, T1, T2, T3, T4, T5]) uint64 {
+	return func(t Tuple6[T0, T1, T2, T3, T4, T5]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		h = HashCombine(h, hash2(t.V2))
+		h = HashCombine(h, hash3(t.V3))
+		h = HashCombine(h, hash4(t.V4))
+		h = HashCombine(h, hash5(t.V5))
+		return h
+	}
+}
+
+// Tuple7 is a fixed-size collection of 7 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 7-element
+// array.
+type Tuple7[T0, T1, T2, T3, T4, T5, T6 any] struct {
+	V0 T0
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+	V5 T5
+	V6 T6
+}
+
+// Creates a Tuple7 object, deducing the target type from the types of
+// arguments.
+func MakeTuple7[T0, T1, T2, T3, T4, T5, T6 any](v0 T0, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{v0, v1, v2, v3, v4, v5, v6}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1, v2, v3, v4, v5, v6 := t.Get()
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) Get() (T0, T1, T2, T3, T4, T5, T6) {
+	return t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) Tie(p0 *T0, p1 *T1, p2 *T2, p3 *T3, p4 *T4, p5 *T5, p6 *T6) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+	if p2 != nil {
+		*p2 = t.V2
+	}
+	if p3 != nil {
+		*p3 = t.V3
+	}
+	if p4 != nil {
+		*p4 = t.V4
+	}
+	if p5 != nil {
+		*p5 = t.V5
+	}
+	if p6 != nil {
+		*p6 = t.V6
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple7[T0, T1, T2, T3, T4, T5, T6]) Swap(other *Tuple7[T0, T1, T2, T3, T4, T5, T6]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v, %v, %v, %v)", t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([7]any{t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6})
+}
+
+// Decodes the tuple from a JSON array of exactly 7 elements.
+func (t *Tuple7[T0, T1, T2, T3, T4, T5, T6]) UnmarshalJSON(data []byte) error {
+	var ret Tuple7[T0, T1, T2, T3, T4, T5, T6]
+	if err := unmarshalArray(data, "Tuple7", &ret.V0, &ret.V1, &ret.V2, &ret.V3, &ret.V4, &ret.V5, &ret.V6); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 7 elements.
+func (t *Tuple7[T0, T1, T2, T3, T4, T5, T6]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply7[T0, T1, T2, T3, T4, T5, T6, R any](f func(T0, T1, T2, T3, T4, T5, T6) R, t Tuple7[T0, T1, T2, T3, T4, T5, T6]) R {
+	return f(t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple7[T0, T1, T2, T3, T4, T5, T6 cmp.Ordered](t, u Tuple7[T0, T1, T2, T3, T4, T5, T6]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V1, u.V1); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V2, u.V2); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V3, u.V3); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V4, u.V4); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V5, u.V5); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V6, u.V6)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple7[T0, T1, T2, T3, T4, T5, T6 cmp.Ordered](t, u Tuple7[T0, T1, T2, T3, T4, T5, T6]) bool {
+	return CompareTuple7(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple7 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple7Hasher[T0, T1, T2, T3, T4, T5, T6 any](hash0 func(T0) uint64, hash1 func(T1) uint64, hash2 func(T2) uint64, hash3 func(T3) uint64, hash4 func(T4) uint64, hash5 func(T5) uint64, hash6 func(T6) uint64) func(Tuple7[T0, T1, T2, T3, T4, T5, T6]) uint64 {
+	return func(t Tuple7[T0, T1, T2, T3, T4, T5, T6]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		h = HashCombine(h, hash2(t.V2))
+		h = HashCombine(h, hash3(t.V3))
+		h = HashCombine(h, hash4(t.V4))
+		h = HashCombine(h, hash5(t.V5))
+		h = HashCombine(h, hash6(t.V6))
+		return h
+	}
+}
+
+// Tuple8 is a fixed-size collection of 8 heterogeneous values, like
+// std::tuple. Tuples marshal to and from JSON and text as a 8-element
+// array.
+type Tuple8[T0, T1, T2, T3, T4, T5, T6, T7 any] struct {
+	V0 T0
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+	V5 T5
+	V6 T6
+	V7 T7
+}
+
+// Creates a Tuple8 object, deducing the target type from the types of
+// arguments.
+func MakeTuple8[T0, T1, T2, T3, T4, T5, T6, T7 any](v0 T0, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7) Tuple8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]{v0, v1, v2, v3, v4, v5, v6, v7}
+}
+
+// Returns the elements of the tuple, for structured access:
+//
+//	v0, v1, v2, v3, v4, v5, v6, v7 := t.Get()
+func (t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) Get() (T0, T1, T2, T3, T4, T5, T6, T7) {
+	return t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6, t.V7
+}
+
+// Assigns the elements of the tuple to the variables pointed to by the
+// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding
+// element, like std::ignore.
+func (t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) Tie(p0 *T0, p1 *T1, p2 *T2, p3 *T3, p4 *T4, p5 *T5, p6 *T6, p7 *T7) {
+	if p0 != nil {
+		*p0 = t.V0
+	}
+	if p1 != nil {
+		*p1 = t.V1
+	}
+	if p2 != nil {
+		*p2 = t.V2
+	}
+	if p3 != nil {
+		*p3 = t.V3
+	}
+	if p4 != nil {
+		*p4 = t.V4
+	}
+	if p5 != nil {
+		*p5 = t.V5
+	}
+	if p6 != nil {
+		*p6 = t.V6
+	}
+	if p7 != nil {
+		*p7 = t.V7
+	}
+}
+
+// Swaps the elements of the tuple with those of other.
+func (t *Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) Swap(other *Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) {
+	*t, *other = *other, *t
+}
+
+// Formats the tuple as (V0, V1, ...).
+func (t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v, %v, %v, %v, %v)", t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6, t.V7)
+}
+
+// Encodes the tuple as a JSON array.
+func (t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([8]any{t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6, t.V7})
+}
+
+// Decodes the tuple from a JSON array of exactly 8 elements.
+func (t *Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) UnmarshalJSON(data []byte) error {
+	var ret Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]
+	if err := unmarshalArray(data, "Tuple8", &ret.V0, &ret.V1, &ret.V2, &ret.V3, &ret.V4, &ret.V5, &ret.V6, &ret.V7); err != nil {
+		return err
+	}
+	*t = ret
+	return nil
+}
+
+// Encodes the tuple as the text of a JSON array. This lets tuples be used as
+// JSON object keys.
+func (t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the tuple from the text of a JSON array of exactly 8 elements.
+func (t *Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// Invokes f with the elements of t as arguments, like std::apply.
+func Apply8[T0, T1, T2, T3, T4, T5, T6, T7, R any](f func(T0, T1, T2, T3, T4, T5, T6, T7) R, t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) R {
+	return f(t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6, t.V7)
+}
+
+// Compares t and u lexicographically: the first elements are compared first,
+// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1
+// like cmp.Compare.
+func CompareTuple8[T0, T1, T2, T3, T4, T5, T6, T7 cmp.Ordered](t, u Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) int {
+	if c := cmp.Compare(t.V0, u.V0); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V1, u.V1); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V2, u.V2); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V3, u.V3); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V4, u.V4); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V5, u.V5); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.V6, u.V6); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.V7, u.V7)
+}
+
+// Reports whether t is lexicographically less than u, like operator< on
+// std::tuple. It can be passed as the comparator of the *Func algorithms.
+func LessTuple8[T0, T1, T2, T3, T4, T5, T6, T7 cmp.Ordered](t, u Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) bool {
+	return CompareTuple8(t, u) < 0
+}
+
+// Returns a function that hashes a Tuple8 by combining the hashes of its
+// elements. The result can be used as the hash function of the unordered
+// containers.
+func Tuple8Hasher[T0, T1, T2, T3, T4, T5, T6, T7 any](hash0 func(T0) uint64, hash1 func(T1) uint64, hash2 func(T2) uint64, hash3 func(T3) uint64, hash4 func(T4) uint64, hash5 func(T5) uint64, hash6 func(T6) uint64, hash7 func(T7) uint64) func(Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) uint64 {
+	return func(t Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]) uint64 {
+		h := hash0(t.V0)
+		h = HashCombine(h, hash1(t.V1))
+		h = HashCombine(h, hash2(t.V2))
+		h = HashCombine(h, hash3(t.V3))
+		h = HashCombine(h, hash4(t.V4))
+		h = HashCombine(h, hash5(t.V5))
+		h = HashCombine(h, hash6(t.V6))
+		h = HashCombine(h, hash7(t.V7))
+		return h
+	}
+}
+
+// Constructs a Tuple4 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat2x2[T0, T1, T2, T3 any](t Tuple2[T0, T1], u Tuple2[T2, T3]) Tuple4[T0, T1, T2, T3] {
+	return Tuple4[T0, T1, T2, T3]{t.V0, t.V1, u.V0, u.V1}
+}
+
+// Constructs a Tuple5 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat2x3[T0, T1, T2, T3, T4 any](t Tuple2[T0, T1], u Tuple3[T2, T3, T4]) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{t.V0, t.V1, u.V0, u.V1, u.V2}
+}
+
+// Constructs a Tuple6 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat2x4[T0, T1, T2, T3, T4, T5 any](t Tuple2[T0, T1], u Tuple4[T2, T3, T4, T5]) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, u.V0, u.V1, u.V2, u.V3}
+}
+
+// Constructs a Tuple7 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat2x5[T0, T1, T2, T3, T4, T5, T6 any](t Tuple2[T0, T1], u Tuple5[T2, T3, T4, T5, T6]) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, u.V0, u.V1, u.V2, u.V3, u.V4}
+}
+
+// Constructs a Tuple8 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat2x6[T0, T1, T2, T3, T4, T5, T6, T7 any](t Tuple2[T0, T1], u Tuple6[T2, T3, T4, T5, T6, T7]) Tuple8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]{t.V0, t.V1, u.V0, u.V1, u.V2, u.V3, u.V4, u.V5}
+}
+
+// Constructs a Tuple5 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat3x2[T0, T1, T2, T3, T4 any](t Tuple3[T0, T1, T2], u Tuple2[T3, T4]) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{t.V0, t.V1, t.V2, u.V0, u.V1}
+}
+
+// Constructs a Tuple6 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat3x3[T0, T1, T2, T3, T4, T5 any](t Tuple3[T0, T1, T2], u Tuple3[T3, T4, T5]) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, t.V2, u.V0, u.V1, u.V2}
+}
+
+// Constructs a Tuple7 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat3x4[T0, T1, T2, T3, T4, T5, T6 any](t Tuple3[T0, T1, T2], u Tuple4[T3, T4, T5, T6]) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, u.V0, u.V1, u.V2, u.V3}
+}
+
+// Constructs a Tuple8 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat3x5[T0, T1, T2, T3, T4, T5, T6, T7 any](t Tuple3[T0, T1, T2], u Tuple5[T3, T4, T5, T6, T7]) Tuple8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]{t.V0, t.V1, t.V2, u.V0, u.V1, u.V2, u.V3, u.V4}
+}
+
+// Constructs a Tuple6 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat4x2[T0, T1, T2, T3, T4, T5 any](t Tuple4[T0, T1, T2, T3], u Tuple2[T4, T5]) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, t.V2, t.V3, u.V0, u.V1}
+}
+
+// Constructs a Tuple7 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat4x3[T0, T1, T2, T3, T4, T5, T6 any](t Tuple4[T0, T1, T2, T3], u Tuple3[T4, T5, T6]) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, t.V3, u.V0, u.V1, u.V2}
+}
+
+// Constructs a Tuple8 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat4x4[T0, T1, T2, T3, T4, T5, T6, T7 any](t Tuple4[T0, T1, T2, T3], u Tuple4[T4, T5, T6, T7]) Tuple8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]{t.V0, t.V1, t.V2, t.V3, u.V0, u.V1, u.V2, u.V3}
+}
+
+// Constructs a Tuple7 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat5x2[T0, T1, T2, T3, T4, T5, T6 any](t Tuple5[T0, T1, T2, T3, T4], u Tuple2[T5, T6]) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, t.V3, t.V4, u.V0, u.V1}
+}
+
+// Constructs a Tuple8 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat5x3[T0, T1, T2, T3, T4, T5, T6, T7 any](t Tuple5[T0, T1, T2, T3, T4], u Tuple3[T5, T6, T7]) Tuple8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]{t.V0, t.V1, t.V2, t.V3, t.V4, u.V0, u.V1, u.V2}
+}
+
+// Constructs a Tuple8 by concatenating the elements of t and u, like
+// std::tuple_cat.
+func TupleCat6x2[T0, T1, T2, T3, T4, T5, T6, T7 any](t Tuple6[T0, T1, T2, T3, T4, T5], u Tuple2[T6, T7]) Tuple8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Tuple8[T0, T1, T2, T3, T4, T5, T6, T7]{t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, u.V0, u.V1}
+}
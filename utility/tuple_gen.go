@@ -0,0 +1,171 @@
+//go:build ignore
+
+// This program generates tuple.go. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+const maxArity = 8
+
+func main() {
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by tuple_gen.go; DO NOT EDIT.
+
+package utility
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+)
+
+// unmarshalArray decodes a JSON array of exactly len(dst) elements into the
+// values pointed to by dst.
+func unmarshalArray(data []byte, name string, dst ...any) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(dst) {
+		return fmt.Errorf("utility: cannot unmarshal array of %d elements into %s", len(raw), name)
+	}
+	for i, r := range raw {
+		if err := json.Unmarshal(r, dst[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`)
+
+	for n := 2; n <= maxArity; n++ {
+		genTuple(&b, n)
+	}
+	for n := 2; n <= maxArity; n++ {
+		for m := 2; n+m <= maxArity; m++ {
+			genCat(&b, n, m)
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("tuple.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// list formats f(i) for each i in [first, last), separated by sep.
+func list(first, last int, sep string, f func(i int) string) string {
+	parts := make([]string, 0, last-first)
+	for i := first; i < last; i++ {
+		parts = append(parts, f(i))
+	}
+	return strings.Join(parts, sep)
+}
+
+func genTuple(b *bytes.Buffer, n int) {
+	name := fmt.Sprintf("Tuple%d", n)
+	typeParams := list(0, n, ", ", func(i int) string { return fmt.Sprintf("T%d", i) })
+	anyParams := typeParams + " any"
+	orderedParams := typeParams + " cmp.Ordered"
+	typ := fmt.Sprintf("%s[%s]", name, typeParams)
+	values := list(0, n, ", ", func(i int) string { return fmt.Sprintf("v%d", i) })
+	fields := list(0, n, ", ", func(i int) string { return fmt.Sprintf("t.V%d", i) })
+
+	fmt.Fprintf(b, "\n// %s is a fixed-size collection of %d heterogeneous values, like\n", name, n)
+	fmt.Fprintf(b, "// std::tuple. Tuples marshal to and from JSON and text as a %d-element\n// array.\n", n)
+	fmt.Fprintf(b, "type %s[%s] struct {\n", name, anyParams)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(b, "\tV%d T%d\n", i, i)
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\n// Creates a %s object, deducing the target type from the types of\n// arguments.\n", name)
+	fmt.Fprintf(b, "func Make%s[%s](%s) %s {\n", name, anyParams,
+		list(0, n, ", ", func(i int) string { return fmt.Sprintf("v%d T%d", i, i) }), typ)
+	fmt.Fprintf(b, "\treturn %s{%s}\n}\n", typ, values)
+
+	fmt.Fprintf(b, "\n// Returns the elements of the tuple, for structured access:\n//\n//\t%s := t.Get()\n", values)
+	fmt.Fprintf(b, "func (t %s) Get() (%s) {\n\treturn %s\n}\n", typ, typeParams, fields)
+
+	fmt.Fprintf(b, "\n// Assigns the elements of the tuple to the variables pointed to by the\n")
+	fmt.Fprintf(b, "// arguments, like std::tie(...) = t. A nil pointer ignores the corresponding\n// element, like std::ignore.\n")
+	fmt.Fprintf(b, "func (t %s) Tie(%s) {\n", typ,
+		list(0, n, ", ", func(i int) string { return fmt.Sprintf("p%d *T%d", i, i) }))
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(b, "\tif p%d != nil {\n\t\t*p%d = t.V%d\n\t}\n", i, i, i)
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\n// Swaps the elements of the tuple with those of other.\n")
+	fmt.Fprintf(b, "func (t *%s) Swap(other *%s) {\n\t*t, *other = *other, *t\n}\n", typ, typ)
+
+	fmt.Fprintf(b, "\n// Formats the tuple as (V0, V1, ...).\n")
+	fmt.Fprintf(b, "func (t %s) String() string {\n", typ)
+	fmt.Fprintf(b, "\treturn fmt.Sprintf(\"(%s)\", %s)\n}\n", list(0, n, ", ", func(int) string { return "%v" }), fields)
+
+	fmt.Fprintf(b, "\n// Encodes the tuple as a JSON array.\n")
+	fmt.Fprintf(b, "func (t %s) MarshalJSON() ([]byte, error) {\n", typ)
+	fmt.Fprintf(b, "\treturn json.Marshal([%d]any{%s})\n}\n", n, fields)
+
+	fmt.Fprintf(b, "\n// Decodes the tuple from a JSON array of exactly %d elements.\n", n)
+	fmt.Fprintf(b, "func (t *%s) UnmarshalJSON(data []byte) error {\n", typ)
+	fmt.Fprintf(b, "\tvar ret %s\n", typ)
+	fmt.Fprintf(b, "\tif err := unmarshalArray(data, %q, %s); err != nil {\n\t\treturn err\n\t}\n", name,
+		list(0, n, ", ", func(i int) string { return fmt.Sprintf("&ret.V%d", i) }))
+	b.WriteString("\t*t = ret\n\treturn nil\n}\n")
+
+	fmt.Fprintf(b, "\n// Encodes the tuple as the text of a JSON array. This lets tuples be used as\n// JSON object keys.\n")
+	fmt.Fprintf(b, "func (t %s) MarshalText() ([]byte, error) {\n\treturn t.MarshalJSON()\n}\n", typ)
+
+	fmt.Fprintf(b, "\n// Decodes the tuple from the text of a JSON array of exactly %d elements.\n", n)
+	fmt.Fprintf(b, "func (t *%s) UnmarshalText(text []byte) error {\n\treturn t.UnmarshalJSON(text)\n}\n", typ)
+
+	fmt.Fprintf(b, "\n// Invokes f with the elements of t as arguments, like std::apply.\n")
+	fmt.Fprintf(b, "func Apply%d[%s, R any](f func(%s) R, t %s) R {\n", n, typeParams, typeParams, typ)
+	fmt.Fprintf(b, "\treturn f(%s)\n}\n", fields)
+
+	fmt.Fprintf(b, "\n// Compares t and u lexicographically: the first elements are compared first,\n")
+	fmt.Fprintf(b, "// and each later pair only if all earlier ones are equal. Returns -1, 0 or +1\n// like cmp.Compare.\n")
+	fmt.Fprintf(b, "func Compare%s[%s](t, u %s) int {\n", name, orderedParams, typ)
+	for i := 0; i < n-1; i++ {
+		fmt.Fprintf(b, "\tif c := cmp.Compare(t.V%d, u.V%d); c != 0 {\n\t\treturn c\n\t}\n", i, i)
+	}
+	fmt.Fprintf(b, "\treturn cmp.Compare(t.V%d, u.V%d)\n}\n", n-1, n-1)
+
+	fmt.Fprintf(b, "\n// Reports whether t is lexicographically less than u, like operator< on\n")
+	fmt.Fprintf(b, "// std::tuple. It can be passed as the comparator of the *Func algorithms.\n")
+	fmt.Fprintf(b, "func Less%s[%s](t, u %s) bool {\n\treturn Compare%s(t, u) < 0\n}\n", name, orderedParams, typ, name)
+
+	fmt.Fprintf(b, "\n// Returns a function that hashes a %s by combining the hashes of its\n", name)
+	fmt.Fprintf(b, "// elements. The result can be used as the hash function of the unordered\n// containers.\n")
+	fmt.Fprintf(b, "func %sHasher[%s](%s) func(%s) uint64 {\n", name, anyParams,
+		list(0, n, ", ", func(i int) string { return fmt.Sprintf("hash%d func(T%d) uint64", i, i) }), typ)
+	fmt.Fprintf(b, "\treturn func(t %s) uint64 {\n\t\th := hash0(t.V0)\n", typ)
+	for i := 1; i < n; i++ {
+		fmt.Fprintf(b, "\t\th = HashCombine(h, hash%d(t.V%d))\n", i, i)
+	}
+	b.WriteString("\t\treturn h\n\t}\n}\n")
+}
+
+func genCat(b *bytes.Buffer, n, m int) {
+	params := func(first, last, offset int) string {
+		return list(first, last, ", ", func(i int) string { return fmt.Sprintf("T%d", i+offset) })
+	}
+	all := params(0, n+m, 0)
+	fmt.Fprintf(b, "\n// Constructs a Tuple%d by concatenating the elements of t and u, like\n// std::tuple_cat.\n", n+m)
+	fmt.Fprintf(b, "func TupleCat%dx%d[%s any](t Tuple%d[%s], u Tuple%d[%s]) Tuple%d[%s] {\n",
+		n, m, all, n, params(0, n, 0), m, params(0, m, n), n+m, all)
+	fmt.Fprintf(b, "\treturn Tuple%d[%s]{%s, %s}\n}\n", n+m, all,
+		list(0, n, ", ", func(i int) string { return fmt.Sprintf("t.V%d", i) }),
+		list(0, m, ", ", func(i int) string { return fmt.Sprintf("u.V%d", i) }))
+}
@@ -1,10 +1,122 @@
 package utility
 
+//go:generate go run tuple_gen.go
+//go:generate go run variant_gen.go
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+)
+
+// Pair stores two heterogeneous objects as a single unit, like std::pair.
+// Pairs marshal to and from JSON and text as a two-element array.
 type Pair[T1, T2 any] struct {
-	first  T1
-	second T2
+	First  T1
+	Second T2
 }
 
+// Creates a Pair object, deducing the target type from the types of
+// arguments.
 func MakePair[T1, T2 any](t T1, u T2) Pair[T1, T2] {
 	return Pair[T1, T2]{t, u}
 }
+
+// Returns both elements of the pair, for structured access:
+//
+//	first, second := p.Get()
+func (p Pair[T1, T2]) Get() (T1, T2) {
+	return p.First, p.Second
+}
+
+// Assigns the elements of the pair to the variables pointed to by first and
+// second, like std::tie(first, second) = p. A nil pointer ignores the
+// corresponding element, like std::ignore.
+func (p Pair[T1, T2]) Tie(first *T1, second *T2) {
+	if first != nil {
+		*first = p.First
+	}
+	if second != nil {
+		*second = p.Second
+	}
+}
+
+// Swaps First with other.First and Second with other.Second.
+func (p *Pair[T1, T2]) Swap(other *Pair[T1, T2]) {
+	*p, *other = *other, *p
+}
+
+// Formats the pair as (First, Second).
+func (p Pair[T1, T2]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Encodes the pair as the JSON array [First, Second].
+func (p Pair[T1, T2]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{p.First, p.Second})
+}
+
+// Decodes the pair from a JSON array of exactly two elements.
+func (p *Pair[T1, T2]) UnmarshalJSON(data []byte) error {
+	var ret Pair[T1, T2]
+	if err := unmarshalArray(data, "Pair", &ret.First, &ret.Second); err != nil {
+		return err
+	}
+	*p = ret
+	return nil
+}
+
+// Encodes the pair as the text of the JSON array [First, Second]. This lets
+// pairs be used as JSON object keys.
+func (p Pair[T1, T2]) MarshalText() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+// Decodes the pair from the text of a JSON array of exactly two elements.
+func (p *Pair[T1, T2]) UnmarshalText(text []byte) error {
+	return p.UnmarshalJSON(text)
+}
+
+// Compares p and q lexicographically: First is compared first, and Second
+// only if the First elements are equal. Returns -1, 0 or +1 like cmp.Compare.
+func ComparePair[T1, T2 cmp.Ordered](p, q Pair[T1, T2]) int {
+	if c := cmp.Compare(p.First, q.First); c != 0 {
+		return c
+	}
+	return cmp.Compare(p.Second, q.Second)
+}
+
+// Reports whether p is lexicographically less than q, like operator< on
+// std::pair. It can be passed as the comparator of the *Func algorithms.
+func LessPair[T1, T2 cmp.Ordered](p, q Pair[T1, T2]) bool {
+	return ComparePair(p, q) < 0
+}
+
+// Returns a function that hashes a Pair by combining the hashes of its
+// elements computed by hash1 and hash2. The result can be used as the hash
+// function of the unordered containers.
+func PairHasher[T1, T2 any](hash1 func(T1) uint64, hash2 func(T2) uint64) func(Pair[T1, T2]) uint64 {
+	return func(p Pair[T1, T2]) uint64 {
+		return HashCombine(hash1(p.First), hash2(p.Second))
+	}
+}
+
+// Monostate is a unit type intended for use as a well-behaved empty
+// alternative in a variant, like std::monostate.
+type Monostate struct{}
+
+// Variant is implemented by every VariantN type. Alternatives are identified
+// by index, as in HoldsAlternative0 or Get1, rather than by type, so that
+// repeated alternative types are told apart and asking for a type that is not
+// an alternative fails to compile.
+type Variant interface {
+	Index() int
+	Interface() any
+}
+
+// Combines the hash h of another value into seed and returns the result, as
+// boost::hash_combine does. It is used to hash composite keys, such as structs
+// with slice fields, one field at a time, for the unordered containers.
+func HashCombine(seed, h uint64) uint64 {
+	return seed ^ (h + 0x9e3779b97f4a7c15 + (seed << 6) + (seed >> 2))
+}
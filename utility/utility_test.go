@@ -0,0 +1,49 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHoldsAlternative(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Variant
+		hold []bool
+	}{
+		// A nil interface value is still the alternative it was stored as.
+		{"nil error", Variant2Alt0[error, int](nil), []bool{true, false}},
+		{"error", Variant2Alt0[error, int](errors.New("e")), []bool{true, false}},
+		{"int", Variant2Alt1[error, int](1), []bool{false, true}},
+		{"nil any", Variant3Alt1[int, any, string](nil), []bool{false, true, false}},
+		{"string", Variant3Alt2[int, any, string]("x"), []bool{false, false, true}},
+		// Repeated alternative types are told apart by index.
+		{"first int", Variant2Alt0[int, int](1), []bool{true, false}},
+		{"second int", Variant2Alt1[int, int](1), []bool{false, true}},
+		{"zero value", Variant2[string, int]{}, []bool{true, false}},
+	}
+	for _, tt := range tests {
+		var got []bool
+		switch v := tt.v.(type) {
+		case Variant2[error, int]:
+			got = []bool{v.HoldsAlternative0(), v.HoldsAlternative1()}
+		case Variant2[int, int]:
+			got = []bool{v.HoldsAlternative0(), v.HoldsAlternative1()}
+		case Variant2[string, int]:
+			got = []bool{v.HoldsAlternative0(), v.HoldsAlternative1()}
+		case Variant3[int, any, string]:
+			got = []bool{v.HoldsAlternative0(), v.HoldsAlternative1(), v.HoldsAlternative2()}
+		}
+		for i := range tt.hold {
+			if got[i] != tt.hold[i] || got[i] != (tt.v.Index() == i) {
+				t.Errorf("%s: HoldsAlternative%d() = %v with Index() %d, want %v", tt.name, i, got[i], tt.v.Index(), tt.hold[i])
+			}
+		}
+	}
+
+	v := Variant8Alt0[int, int, int, int, int, int, int, int](0)
+	v.Emplace7(1)
+	if v.HoldsAlternative0() || !v.HoldsAlternative7() {
+		t.Errorf("HoldsAlternative after Emplace7 is wrong")
+	}
+}
@@ -0,0 +1,1608 @@
+// Code generated by variant_gen.go; DO NOT EDIT.
+
+package utility
+
+import "fmt"
+
+// Variant2 is a type-safe union that holds a value of exactly one of 2
+// alternative types, like std::variant. The zero Variant2 holds the zero value
+// of T0.
+type Variant2[T0, T1 any] struct {
+	index int
+	v0    T0
+	v1    T1
+}
+
+// Creates a Variant2 that holds the alternative T0 with value x.
+func Variant2Alt0[T0, T1 any](x T0) Variant2[T0, T1] {
+	return Variant2[T0, T1]{index: 0, v0: x}
+}
+
+// Creates a Variant2 that holds the alternative T1 with value x.
+func Variant2Alt1[T0, T1 any](x T1) Variant2[T0, T1] {
+	return Variant2[T0, T1]{index: 1, v1: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant2[T0, T1]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant2[T0, T1]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant2[T0, T1]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant2[T0, T1]) Emplace0(x T0) {
+	*v = Variant2[T0, T1]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant2[T0, T1]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant2[T0, T1]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant2.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant2[T0, T1]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant2[T0, T1]) Emplace1(x T1) {
+	*v = Variant2[T0, T1]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant2[T0, T1]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant2[T0, T1]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant2.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant2[T0, T1]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant2[T0, T1]) Swap(other *Variant2[T0, T1]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit2[T0, T1, R any](v Variant2[T0, T1], f0 func(T0) R, f1 func(T1) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	}
+	return f0(v.v0)
+}
+
+// Variant3 is a type-safe union that holds a value of exactly one of 3
+// alternative types, like std::variant. The zero Variant3 holds the zero value
+// of T0.
+type Variant3[T0, T1, T2 any] struct {
+	index int
+	v0    T0
+	v1    T1
+	v2    T2
+}
+
+// Creates a Variant3 that holds the alternative T0 with value x.
+func Variant3Alt0[T0, T1, T2 any](x T0) Variant3[T0, T1, T2] {
+	return Variant3[T0, T1, T2]{index: 0, v0: x}
+}
+
+// Creates a Variant3 that holds the alternative T1 with value x.
+func Variant3Alt1[T0, T1, T2 any](x T1) Variant3[T0, T1, T2] {
+	return Variant3[T0, T1, T2]{index: 1, v1: x}
+}
+
+// Creates a Variant3 that holds the alternative T2 with value x.
+func Variant3Alt2[T0, T1, T2 any](x T2) Variant3[T0, T1, T2] {
+	return Variant3[T0, T1, T2]{index: 2, v2: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant3[T0, T1, T2]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant3[T0, T1, T2]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	case 2:
+		return v.v2
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant3[T0, T1, T2]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant3[T0, T1, T2]) Emplace0(x T0) {
+	*v = Variant3[T0, T1, T2]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant3[T0, T1, T2]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant3[T0, T1, T2]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant3.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant3[T0, T1, T2]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant3[T0, T1, T2]) Emplace1(x T1) {
+	*v = Variant3[T0, T1, T2]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant3[T0, T1, T2]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant3[T0, T1, T2]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant3.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant3[T0, T1, T2]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Destroys the held value and makes the variant hold the alternative T2
+// with value x.
+func (v *Variant3[T0, T1, T2]) Emplace2(x T2) {
+	*v = Variant3[T0, T1, T2]{index: 2, v2: x}
+}
+
+// Checks if the variant holds alternative T2, like std::holds_alternative.
+func (v Variant3[T0, T1, T2]) HoldsAlternative2() bool {
+	return v.index == 2
+}
+
+// Returns the value of alternative T2. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant3[T0, T1, T2]) Get2() T2 {
+	if v.index != 2 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant3.Get2 on alternative %d", v.index))
+	}
+	return v.v2
+}
+
+// Returns a pointer to the value of alternative T2 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant3[T0, T1, T2]) GetIf2() *T2 {
+	if v.index != 2 {
+		return nil
+	}
+	return &v.v2
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant3[T0, T1, T2]) Swap(other *Variant3[T0, T1, T2]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit3[T0, T1, T2, R any](v Variant3[T0, T1, T2], f0 func(T0) R, f1 func(T1) R, f2 func(T2) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	case 2:
+		return f2(v.v2)
+	}
+	return f0(v.v0)
+}
+
+// Variant4 is a type-safe union that holds a value of exactly one of 4
+// alternative types, like std::variant. The zero Variant4 holds the zero value
+// of T0.
+type Variant4[T0, T1, T2, T3 any] struct {
+	index int
+	v0    T0
+	v1    T1
+	v2    T2
+	v3    T3
+}
+
+// Creates a Variant4 that holds the alternative T0 with value x.
+func Variant4Alt0[T0, T1, T2, T3 any](x T0) Variant4[T0, T1, T2, T3] {
+	return Variant4[T0, T1, T2, T3]{index: 0, v0: x}
+}
+
+// Creates a Variant4 that holds the alternative T1 with value x.
+func Variant4Alt1[T0, T1, T2, T3 any](x T1) Variant4[T0, T1, T2, T3] {
+	return Variant4[T0, T1, T2, T3]{index: 1, v1: x}
+}
+
+// Creates a Variant4 that holds the alternative T2 with value x.
+func Variant4Alt2[T0, T1, T2, T3 any](x T2) Variant4[T0, T1, T2, T3] {
+	return Variant4[T0, T1, T2, T3]{index: 2, v2: x}
+}
+
+// Creates a Variant4 that holds the alternative T3 with value x.
+func Variant4Alt3[T0, T1, T2, T3 any](x T3) Variant4[T0, T1, T2, T3] {
+	return Variant4[T0, T1, T2, T3]{index: 3, v3: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant4[T0, T1, T2, T3]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant4[T0, T1, T2, T3]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	case 2:
+		return v.v2
+	case 3:
+		return v.v3
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant4[T0, T1, T2, T3]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant4[T0, T1, T2, T3]) Emplace0(x T0) {
+	*v = Variant4[T0, T1, T2, T3]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant4[T0, T1, T2, T3]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant4[T0, T1, T2, T3]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant4.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant4[T0, T1, T2, T3]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant4[T0, T1, T2, T3]) Emplace1(x T1) {
+	*v = Variant4[T0, T1, T2, T3]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant4[T0, T1, T2, T3]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant4[T0, T1, T2, T3]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant4.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant4[T0, T1, T2, T3]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Destroys the held value and makes the variant hold the alternative T2
+// with value x.
+func (v *Variant4[T0, T1, T2, T3]) Emplace2(x T2) {
+	*v = Variant4[T0, T1, T2, T3]{index: 2, v2: x}
+}
+
+// Checks if the variant holds alternative T2, like std::holds_alternative.
+func (v Variant4[T0, T1, T2, T3]) HoldsAlternative2() bool {
+	return v.index == 2
+}
+
+// Returns the value of alternative T2. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant4[T0, T1, T2, T3]) Get2() T2 {
+	if v.index != 2 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant4.Get2 on alternative %d", v.index))
+	}
+	return v.v2
+}
+
+// Returns a pointer to the value of alternative T2 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant4[T0, T1, T2, T3]) GetIf2() *T2 {
+	if v.index != 2 {
+		return nil
+	}
+	return &v.v2
+}
+
+// Destroys the held value and makes the variant hold the alternative T3
+// with value x.
+func (v *Variant4[T0, T1, T2, T3]) Emplace3(x T3) {
+	*v = Variant4[T0, T1, T2, T3]{index: 3, v3: x}
+}
+
+// Checks if the variant holds alternative T3, like std::holds_alternative.
+func (v Variant4[T0, T1, T2, T3]) HoldsAlternative3() bool {
+	return v.index == 3
+}
+
+// Returns the value of alternative T3. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant4[T0, T1, T2, T3]) Get3() T3 {
+	if v.index != 3 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant4.Get3 on alternative %d", v.index))
+	}
+	return v.v3
+}
+
+// Returns a pointer to the value of alternative T3 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant4[T0, T1, T2, T3]) GetIf3() *T3 {
+	if v.index != 3 {
+		return nil
+	}
+	return &v.v3
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant4[T0, T1, T2, T3]) Swap(other *Variant4[T0, T1, T2, T3]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit4[T0, T1, T2, T3, R any](v Variant4[T0, T1, T2, T3], f0 func(T0) R, f1 func(T1) R, f2 func(T2) R, f3 func(T3) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	case 2:
+		return f2(v.v2)
+	case 3:
+		return f3(v.v3)
+	}
+	return f0(v.v0)
+}
+
+// Variant5 is a type-safe union that holds a value of exactly one of 5
+// alternative types, like std::variant. The zero Variant5 holds the zero value
+// of T0.
+type Variant5[T0, T1, T2, T3, T4 any] struct {
+	index int
+	v0    T0
+	v1    T1
+	v2    T2
+	v3    T3
+	v4    T4
+}
+
+// Creates a Variant5 that holds the alternative T0 with value x.
+func Variant5Alt0[T0, T1, T2, T3, T4 any](x T0) Variant5[T0, T1, T2, T3, T4] {
+	return Variant5[T0, T1, T2, T3, T4]{index: 0, v0: x}
+}
+
+// Creates a Variant5 that holds the alternative T1 with value x.
+func Variant5Alt1[T0, T1, T2, T3, T4 any](x T1) Variant5[T0, T1, T2, T3, T4] {
+	return Variant5[T0, T1, T2, T3, T4]{index: 1, v1: x}
+}
+
+// Creates a Variant5 that holds the alternative T2 with value x.
+func Variant5Alt2[T0, T1, T2, T3, T4 any](x T2) Variant5[T0, T1, T2, T3, T4] {
+	return Variant5[T0, T1, T2, T3, T4]{index: 2, v2: x}
+}
+
+// Creates a Variant5 that holds the alternative T3 with value x.
+func Variant5Alt3[T0, T1, T2, T3, T4 any](x T3) Variant5[T0, T1, T2, T3, T4] {
+	return Variant5[T0, T1, T2, T3, T4]{index: 3, v3: x}
+}
+
+// Creates a Variant5 that holds the alternative T4 with value x.
+func Variant5Alt4[T0, T1, T2, T3, T4 any](x T4) Variant5[T0, T1, T2, T3, T4] {
+	return Variant5[T0, T1, T2, T3, T4]{index: 4, v4: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant5[T0, T1, T2, T3, T4]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant5[T0, T1, T2, T3, T4]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	case 2:
+		return v.v2
+	case 3:
+		return v.v3
+	case 4:
+		return v.v4
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant5[T0, T1, T2, T3, T4]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant5[T0, T1, T2, T3, T4]) Emplace0(x T0) {
+	*v = Variant5[T0, T1, T2, T3, T4]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant5[T0, T1, T2, T3, T4]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant5[T0, T1, T2, T3, T4]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant5.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant5[T0, T1, T2, T3, T4]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant5[T0, T1, T2, T3, T4]) Emplace1(x T1) {
+	*v = Variant5[T0, T1, T2, T3, T4]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant5[T0, T1, T2, T3, T4]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant5[T0, T1, T2, T3, T4]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant5.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant5[T0, T1, T2, T3, T4]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Destroys the held value and makes the variant hold the alternative T2
+// with value x.
+func (v *Variant5[T0, T1, T2, T3, T4]) Emplace2(x T2) {
+	*v = Variant5[T0, T1, T2, T3, T4]{index: 2, v2: x}
+}
+
+// Checks if the variant holds alternative T2, like std::holds_alternative.
+func (v Variant5[T0, T1, T2, T3, T4]) HoldsAlternative2() bool {
+	return v.index == 2
+}
+
+// Returns the value of alternative T2. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant5[T0, T1, T2, T3, T4]) Get2() T2 {
+	if v.index != 2 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant5.Get2 on alternative %d", v.index))
+	}
+	return v.v2
+}
+
+// Returns a pointer to the value of alternative T2 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant5[T0, T1, T2, T3, T4]) GetIf2() *T2 {
+	if v.index != 2 {
+		return nil
+	}
+	return &v.v2
+}
+
+// Destroys the held value and makes the variant hold the alternative T3
+// with value x.
+func (v *Variant5[T0, T1, T2, T3, T4]) Emplace3(x T3) {
+	*v = Variant5[T0, T1, T2, T3, T4]{index: 3, v3: x}
+}
+
+// Checks if the variant holds alternative T3, like std::holds_alternative.
+func (v Variant5[T0, T1, T2, T3, T4]) HoldsAlternative3() bool {
+	return v.index == 3
+}
+
+// Returns the value of alternative T3. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant5[T0, T1, T2, T3, T4]) Get3() T3 {
+	if v.index != 3 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant5.Get3 on alternative %d", v.index))
+	}
+	return v.v3
+}
+
+// Returns a pointer to the value of alternative T3 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant5[T0, T1, T2, T3, T4]) GetIf3() *T3 {
+	if v.index != 3 {
+		return nil
+	}
+	return &v.v3
+}
+
+// Destroys the held value and makes the variant hold the alternative T4
+// with value x.
+func (v *Variant5[T0, T1, T2, T3, T4]) Emplace4(x T4) {
+	*v = Variant5[T0, T1, T2, T3, T4]{index: 4, v4: x}
+}
+
+// Checks if the variant holds alternative T4, like std::holds_alternative.
+func (v Variant5[T0, T1, T2, T3, T4]) HoldsAlternative4() bool {
+	return v.index == 4
+}
+
+// Returns the value of alternative T4. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant5[T0, T1, T2, T3, T4]) Get4() T4 {
+	if v.index != 4 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant5.Get4 on alternative %d", v.index))
+	}
+	return v.v4
+}
+
+// Returns a pointer to the value of alternative T4 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant5[T0, T1, T2, T3, T4]) GetIf4() *T4 {
+	if v.index != 4 {
+		return nil
+	}
+	return &v.v4
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant5[T0, T1, T2, T3, T4]) Swap(other *Variant5[T0, T1, T2, T3, T4]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit5[T0, T1, T2, T3, T4, R any](v Variant5[T0, T1, T2, T3, T4], f0 func(T0) R, f1 func(T1) R, f2 func(T2) R, f3 func(T3) R, f4 func(T4) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	case 2:
+		return f2(v.v2)
+	case 3:
+		return f3(v.v3)
+	case 4:
+		return f4(v.v4)
+	}
+	return f0(v.v0)
+}
+
+// Variant6 is a type-safe union that holds a value of exactly one of 6
+// alternative types, like std::variant. The zero Variant6 holds the zero value
+// of T0.
+type Variant6[T0, T1, T2, T3, T4, T5 any] struct {
+	index int
+	v0    T0
+	v1    T1
+	v2    T2
+	v3    T3
+	v4    T4
+	v5    T5
+}
+
+// Creates a Variant6 that holds the alternative T0 with value x.
+func Variant6Alt0[T0, T1, T2, T3, T4, T5 any](x T0) Variant6[T0, T1, T2, T3, T4, T5] {
+	return Variant6[T0, T1, T2, T3, T4, T5]{index: 0, v0: x}
+}
+
+// Creates a Variant6 that holds the alternative T1 with value x.
+func Variant6Alt1[T0, T1, T2, T3, T4, T5 any](x T1) Variant6[T0, T1, T2, T3, T4, T5] {
+	return Variant6[T0, T1, T2, T3, T4, T5]{index: 1, v1: x}
+}
+
+// Creates a Variant6 that holds the alternative T2 with value x.
+func Variant6Alt2[T0, T1, T2, T3, T4, T5 any](x T2) Variant6[T0, T1, T2, T3, T4, T5] {
+	return Variant6[T0, T1, T2, T3, T4, T5]{index: 2, v2: x}
+}
+
+// Creates a Variant6 that holds the alternative T3 with value x.
+func Variant6Alt3[T0, T1, T2, T3, T4, T5 any](x T3) Variant6[T0, T1, T2, T3, T4, T5] {
+	return Variant6[T0, T1, T2, T3, T4, T5]{index: 3, v3: x}
+}
+
+// Creates a Variant6 that holds the alternative T4 with value x.
+func Variant6Alt4[T0, T1, T2, T3, T4, T5 any](x T4) Variant6[T0, T1, T2, T3, T4, T5] {
+	return Variant6[T0, T1, T2, T3, T4, T5]{index: 4, v4: x}
+}
+
+// Creates a Variant6 that holds the alternative T5 with value x.
+func Variant6Alt5[T0, T1, T2, T3, T4, T5 any](x T5) Variant6[T0, T1, T2, T3, T4, T5] {
+	return Variant6[T0, T1, T2, T3, T4, T5]{index: 5, v5: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	case 2:
+		return v.v2
+	case 3:
+		return v.v3
+	case 4:
+		return v.v4
+	case 5:
+		return v.v5
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Emplace0(x T0) {
+	*v = Variant6[T0, T1, T2, T3, T4, T5]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant6.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Emplace1(x T1) {
+	*v = Variant6[T0, T1, T2, T3, T4, T5]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant6.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Destroys the held value and makes the variant hold the alternative T2
+// with value x.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Emplace2(x T2) {
+	*v = Variant6[T0, T1, T2, T3, T4, T5]{index: 2, v2: x}
+}
+
+// Checks if the variant holds alternative T2, like std::holds_alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) HoldsAlternative2() bool {
+	return v.index == 2
+}
+
+// Returns the value of alternative T2. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Get2() T2 {
+	if v.index != 2 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant6.Get2 on alternative %d", v.index))
+	}
+	return v.v2
+}
+
+// Returns a pointer to the value of alternative T2 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) GetIf2() *T2 {
+	if v.index != 2 {
+		return nil
+	}
+	return &v.v2
+}
+
+// Destroys the held value and makes the variant hold the alternative T3
+// with value x.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Emplace3(x T3) {
+	*v = Variant6[T0, T1, T2, T3, T4, T5]{index: 3, v3: x}
+}
+
+// Checks if the variant holds alternative T3, like std::holds_alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) HoldsAlternative3() bool {
+	return v.index == 3
+}
+
+// Returns the value of alternative T3. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Get3() T3 {
+	if v.index != 3 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant6.Get3 on alternative %d", v.index))
+	}
+	return v.v3
+}
+
+// Returns a pointer to the value of alternative T3 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) GetIf3() *T3 {
+	if v.index != 3 {
+		return nil
+	}
+	return &v.v3
+}
+
+// Destroys the held value and makes the variant hold the alternative T4
+// with value x.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Emplace4(x T4) {
+	*v = Variant6[T0, T1, T2, T3, T4, T5]{index: 4, v4: x}
+}
+
+// Checks if the variant holds alternative T4, like std::holds_alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) HoldsAlternative4() bool {
+	return v.index == 4
+}
+
+// Returns the value of alternative T4. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Get4() T4 {
+	if v.index != 4 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant6.Get4 on alternative %d", v.index))
+	}
+	return v.v4
+}
+
+// Returns a pointer to the value of alternative T4 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) GetIf4() *T4 {
+	if v.index != 4 {
+		return nil
+	}
+	return &v.v4
+}
+
+// Destroys the held value and makes the variant hold the alternative T5
+// with value x.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Emplace5(x T5) {
+	*v = Variant6[T0, T1, T2, T3, T4, T5]{index: 5, v5: x}
+}
+
+// Checks if the variant holds alternative T5, like std::holds_alternative.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) HoldsAlternative5() bool {
+	return v.index == 5
+}
+
+// Returns the value of alternative T5. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant6[T0, T1, T2, T3, T4, T5]) Get5() T5 {
+	if v.index != 5 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant6.Get5 on alternative %d", v.index))
+	}
+	return v.v5
+}
+
+// Returns a pointer to the value of alternative T5 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) GetIf5() *T5 {
+	if v.index != 5 {
+		return nil
+	}
+	return &v.v5
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant6[T0, T1, T2, T3, T4, T5]) Swap(other *Variant6[T0, T1, T2, T3, T4, T5]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit6[T0, T1, T2, T3, T4, T5, R any](v Variant6[T0, T1, T2, T3, T4, T5], f0 func(T0) R, f1 func(T1) R, f2 func(T2) R, f3 func(T3) R, f4 func(T4) R, f5 func(T5) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	case 2:
+		return f2(v.v2)
+	case 3:
+		return f3(v.v3)
+	case 4:
+		return f4(v.v4)
+	case 5:
+		return f5(v.v5)
+	}
+	return f0(v.v0)
+}
+
+// Variant7 is a type-safe union that holds a value of exactly one of 7
+// alternative types, like std::variant. The zero Variant7 holds the zero value
+// of T0.
+type Variant7[T0, T1, T2, T3, T4, T5, T6 any] struct {
+	index int
+	v0    T0
+	v1    T1
+	v2    T2
+	v3    T3
+	v4    T4
+	v5    T5
+	v6    T6
+}
+
+// Creates a Variant7 that holds the alternative T0 with value x.
+func Variant7Alt0[T0, T1, T2, T3, T4, T5, T6 any](x T0) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 0, v0: x}
+}
+
+// Creates a Variant7 that holds the alternative T1 with value x.
+func Variant7Alt1[T0, T1, T2, T3, T4, T5, T6 any](x T1) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 1, v1: x}
+}
+
+// Creates a Variant7 that holds the alternative T2 with value x.
+func Variant7Alt2[T0, T1, T2, T3, T4, T5, T6 any](x T2) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 2, v2: x}
+}
+
+// Creates a Variant7 that holds the alternative T3 with value x.
+func Variant7Alt3[T0, T1, T2, T3, T4, T5, T6 any](x T3) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 3, v3: x}
+}
+
+// Creates a Variant7 that holds the alternative T4 with value x.
+func Variant7Alt4[T0, T1, T2, T3, T4, T5, T6 any](x T4) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 4, v4: x}
+}
+
+// Creates a Variant7 that holds the alternative T5 with value x.
+func Variant7Alt5[T0, T1, T2, T3, T4, T5, T6 any](x T5) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 5, v5: x}
+}
+
+// Creates a Variant7 that holds the alternative T6 with value x.
+func Variant7Alt6[T0, T1, T2, T3, T4, T5, T6 any](x T6) Variant7[T0, T1, T2, T3, T4, T5, T6] {
+	return Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 6, v6: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	case 2:
+		return v.v2
+	case 3:
+		return v.v3
+	case 4:
+		return v.v4
+	case 5:
+		return v.v5
+	case 6:
+		return v.v6
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace0(x T0) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace1(x T1) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Destroys the held value and makes the variant hold the alternative T2
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace2(x T2) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 2, v2: x}
+}
+
+// Checks if the variant holds alternative T2, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative2() bool {
+	return v.index == 2
+}
+
+// Returns the value of alternative T2. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get2() T2 {
+	if v.index != 2 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get2 on alternative %d", v.index))
+	}
+	return v.v2
+}
+
+// Returns a pointer to the value of alternative T2 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf2() *T2 {
+	if v.index != 2 {
+		return nil
+	}
+	return &v.v2
+}
+
+// Destroys the held value and makes the variant hold the alternative T3
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace3(x T3) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 3, v3: x}
+}
+
+// Checks if the variant holds alternative T3, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative3() bool {
+	return v.index == 3
+}
+
+// Returns the value of alternative T3. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get3() T3 {
+	if v.index != 3 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get3 on alternative %d", v.index))
+	}
+	return v.v3
+}
+
+// Returns a pointer to the value of alternative T3 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf3() *T3 {
+	if v.index != 3 {
+		return nil
+	}
+	return &v.v3
+}
+
+// Destroys the held value and makes the variant hold the alternative T4
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace4(x T4) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 4, v4: x}
+}
+
+// Checks if the variant holds alternative T4, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative4() bool {
+	return v.index == 4
+}
+
+// Returns the value of alternative T4. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get4() T4 {
+	if v.index != 4 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get4 on alternative %d", v.index))
+	}
+	return v.v4
+}
+
+// Returns a pointer to the value of alternative T4 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf4() *T4 {
+	if v.index != 4 {
+		return nil
+	}
+	return &v.v4
+}
+
+// Destroys the held value and makes the variant hold the alternative T5
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace5(x T5) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 5, v5: x}
+}
+
+// Checks if the variant holds alternative T5, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative5() bool {
+	return v.index == 5
+}
+
+// Returns the value of alternative T5. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get5() T5 {
+	if v.index != 5 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get5 on alternative %d", v.index))
+	}
+	return v.v5
+}
+
+// Returns a pointer to the value of alternative T5 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf5() *T5 {
+	if v.index != 5 {
+		return nil
+	}
+	return &v.v5
+}
+
+// Destroys the held value and makes the variant hold the alternative T6
+// with value x.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Emplace6(x T6) {
+	*v = Variant7[T0, T1, T2, T3, T4, T5, T6]{index: 6, v6: x}
+}
+
+// Checks if the variant holds alternative T6, like std::holds_alternative.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) HoldsAlternative6() bool {
+	return v.index == 6
+}
+
+// Returns the value of alternative T6. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant7[T0, T1, T2, T3, T4, T5, T6]) Get6() T6 {
+	if v.index != 6 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant7.Get6 on alternative %d", v.index))
+	}
+	return v.v6
+}
+
+// Returns a pointer to the value of alternative T6 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) GetIf6() *T6 {
+	if v.index != 6 {
+		return nil
+	}
+	return &v.v6
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant7[T0, T1, T2, T3, T4, T5, T6]) Swap(other *Variant7[T0, T1, T2, T3, T4, T5, T6]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit7[T0, T1, T2, T3, T4, T5, T6, R any](v Variant7[T0, T1, T2, T3, T4, T5, T6], f0 func(T0) R, f1 func(T1) R, f2 func(T2) R, f3 func(T3) R, f4 func(T4) R, f5 func(T5) R, f6 func(T6) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	case 2:
+		return f2(v.v2)
+	case 3:
+		return f3(v.v3)
+	case 4:
+		return f4(v.v4)
+	case 5:
+		return f5(v.v5)
+	case 6:
+		return f6(v.v6)
+	}
+	return f0(v.v0)
+}
+
+// Variant8 is a type-safe union that holds a value of exactly one of 8
+// alternative types, like std::variant. The zero Variant8 holds the zero value
+// of T0.
+type Variant8[T0, T1, T2, T3, T4, T5, T6, T7 any] struct {
+	index int
+	v0    T0
+	v1    T1
+	v2    T2
+	v3    T3
+	v4    T4
+	v5    T5
+	v6    T6
+	v7    T7
+}
+
+// Creates a Variant8 that holds the alternative T0 with value x.
+func Variant8Alt0[T0, T1, T2, T3, T4, T5, T6, T7 any](x T0) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 0, v0: x}
+}
+
+// Creates a Variant8 that holds the alternative T1 with value x.
+func Variant8Alt1[T0, T1, T2, T3, T4, T5, T6, T7 any](x T1) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 1, v1: x}
+}
+
+// Creates a Variant8 that holds the alternative T2 with value x.
+func Variant8Alt2[T0, T1, T2, T3, T4, T5, T6, T7 any](x T2) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 2, v2: x}
+}
+
+// Creates a Variant8 that holds the alternative T3 with value x.
+func Variant8Alt3[T0, T1, T2, T3, T4, T5, T6, T7 any](x T3) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 3, v3: x}
+}
+
+// Creates a Variant8 that holds the alternative T4 with value x.
+func Variant8Alt4[T0, T1, T2, T3, T4, T5, T6, T7 any](x T4) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 4, v4: x}
+}
+
+// Creates a Variant8 that holds the alternative T5 with value x.
+func Variant8Alt5[T0, T1, T2, T3, T4, T5, T6, T7 any](x T5) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 5, v5: x}
+}
+
+// Creates a Variant8 that holds the alternative T6 with value x.
+func Variant8Alt6[T0, T1, T2, T3, T4, T5, T6, T7 any](x T6) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 6, v6: x}
+}
+
+// Creates a Variant8 that holds the alternative T7 with value x.
+func Variant8Alt7[T0, T1, T2, T3, T4, T5, T6, T7 any](x T7) Variant8[T0, T1, T2, T3, T4, T5, T6, T7] {
+	return Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 7, v7: x}
+}
+
+// Returns the zero-based index of the alternative held by the variant.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Index() int {
+	return v.index
+}
+
+// Returns the value of the held alternative as an interface value.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Interface() any {
+	switch v.index {
+	case 1:
+		return v.v1
+	case 2:
+		return v.v2
+	case 3:
+		return v.v3
+	case 4:
+		return v.v4
+	case 5:
+		return v.v5
+	case 6:
+		return v.v6
+	case 7:
+		return v.v7
+	}
+	return v.v0
+}
+
+// Formats the value of the held alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) String() string {
+	return fmt.Sprint(v.Interface())
+}
+
+// Destroys the held value and makes the variant hold the alternative T0
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace0(x T0) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 0, v0: x}
+}
+
+// Checks if the variant holds alternative T0, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative0() bool {
+	return v.index == 0
+}
+
+// Returns the value of alternative T0. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get0() T0 {
+	if v.index != 0 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get0 on alternative %d", v.index))
+	}
+	return v.v0
+}
+
+// Returns a pointer to the value of alternative T0 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf0() *T0 {
+	if v.index != 0 {
+		return nil
+	}
+	return &v.v0
+}
+
+// Destroys the held value and makes the variant hold the alternative T1
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace1(x T1) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 1, v1: x}
+}
+
+// Checks if the variant holds alternative T1, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative1() bool {
+	return v.index == 1
+}
+
+// Returns the value of alternative T1. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get1() T1 {
+	if v.index != 1 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get1 on alternative %d", v.index))
+	}
+	return v.v1
+}
+
+// Returns a pointer to the value of alternative T1 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf1() *T1 {
+	if v.index != 1 {
+		return nil
+	}
+	return &v.v1
+}
+
+// Destroys the held value and makes the variant hold the alternative T2
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace2(x T2) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 2, v2: x}
+}
+
+// Checks if the variant holds alternative T2, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative2() bool {
+	return v.index == 2
+}
+
+// Returns the value of alternative T2. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get2() T2 {
+	if v.index != 2 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get2 on alternative %d", v.index))
+	}
+	return v.v2
+}
+
+// Returns a pointer to the value of alternative T2 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf2() *T2 {
+	if v.index != 2 {
+		return nil
+	}
+	return &v.v2
+}
+
+// Destroys the held value and makes the variant hold the alternative T3
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace3(x T3) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 3, v3: x}
+}
+
+// Checks if the variant holds alternative T3, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative3() bool {
+	return v.index == 3
+}
+
+// Returns the value of alternative T3. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get3() T3 {
+	if v.index != 3 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get3 on alternative %d", v.index))
+	}
+	return v.v3
+}
+
+// Returns a pointer to the value of alternative T3 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf3() *T3 {
+	if v.index != 3 {
+		return nil
+	}
+	return &v.v3
+}
+
+// Destroys the held value and makes the variant hold the alternative T4
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace4(x T4) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 4, v4: x}
+}
+
+// Checks if the variant holds alternative T4, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative4() bool {
+	return v.index == 4
+}
+
+// Returns the value of alternative T4. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get4() T4 {
+	if v.index != 4 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get4 on alternative %d", v.index))
+	}
+	return v.v4
+}
+
+// Returns a pointer to the value of alternative T4 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf4() *T4 {
+	if v.index != 4 {
+		return nil
+	}
+	return &v.v4
+}
+
+// Destroys the held value and makes the variant hold the alternative T5
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace5(x T5) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 5, v5: x}
+}
+
+// Checks if the variant holds alternative T5, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative5() bool {
+	return v.index == 5
+}
+
+// Returns the value of alternative T5. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get5() T5 {
+	if v.index != 5 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get5 on alternative %d", v.index))
+	}
+	return v.v5
+}
+
+// Returns a pointer to the value of alternative T5 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf5() *T5 {
+	if v.index != 5 {
+		return nil
+	}
+	return &v.v5
+}
+
+// Destroys the held value and makes the variant hold the alternative T6
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace6(x T6) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 6, v6: x}
+}
+
+// Checks if the variant holds alternative T6, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative6() bool {
+	return v.index == 6
+}
+
+// Returns the value of alternative T6. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get6() T6 {
+	if v.index != 6 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get6 on alternative %d", v.index))
+	}
+	return v.v6
+}
+
+// Returns a pointer to the value of alternative T6 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf6() *T6 {
+	if v.index != 6 {
+		return nil
+	}
+	return &v.v6
+}
+
+// Destroys the held value and makes the variant hold the alternative T7
+// with value x.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Emplace7(x T7) {
+	*v = Variant8[T0, T1, T2, T3, T4, T5, T6, T7]{index: 7, v7: x}
+}
+
+// Checks if the variant holds alternative T7, like std::holds_alternative.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) HoldsAlternative7() bool {
+	return v.index == 7
+}
+
+// Returns the value of alternative T7. Panics if the variant holds a
+// different alternative, like std::bad_variant_access.
+func (v Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Get7() T7 {
+	if v.index != 7 {
+		panic(fmt.Sprintf("utility: bad variant access: Variant8.Get7 on alternative %d", v.index))
+	}
+	return v.v7
+}
+
+// Returns a pointer to the value of alternative T7 if the variant holds it,
+// or nil otherwise, like std::get_if.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) GetIf7() *T7 {
+	if v.index != 7 {
+		return nil
+	}
+	return &v.v7
+}
+
+// Exchanges the contents of the variant with those of other.
+func (v *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) Swap(other *Variant8[T0, T1, T2, T3, T4, T5, T6, T7]) {
+	*v, *other = *other, *v
+}
+
+// Applies the callback for the held alternative to its value and returns the
+// result, like std::visit with an overload set. There must be exactly one
+// callback per alternative, so a missing case is a compile-time error.
+func Visit8[T0, T1, T2, T3, T4, T5, T6, T7, R any](v Variant8[T0, T1, T2, T3, T4, T5, T6, T7], f0 func(T0) R, f1 func(T1) R, f2 func(T2) R, f3 func(T3) R, f4 func(T4) R, f5 func(T5) R, f6 func(T6) R, f7 func(T7) R) R {
+	switch v.index {
+	case 1:
+		return f1(v.v1)
+	case 2:
+		return f2(v.v2)
+	case 3:
+		return f3(v.v3)
+	case 4:
+		return f4(v.v4)
+	case 5:
+		return f5(v.v5)
+	case 6:
+		return f6(v.v6)
+	case 7:
+		return f7(v.v7)
+	}
+	return f0(v.v0)
+}
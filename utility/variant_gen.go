@@ -0,0 +1,112 @@
+//go:build ignore
+
+// This program generates variant.go. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+const maxAlternatives = 8
+
+func main() {
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by variant_gen.go; DO NOT EDIT.
+
+package utility
+
+import "fmt"
+`)
+
+	for n := 2; n <= maxAlternatives; n++ {
+		genVariant(&b, n)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("variant.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// list formats f(i) for each i in [0, n), separated by sep.
+func list(n int, sep string, f func(i int) string) string {
+	parts := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		parts = append(parts, f(i))
+	}
+	return strings.Join(parts, sep)
+}
+
+func genVariant(b *bytes.Buffer, n int) {
+	name := fmt.Sprintf("Variant%d", n)
+	typeParams := list(n, ", ", func(i int) string { return fmt.Sprintf("T%d", i) })
+	typ := fmt.Sprintf("%s[%s]", name, typeParams)
+
+	fmt.Fprintf(b, "\n// %s is a type-safe union that holds a value of exactly one of %d\n", name, n)
+	fmt.Fprintf(b, "// alternative types, like std::variant. The zero %s holds the zero value\n// of T0.\n", name)
+	fmt.Fprintf(b, "type %s[%s any] struct {\n\tindex int\n", name, typeParams)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(b, "\tv%d T%d\n", i, i)
+	}
+	b.WriteString("}\n")
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(b, "\n// Creates a %s that holds the alternative T%d with value x.\n", name, i)
+		fmt.Fprintf(b, "func %sAlt%d[%s any](x T%d) %s {\n", name, i, typeParams, i, typ)
+		fmt.Fprintf(b, "\treturn %s{index: %d, v%d: x}\n}\n", typ, i, i)
+	}
+
+	fmt.Fprintf(b, "\n// Returns the zero-based index of the alternative held by the variant.\n")
+	fmt.Fprintf(b, "func (v %s) Index() int {\n\treturn v.index\n}\n", typ)
+
+	fmt.Fprintf(b, "\n// Returns the value of the held alternative as an interface value.\n")
+	fmt.Fprintf(b, "func (v %s) Interface() any {\n\tswitch v.index {\n", typ)
+	for i := 1; i < n; i++ {
+		fmt.Fprintf(b, "\tcase %d:\n\t\treturn v.v%d\n", i, i)
+	}
+	b.WriteString("\t}\n\treturn v.v0\n}\n")
+
+	fmt.Fprintf(b, "\n// Formats the value of the held alternative.\n")
+	fmt.Fprintf(b, "func (v %s) String() string {\n\treturn fmt.Sprint(v.Interface())\n}\n", typ)
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(b, "\n// Destroys the held value and makes the variant hold the alternative T%d\n// with value x.\n", i)
+		fmt.Fprintf(b, "func (v *%s) Emplace%d(x T%d) {\n\t*v = %s{index: %d, v%d: x}\n}\n", typ, i, i, typ, i, i)
+
+		fmt.Fprintf(b, "\n// Checks if the variant holds alternative T%d, like std::holds_alternative.\n", i)
+		fmt.Fprintf(b, "func (v %s) HoldsAlternative%d() bool {\n\treturn v.index == %d\n}\n", typ, i, i)
+
+		fmt.Fprintf(b, "\n// Returns the value of alternative T%d. Panics if the variant holds a\n", i)
+		b.WriteString("// different alternative, like std::bad_variant_access.\n")
+		fmt.Fprintf(b, "func (v %s) Get%d() T%d {\n", typ, i, i)
+		fmt.Fprintf(b, "\tif v.index != %d {\n", i)
+		fmt.Fprintf(b, "\t\tpanic(fmt.Sprintf(\"utility: bad variant access: %s.Get%d on alternative %%d\", v.index))\n\t}\n", name, i)
+		fmt.Fprintf(b, "\treturn v.v%d\n}\n", i)
+
+		fmt.Fprintf(b, "\n// Returns a pointer to the value of alternative T%d if the variant holds it,\n", i)
+		b.WriteString("// or nil otherwise, like std::get_if.\n")
+		fmt.Fprintf(b, "func (v *%s) GetIf%d() *T%d {\n", typ, i, i)
+		fmt.Fprintf(b, "\tif v.index != %d {\n\t\treturn nil\n\t}\n\treturn &v.v%d\n}\n", i, i)
+	}
+
+	fmt.Fprintf(b, "\n// Exchanges the contents of the variant with those of other.\n")
+	fmt.Fprintf(b, "func (v *%s) Swap(other *%s) {\n\t*v, *other = *other, *v\n}\n", typ, typ)
+
+	fmt.Fprintf(b, "\n// Applies the callback for the held alternative to its value and returns the\n")
+	fmt.Fprintf(b, "// result, like std::visit with an overload set. There must be exactly one\n")
+	fmt.Fprintf(b, "// callback per alternative, so a missing case is a compile-time error.\n")
+	fmt.Fprintf(b, "func Visit%d[%s, R any](v %s, %s) R {\n\tswitch v.index {\n", n, typeParams, typ,
+		list(n, ", ", func(i int) string { return fmt.Sprintf("f%d func(T%d) R", i, i) }))
+	for i := 1; i < n; i++ {
+		fmt.Fprintf(b, "\tcase %d:\n\t\treturn f%d(v.v%d)\n", i, i, i)
+	}
+	b.WriteString("\t}\n\treturn f0(v.v0)\n}\n")
+}
@@ -0,0 +1,115 @@
+package valarray
+
+import (
+	"math"
+)
+
+// apply returns a new array with the results of f applied to the elements of
+// a, computed in float64.
+func apply[T Float](a *Valarray[T], f func(float64) float64) *Valarray[T] {
+	return a.Apply(func(x T) T { return T(f(float64(x))) })
+}
+
+// apply2 returns a new array with the results of f applied to the elements of
+// a and b, computed in float64.
+func apply2[T Float](name string, a, b *Valarray[T], f func(x, y float64) float64) *Valarray[T] {
+	a.checkSize(name, len(b.v))
+	ret := New[T](len(a.v))
+	for i, x := range a.v {
+		ret.v[i] = T(f(float64(x), float64(b.v[i])))
+	}
+	return ret
+}
+
+// Returns a new array with the absolute values of the elements of a.
+func Abs[T Number](a *Valarray[T]) *Valarray[T] {
+	return a.Apply(func(x T) T {
+		if x < 0 {
+			return -x
+		}
+		return x
+	})
+}
+
+// Returns a new array with e raised to the power of the elements of a.
+func Exp[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Exp)
+}
+
+// Returns a new array with the natural logarithms of the elements of a.
+func Log[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Log)
+}
+
+// Returns a new array with the common (base 10) logarithms of the elements of
+// a.
+func Log10[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Log10)
+}
+
+// Returns a new array with the elements of a raised to the power of the
+// elements of b.
+func Pow[T Float](a, b *Valarray[T]) *Valarray[T] {
+	return apply2("Pow", a, b, math.Pow)
+}
+
+// Returns a new array with the elements of a raised to the power exp.
+func PowScalar[T Float](a *Valarray[T], exp T) *Valarray[T] {
+	return apply(a, func(x float64) float64 { return math.Pow(x, float64(exp)) })
+}
+
+// Returns a new array with the square roots of the elements of a.
+func Sqrt[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Sqrt)
+}
+
+// Returns a new array with the sines of the elements of a.
+func Sin[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Sin)
+}
+
+// Returns a new array with the cosines of the elements of a.
+func Cos[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Cos)
+}
+
+// Returns a new array with the tangents of the elements of a.
+func Tan[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Tan)
+}
+
+// Returns a new array with the arc sines of the elements of a.
+func Asin[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Asin)
+}
+
+// Returns a new array with the arc cosines of the elements of a.
+func Acos[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Acos)
+}
+
+// Returns a new array with the arc tangents of the elements of a.
+func Atan[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Atan)
+}
+
+// Returns a new array with the arc tangents of the elements of a divided by
+// those of b, using the signs of both to determine the quadrant.
+func Atan2[T Float](a, b *Valarray[T]) *Valarray[T] {
+	return apply2("Atan2", a, b, math.Atan2)
+}
+
+// Returns a new array with the hyperbolic sines of the elements of a.
+func Sinh[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Sinh)
+}
+
+// Returns a new array with the hyperbolic cosines of the elements of a.
+func Cosh[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Cosh)
+}
+
+// Returns a new array with the hyperbolic tangents of the elements of a.
+func Tanh[T Float](a *Valarray[T]) *Valarray[T] {
+	return apply(a, math.Tanh)
+}
@@ -0,0 +1,186 @@
+package valarray
+
+import (
+	"fmt"
+)
+
+// Slice selects the elements of a Valarray at the positions start, start +
+// stride, ..., start + (size - 1) * stride, like std::slice.
+type Slice struct {
+	Start, Size, Stride int
+}
+
+// GSlice selects the elements of a Valarray at the positions of a
+// multidimensional index space, like std::gslice: the position of the indices
+// (i0, i1, ...) is Start + i0 * Strides[0] + i1 * Strides[1] + .... Each index
+// ik ranges over [0, Sizes[k]), and the positions are listed with the last
+// index varying fastest.
+type GSlice struct {
+	Start   int
+	Sizes   []int
+	Strides []int
+}
+
+// indices returns the positions selected by s.
+func (s Slice) indices() []int {
+	if s.Size < 0 {
+		panic(fmt.Sprintf("valarray: Slice: negative size %d", s.Size))
+	}
+	idx := make([]int, s.Size)
+	for i := range idx {
+		idx[i] = s.Start + i*s.Stride
+	}
+	return idx
+}
+
+// indices returns the positions selected by g.
+func (g GSlice) indices() []int {
+	if len(g.Sizes) != len(g.Strides) {
+		panic(fmt.Sprintf("valarray: GSlice: %d sizes for %d strides", len(g.Sizes), len(g.Strides)))
+	}
+	n := 1
+	for _, size := range g.Sizes {
+		if size < 0 {
+			panic(fmt.Sprintf("valarray: GSlice: negative size %d", size))
+		}
+		n *= size
+	}
+	idx := make([]int, 0, n)
+	counters := make([]int, len(g.Sizes))
+	for j := 0; j < n; j++ {
+		pos := g.Start
+		for k, i := range counters {
+			pos += i * g.Strides[k]
+		}
+		idx = append(idx, pos)
+		for k := len(counters) - 1; k >= 0; k-- {
+			if counters[k]++; counters[k] < g.Sizes[k] {
+				break
+			}
+			counters[k] = 0
+		}
+	}
+	return idx
+}
+
+// Selection is a reference to some elements of a Valarray, through which they
+// can be read and assigned. It plays the role of std::slice_array,
+// std::gslice_array, std::mask_array and std::indirect_array, which differ
+// only in how the elements are selected. The selected elements are those of
+// the array when the selection is made; resizing the array invalidates it.
+type Selection[T Number] struct {
+	a   *Valarray[T]
+	idx []int
+}
+
+func (a *Valarray[T]) selection(method string, idx []int) *Selection[T] {
+	for _, i := range idx {
+		a.checkIndex(method, i)
+	}
+	return &Selection[T]{a, idx}
+}
+
+// Returns the selection of the elements at the positions of s, like
+// operator[] with a std::slice. Panics if a position is out of range.
+func (a *Valarray[T]) Slice(s Slice) *Selection[T] {
+	return a.selection("Slice", s.indices())
+}
+
+// Returns the selection of the elements at the positions of g, like
+// operator[] with a std::gslice. Panics if a position is out of range.
+func (a *Valarray[T]) GSlice(g GSlice) *Selection[T] {
+	return a.selection("GSlice", g.indices())
+}
+
+// Returns the selection of the elements at the positions where m is true, like
+// operator[] with a std::valarray<bool>. Panics if m differs in size from a.
+func (a *Valarray[T]) Mask(m Mask) *Selection[T] {
+	a.checkSize("Mask", len(m))
+	idx := make([]int, 0, m.Count())
+	for i, b := range m {
+		if b {
+			idx = append(idx, i)
+		}
+	}
+	return &Selection[T]{a, idx}
+}
+
+// Returns the selection of the elements at the positions idx, in that order,
+// like operator[] with a std::valarray<size_t>. Panics if a position is out of
+// range.
+func (a *Valarray[T]) Indirect(idx []int) *Selection[T] {
+	return a.selection("Indirect", append([]int(nil), idx...))
+}
+
+func (s *Selection[T]) checkSize(method string, n int) {
+	if n != len(s.idx) {
+		panic(fmt.Sprintf("valarray: Selection.%s: size mismatch %d != %d", method, len(s.idx), n))
+	}
+}
+
+// Returns the number of selected elements.
+func (s *Selection[T]) Size() int {
+	return len(s.idx)
+}
+
+// Returns a new Valarray holding copies of the selected elements, in the order
+// of the selection, like the std::valarray constructor from a selection.
+func (s *Selection[T]) Get() *Valarray[T] {
+	ret := New[T](len(s.idx))
+	for k, i := range s.idx {
+		ret.v[k] = s.a.v[i]
+	}
+	return ret
+}
+
+// Replaces the selected elements with the elements of b, in the order of the
+// selection. Panics if b differs in size from the selection.
+func (s *Selection[T]) Assign(b *Valarray[T]) {
+	s.checkSize("Assign", len(b.v))
+	for k, i := range s.idx {
+		s.a.v[i] = b.v[k]
+	}
+}
+
+// Sets the selected elements to x.
+func (s *Selection[T]) Fill(x T) {
+	for _, i := range s.idx {
+		s.a.v[i] = x
+	}
+}
+
+// Adds the elements of b to the selected elements, like operator+=. Panics if
+// b differs in size from the selection.
+func (s *Selection[T]) AddAssign(b *Valarray[T]) {
+	s.checkSize("AddAssign", len(b.v))
+	for k, i := range s.idx {
+		s.a.v[i] += b.v[k]
+	}
+}
+
+// Subtracts the elements of b from the selected elements, like operator-=.
+// Panics if b differs in size from the selection.
+func (s *Selection[T]) SubAssign(b *Valarray[T]) {
+	s.checkSize("SubAssign", len(b.v))
+	for k, i := range s.idx {
+		s.a.v[i] -= b.v[k]
+	}
+}
+
+// Multiplies the selected elements by the elements of b, like operator*=.
+// Panics if b differs in size from the selection.
+func (s *Selection[T]) MulAssign(b *Valarray[T]) {
+	s.checkSize("MulAssign", len(b.v))
+	for k, i := range s.idx {
+		s.a.v[i] *= b.v[k]
+	}
+}
+
+// Divides the selected elements by the elements of b, like operator/=. Panics
+// if b differs in size from the selection.
+func (s *Selection[T]) DivAssign(b *Valarray[T]) {
+	s.checkSize("DivAssign", len(b.v))
+	for k, i := range s.idx {
+		s.a.v[i] /= b.v[k]
+	}
+}
@@ -0,0 +1,512 @@
+// Package valarray provides Valarray, an array of numbers with element-wise
+// arithmetic, comparison and math functions, and selections of its elements
+// that can be assigned through, like std::valarray.
+//
+// Operations that take two arrays require them to have the same size, and
+// panic otherwise; the standard leaves that case undefined. Operators that
+// only exist for integers, such as % and the bitwise operators, are
+// functions rather than methods, since methods cannot restrict T further.
+package valarray
+
+import (
+	"fmt"
+)
+
+// Integer is the constraint of the integer element types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the constraint of the floating-point element types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the constraint of the element types of a Valarray.
+type Number interface {
+	Integer | Float
+}
+
+// Valarray is an array of numbers, like std::valarray. Its size is set when it
+// is constructed and only changes with Resize. The zero Valarray is empty.
+type Valarray[T Number] struct {
+	v []T
+}
+
+// Constructs a Valarray of n zero elements.
+func New[T Number](n int) *Valarray[T] {
+	if n < 0 {
+		panic(fmt.Sprintf("valarray: negative size %d", n))
+	}
+	return &Valarray[T]{make([]T, n)}
+}
+
+// Constructs a Valarray of n elements, each equal to value.
+func Filled[T Number](value T, n int) *Valarray[T] {
+	a := New[T](n)
+	for i := range a.v {
+		a.v[i] = value
+	}
+	return a
+}
+
+// Constructs a Valarray holding a copy of the elements of r.
+func From[T Number](r []T) *Valarray[T] {
+	return &Valarray[T]{append(make([]T, 0, len(r)), r...)}
+}
+
+// Constructs a Valarray holding the given elements.
+func Of[T Number](values ...T) *Valarray[T] {
+	return From(values)
+}
+
+func (a *Valarray[T]) checkIndex(method string, i int) {
+	if i < 0 || i >= len(a.v) {
+		panic(fmt.Sprintf("valarray: %s: index %d out of range [0, %d)", method, i, len(a.v)))
+	}
+}
+
+func (a *Valarray[T]) checkSize(method string, n int) {
+	if n != len(a.v) {
+		panic(fmt.Sprintf("valarray: %s: size mismatch %d != %d", method, len(a.v), n))
+	}
+}
+
+// Returns a copy of the array.
+func (a *Valarray[T]) Clone() *Valarray[T] {
+	return From(a.v)
+}
+
+// Returns the number of elements.
+func (a *Valarray[T]) Size() int {
+	return len(a.v)
+}
+
+// Changes the number of elements to n, and sets all of them to value. The
+// previous elements are lost, as with std::valarray::resize.
+func (a *Valarray[T]) Resize(n int, value T) {
+	*a = *Filled(value, n)
+}
+
+// Returns the element at position i. Panics if i is out of range.
+func (a *Valarray[T]) At(i int) T {
+	a.checkIndex("At", i)
+	return a.v[i]
+}
+
+// Replaces the element at position i with v. Panics if i is out of range.
+func (a *Valarray[T]) Set(i int, v T) {
+	a.checkIndex("Set", i)
+	a.v[i] = v
+}
+
+// Returns the elements of the array. The slice shares the storage of the
+// array, so it can be passed to the index-range algorithms.
+func (a *Valarray[T]) Values() []T {
+	return a.v
+}
+
+// Replaces the elements with a copy of those of b, which must have the same
+// size.
+func (a *Valarray[T]) Assign(b *Valarray[T]) *Valarray[T] {
+	a.checkSize("Assign", len(b.v))
+	copy(a.v, b.v)
+	return a
+}
+
+// Sets all elements to x.
+func (a *Valarray[T]) Fill(x T) *Valarray[T] {
+	for i := range a.v {
+		a.v[i] = x
+	}
+	return a
+}
+
+// Exchanges the contents of the array with those of other.
+func (a *Valarray[T]) Swap(other *Valarray[T]) {
+	*a, *other = *other, *a
+}
+
+// Formats the elements as [e0 e1 ...].
+func (a *Valarray[T]) String() string {
+	return fmt.Sprint(a.v)
+}
+
+// Returns a new array with the results of f applied to each element.
+func (a *Valarray[T]) Apply(f func(T) T) *Valarray[T] {
+	ret := New[T](len(a.v))
+	for i, x := range a.v {
+		ret.v[i] = f(x)
+	}
+	return ret
+}
+
+// Returns the sum of the elements, or zero if there are none.
+func (a *Valarray[T]) Sum() T {
+	var sum T
+	for _, x := range a.v {
+		sum += x
+	}
+	return sum
+}
+
+// Returns the smallest element. Panics if the array is empty.
+func (a *Valarray[T]) Min() T {
+	a.checkIndex("Min", 0)
+	m := a.v[0]
+	for _, x := range a.v[1:] {
+		m = min(m, x)
+	}
+	return m
+}
+
+// Returns the largest element. Panics if the array is empty.
+func (a *Valarray[T]) Max() T {
+	a.checkIndex("Max", 0)
+	m := a.v[0]
+	for _, x := range a.v[1:] {
+		m = max(m, x)
+	}
+	return m
+}
+
+// Returns a new array whose element i is element i + n of a, or zero if
+// i + n is out of range. A positive n shifts the elements towards the
+// beginning, a negative n towards the end.
+func (a *Valarray[T]) Shift(n int) *Valarray[T] {
+	ret := New[T](len(a.v))
+	for i := range ret.v {
+		if j := i + n; j >= 0 && j < len(a.v) {
+			ret.v[i] = a.v[j]
+		}
+	}
+	return ret
+}
+
+// Returns a new array whose element i is element (i + n) mod Size of a: the
+// elements rotated by n positions towards the beginning, or towards the end if
+// n is negative.
+func (a *Valarray[T]) Cshift(n int) *Valarray[T] {
+	size := len(a.v)
+	ret := New[T](size)
+	if size == 0 {
+		return ret
+	}
+	n %= size
+	if n < 0 {
+		n += size
+	}
+	copy(ret.v, a.v[n:])
+	copy(ret.v[size-n:], a.v[:n])
+	return ret
+}
+
+// Returns a new array with the elements negated, like unary operator-.
+func (a *Valarray[T]) Neg() *Valarray[T] {
+	ret := New[T](len(a.v))
+	for i, x := range a.v {
+		ret.v[i] = -x
+	}
+	return ret
+}
+
+// Returns a new array with the sums of the elements of a and b.
+func (a *Valarray[T]) Add(b *Valarray[T]) *Valarray[T] {
+	return a.Clone().AddAssign(b)
+}
+
+// Returns a new array with the differences of the elements of a and b.
+func (a *Valarray[T]) Sub(b *Valarray[T]) *Valarray[T] {
+	return a.Clone().SubAssign(b)
+}
+
+// Returns a new array with the products of the elements of a and b.
+func (a *Valarray[T]) Mul(b *Valarray[T]) *Valarray[T] {
+	return a.Clone().MulAssign(b)
+}
+
+// Returns a new array with the quotients of the elements of a and b.
+func (a *Valarray[T]) Div(b *Valarray[T]) *Valarray[T] {
+	return a.Clone().DivAssign(b)
+}
+
+// Returns a new array with x added to each element.
+func (a *Valarray[T]) AddScalar(x T) *Valarray[T] {
+	return a.Clone().AddAssignScalar(x)
+}
+
+// Returns a new array with x subtracted from each element.
+func (a *Valarray[T]) SubScalar(x T) *Valarray[T] {
+	return a.Clone().SubAssignScalar(x)
+}
+
+// Returns a new array with each element multiplied by x.
+func (a *Valarray[T]) MulScalar(x T) *Valarray[T] {
+	return a.Clone().MulAssignScalar(x)
+}
+
+// Returns a new array with each element divided by x.
+func (a *Valarray[T]) DivScalar(x T) *Valarray[T] {
+	return a.Clone().DivAssignScalar(x)
+}
+
+// Adds the elements of b to those of a, like operator+=, and returns a.
+func (a *Valarray[T]) AddAssign(b *Valarray[T]) *Valarray[T] {
+	a.checkSize("AddAssign", len(b.v))
+	for i, x := range b.v {
+		a.v[i] += x
+	}
+	return a
+}
+
+// Subtracts the elements of b from those of a, like operator-=, and returns
+// a.
+func (a *Valarray[T]) SubAssign(b *Valarray[T]) *Valarray[T] {
+	a.checkSize("SubAssign", len(b.v))
+	for i, x := range b.v {
+		a.v[i] -= x
+	}
+	return a
+}
+
+// Multiplies the elements of a by those of b, like operator*=, and returns a.
+func (a *Valarray[T]) MulAssign(b *Valarray[T]) *Valarray[T] {
+	a.checkSize("MulAssign", len(b.v))
+	for i, x := range b.v {
+		a.v[i] *= x
+	}
+	return a
+}
+
+// Divides the elements of a by those of b, like operator/=, and returns a.
+func (a *Valarray[T]) DivAssign(b *Valarray[T]) *Valarray[T] {
+	a.checkSize("DivAssign", len(b.v))
+	for i, x := range b.v {
+		a.v[i] /= x
+	}
+	return a
+}
+
+// Adds x to each element, and returns a.
+func (a *Valarray[T]) AddAssignScalar(x T) *Valarray[T] {
+	for i := range a.v {
+		a.v[i] += x
+	}
+	return a
+}
+
+// Subtracts x from each element, and returns a.
+func (a *Valarray[T]) SubAssignScalar(x T) *Valarray[T] {
+	for i := range a.v {
+		a.v[i] -= x
+	}
+	return a
+}
+
+// Multiplies each element by x, and returns a.
+func (a *Valarray[T]) MulAssignScalar(x T) *Valarray[T] {
+	for i := range a.v {
+		a.v[i] *= x
+	}
+	return a
+}
+
+// Divides each element by x, and returns a.
+func (a *Valarray[T]) DivAssignScalar(x T) *Valarray[T] {
+	for i := range a.v {
+		a.v[i] /= x
+	}
+	return a
+}
+
+// compare returns the mask of the elements of a and b for which f is true.
+func (a *Valarray[T]) compare(method string, b *Valarray[T], f func(x, y T) bool) Mask {
+	a.checkSize(method, len(b.v))
+	m := make(Mask, len(a.v))
+	for i, x := range a.v {
+		m[i] = f(x, b.v[i])
+	}
+	return m
+}
+
+// compareScalar returns the mask of the elements of a for which f is true.
+func (a *Valarray[T]) compareScalar(x T, f func(x, y T) bool) Mask {
+	m := make(Mask, len(a.v))
+	for i, y := range a.v {
+		m[i] = f(y, x)
+	}
+	return m
+}
+
+// Returns the mask of the positions where the elements of a and b are equal.
+func (a *Valarray[T]) Equal(b *Valarray[T]) Mask {
+	return a.compare("Equal", b, func(x, y T) bool { return x == y })
+}
+
+// Returns the mask of the positions where the elements of a and b differ.
+func (a *Valarray[T]) NotEqual(b *Valarray[T]) Mask {
+	return a.compare("NotEqual", b, func(x, y T) bool { return x != y })
+}
+
+// Returns the mask of the positions where the element of a is less than that
+// of b.
+func (a *Valarray[T]) Less(b *Valarray[T]) Mask {
+	return a.compare("Less", b, func(x, y T) bool { return x < y })
+}
+
+// Returns the mask of the positions where the element of a is less than or
+// equal to that of b.
+func (a *Valarray[T]) LessEqual(b *Valarray[T]) Mask {
+	return a.compare("LessEqual", b, func(x, y T) bool { return x <= y })
+}
+
+// Returns the mask of the positions where the element of a is greater than
+// that of b.
+func (a *Valarray[T]) Greater(b *Valarray[T]) Mask {
+	return a.compare("Greater", b, func(x, y T) bool { return x > y })
+}
+
+// Returns the mask of the positions where the element of a is greater than or
+// equal to that of b.
+func (a *Valarray[T]) GreaterEqual(b *Valarray[T]) Mask {
+	return a.compare("GreaterEqual", b, func(x, y T) bool { return x >= y })
+}
+
+// Returns the mask of the positions where the element equals x.
+func (a *Valarray[T]) EqualScalar(x T) Mask {
+	return a.compareScalar(x, func(x, y T) bool { return x == y })
+}
+
+// Returns the mask of the positions where the element differs from x.
+func (a *Valarray[T]) NotEqualScalar(x T) Mask {
+	return a.compareScalar(x, func(x, y T) bool { return x != y })
+}
+
+// Returns the mask of the positions where the element is less than x.
+func (a *Valarray[T]) LessScalar(x T) Mask {
+	return a.compareScalar(x, func(x, y T) bool { return x < y })
+}
+
+// Returns the mask of the positions where the element is less than or equal
+// to x.
+func (a *Valarray[T]) LessEqualScalar(x T) Mask {
+	return a.compareScalar(x, func(x, y T) bool { return x <= y })
+}
+
+// Returns the mask of the positions where the element is greater than x.
+func (a *Valarray[T]) GreaterScalar(x T) Mask {
+	return a.compareScalar(x, func(x, y T) bool { return x > y })
+}
+
+// Returns the mask of the positions where the element is greater than or
+// equal to x.
+func (a *Valarray[T]) GreaterEqualScalar(x T) Mask {
+	return a.compareScalar(x, func(x, y T) bool { return x >= y })
+}
+
+// integerOp returns a new array with the results of f applied to the
+// elements of a and b.
+func integerOp[T Integer](name string, a, b *Valarray[T], f func(x, y T) T) *Valarray[T] {
+	a.checkSize(name, len(b.v))
+	ret := New[T](len(a.v))
+	for i, x := range a.v {
+		ret.v[i] = f(x, b.v[i])
+	}
+	return ret
+}
+
+// Returns a new array with the remainders of the elements of a divided by
+// those of b, like operator%.
+func Mod[T Integer](a, b *Valarray[T]) *Valarray[T] {
+	return integerOp("Mod", a, b, func(x, y T) T { return x % y })
+}
+
+// Returns a new array with the bitwise and of the elements of a and b, like
+// operator&.
+func BitAnd[T Integer](a, b *Valarray[T]) *Valarray[T] {
+	return integerOp("BitAnd", a, b, func(x, y T) T { return x & y })
+}
+
+// Returns a new array with the bitwise or of the elements of a and b, like
+// operator|.
+func BitOr[T Integer](a, b *Valarray[T]) *Valarray[T] {
+	return integerOp("BitOr", a, b, func(x, y T) T { return x | y })
+}
+
+// Returns a new array with the bitwise exclusive or of the elements of a and
+// b, like operator^.
+func BitXor[T Integer](a, b *Valarray[T]) *Valarray[T] {
+	return integerOp("BitXor", a, b, func(x, y T) T { return x ^ y })
+}
+
+// Returns a new array with the elements of a shifted left by the elements of
+// b, like operator<<. Panics if an element of b is negative.
+func Lsh[T Integer](a, b *Valarray[T]) *Valarray[T] {
+	return integerOp("Lsh", a, b, func(x, y T) T { return x << y })
+}
+
+// Returns a new array with the elements of a shifted right by the elements of
+// b, like operator>>. Panics if an element of b is negative.
+func Rsh[T Integer](a, b *Valarray[T]) *Valarray[T] {
+	return integerOp("Rsh", a, b, func(x, y T) T { return x >> y })
+}
+
+// Returns a new array with the bitwise complements of the elements of a, like
+// operator~.
+func BitNot[T Integer](a *Valarray[T]) *Valarray[T] {
+	return a.Apply(func(x T) T { return ^x })
+}
+
+// Mask is an array of booleans, like std::valarray<bool>. The comparisons of
+// Valarray return masks, and Valarray.Mask selects the elements at the
+// positions where a mask is true.
+type Mask []bool
+
+// Returns a new mask with the elements negated, like operator!.
+func (m Mask) Not() Mask {
+	ret := make(Mask, len(m))
+	for i, b := range m {
+		ret[i] = !b
+	}
+	return ret
+}
+
+// Returns a new mask that is true where both m and other are, like
+// operator&&. Panics if they differ in size.
+func (m Mask) And(other Mask) Mask {
+	m.checkSize("And", other)
+	ret := make(Mask, len(m))
+	for i, b := range m {
+		ret[i] = b && other[i]
+	}
+	return ret
+}
+
+// Returns a new mask that is true where m or other is, like operator||.
+// Panics if they differ in size.
+func (m Mask) Or(other Mask) Mask {
+	m.checkSize("Or", other)
+	ret := make(Mask, len(m))
+	for i, b := range m {
+		ret[i] = b || other[i]
+	}
+	return ret
+}
+
+// Returns the number of true elements.
+func (m Mask) Count() int {
+	n := 0
+	for _, b := range m {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+func (m Mask) checkSize(method string, other Mask) {
+	if len(m) != len(other) {
+		panic(fmt.Sprintf("valarray: Mask.%s: size mismatch %d != %d", method, len(m), len(other)))
+	}
+}
@@ -0,0 +1,234 @@
+package valarray
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// mustPanic reports an error unless f panics with a message containing want.
+func mustPanic(t *testing.T, name, want string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		r := recover()
+		if r == nil {
+			t.Errorf("%s did not panic", name)
+		} else if msg, _ := r.(string); !strings.Contains(msg, want) {
+			t.Errorf("%s panicked with %v, want a message containing %q", name, r, want)
+		}
+	}()
+	f()
+}
+
+// seq returns the array 0, 1, ..., n - 1.
+func seq(n int) *Valarray[int] {
+	a := New[int](n)
+	for i := range a.v {
+		a.v[i] = i
+	}
+	return a
+}
+
+func TestShift(t *testing.T) {
+	a := Of(1, 2, 3, 4, 5)
+	tests := []struct {
+		n             int
+		shift, cshift []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}},
+		{2, []int{3, 4, 5, 0, 0}, []int{3, 4, 5, 1, 2}},
+		{-2, []int{0, 0, 1, 2, 3}, []int{4, 5, 1, 2, 3}},
+		{4, []int{5, 0, 0, 0, 0}, []int{5, 1, 2, 3, 4}},
+		{5, []int{0, 0, 0, 0, 0}, []int{1, 2, 3, 4, 5}},
+		{-5, []int{0, 0, 0, 0, 0}, []int{1, 2, 3, 4, 5}},
+		// Shifting by more than the size shifts everything out, and
+		// rotating by it rotates by n mod Size.
+		{7, []int{0, 0, 0, 0, 0}, []int{3, 4, 5, 1, 2}},
+		{-7, []int{0, 0, 0, 0, 0}, []int{4, 5, 1, 2, 3}},
+		{101, []int{0, 0, 0, 0, 0}, []int{2, 3, 4, 5, 1}},
+		{-101, []int{0, 0, 0, 0, 0}, []int{5, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		if got := a.Shift(tt.n).Values(); !slices.Equal(got, tt.shift) {
+			t.Errorf("Shift(%d) = %v, want %v", tt.n, got, tt.shift)
+		}
+		if got := a.Cshift(tt.n).Values(); !slices.Equal(got, tt.cshift) {
+			t.Errorf("Cshift(%d) = %v, want %v", tt.n, got, tt.cshift)
+		}
+	}
+	if !slices.Equal(a.Values(), []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Shift and Cshift modified the array: %v", a)
+	}
+	var empty Valarray[int]
+	if empty.Shift(3).Size() != 0 || empty.Cshift(-3).Size() != 0 {
+		t.Errorf("Shift or Cshift of an empty array is not empty")
+	}
+}
+
+func TestSelection(t *testing.T) {
+	tests := []struct {
+		name   string
+		sel    func(a *Valarray[int]) *Selection[int]
+		get    []int
+		assign []int // the array after assigning -1, -2, ... to the selection
+	}{
+		{"Slice", func(a *Valarray[int]) *Selection[int] { return a.Slice(Slice{1, 3, 4}) },
+			[]int{1, 5, 9}, []int{0, -1, 2, 3, 4, -2, 6, 7, 8, -3, 10, 11}},
+		{"empty Slice", func(a *Valarray[int]) *Selection[int] { return a.Slice(Slice{20, 0, 1}) },
+			nil, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		// The positions of a GSlice are listed with the last index varying
+		// fastest.
+		{"GSlice", func(a *Valarray[int]) *Selection[int] {
+			return a.GSlice(GSlice{Start: 1, Sizes: []int{2, 3}, Strides: []int{6, 2}})
+		}, []int{1, 3, 5, 7, 9, 11}, []int{0, -1, 2, -2, 4, -3, 6, -4, 8, -5, 10, -6}},
+		{"GSlice columns", func(a *Valarray[int]) *Selection[int] {
+			return a.GSlice(GSlice{Start: 0, Sizes: []int{2, 2}, Strides: []int{1, 4}})
+		}, []int{0, 4, 1, 5}, []int{-1, -3, 2, 3, -2, -4, 6, 7, 8, 9, 10, 11}},
+		{"Mask", func(a *Valarray[int]) *Selection[int] { return a.Mask(Mod(a, Filled(3, 12)).EqualScalar(0)) },
+			[]int{0, 3, 6, 9}, []int{-1, 1, 2, -2, 4, 5, -3, 7, 8, -4, 10, 11}},
+		{"Indirect", func(a *Valarray[int]) *Selection[int] { return a.Indirect([]int{11, 0, 4}) },
+			[]int{11, 0, 4}, []int{-2, 1, 2, 3, -3, 5, 6, 7, 8, 9, 10, -1}},
+	}
+	for _, tt := range tests {
+		a := seq(12)
+		s := tt.sel(a)
+		if got := s.Get().Values(); !slices.Equal(got, tt.get) || s.Size() != len(tt.get) {
+			t.Errorf("%s: Get() = %v, want %v", tt.name, got, tt.get)
+		}
+		b := New[int](s.Size())
+		for i := range b.v {
+			b.v[i] = -1 - i
+		}
+		s.Assign(b)
+		if !slices.Equal(a.Values(), tt.assign) {
+			t.Errorf("%s: Assign gave %v, want %v", tt.name, a, tt.assign)
+		}
+		mustPanic(t, tt.name+": Assign of the wrong size", "Selection.Assign: size mismatch", func() { s.Assign(New[int](s.Size() + 1)) })
+	}
+
+	a := seq(6)
+	s := a.Slice(Slice{0, 3, 2})
+	s.AddAssign(Of(10, 10, 10))
+	s.MulAssign(Of(1, 2, 3))
+	s.SubAssign(Of(1, 1, 1))
+	s.DivAssign(Of(3, 3, 3))
+	a.Indirect([]int{1, 3}).Fill(-1)
+	if !slices.Equal(a.Values(), []int{3, -1, 7, -1, 13, 5}) {
+		t.Errorf("compound assignments through a Slice gave %v", a)
+	}
+
+	// Indirect copies the positions, so changing them later has no effect.
+	idx := []int{0, 1}
+	sel := a.Indirect(idx)
+	idx[0] = 5
+	if got := sel.Get().Values(); !slices.Equal(got, []int{3, -1}) {
+		t.Errorf("Indirect after changing the positions: %v", got)
+	}
+
+	mustPanic(t, "Slice beyond the end", "Slice: index 6 out of range [0, 6)", func() { a.Slice(Slice{0, 4, 2}) })
+	mustPanic(t, "Slice negative size", "Slice: negative size -1", func() { a.Slice(Slice{0, -1, 1}) })
+	mustPanic(t, "GSlice beyond the end", "GSlice: index 7 out of range", func() { a.GSlice(GSlice{1, []int{2, 2}, []int{3, 3}}) })
+	mustPanic(t, "GSlice sizes and strides", "GSlice: 2 sizes for 1 strides", func() { a.GSlice(GSlice{0, []int{1, 1}, []int{1}}) })
+	mustPanic(t, "GSlice negative size", "GSlice: negative size -2", func() { a.GSlice(GSlice{0, []int{-2}, []int{1}}) })
+	mustPanic(t, "Mask of the wrong size", "Mask: size mismatch 6 != 5", func() { a.Mask(make(Mask, 5)) })
+	mustPanic(t, "Indirect negative", "Indirect: index -1 out of range", func() { a.Indirect([]int{0, -1}) })
+	mustPanic(t, "AddAssign of the wrong size", "Selection.AddAssign: size mismatch 3 != 2", func() { s.AddAssign(Of(1, 2)) })
+	mustPanic(t, "DivAssign of the wrong size", "Selection.DivAssign: size mismatch 3 != 4", func() { s.DivAssign(seq(4)) })
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := Of(6, -2, 9), Of(3, 4, -1)
+	tests := []struct {
+		name string
+		got  *Valarray[int]
+		want []int
+	}{
+		{"Add", a.Add(b), []int{9, 2, 8}},
+		{"Sub", a.Sub(b), []int{3, -6, 10}},
+		{"Mul", a.Mul(b), []int{18, -8, -9}},
+		{"Div", a.Div(b), []int{2, 0, -9}},
+		{"AddScalar", a.AddScalar(1), []int{7, -1, 10}},
+		{"DivScalar", a.DivScalar(2), []int{3, -1, 4}},
+		{"Neg", a.Neg(), []int{-6, 2, -9}},
+		{"Abs", Abs(a), []int{6, 2, 9}},
+		{"Mod", Mod(a, b), []int{0, -2, 0}},
+		{"BitAnd", BitAnd(a, Of(3, 3, 3)), []int{2, 2, 1}},
+		{"BitOr", BitOr(a, Of(1, 1, 1)), []int{7, -1, 9}},
+		{"BitXor", BitXor(a, Of(5, 5, 5)), []int{3, -5, 12}},
+		{"Lsh", Lsh(a, Of(1, 2, 0)), []int{12, -8, 9}},
+		{"Rsh", Rsh(a, Of(1, 1, 3)), []int{3, -1, 1}},
+		{"BitNot", BitNot(a), []int{-7, 1, -10}},
+		{"Apply", a.Apply(func(x int) int { return x * x }), []int{36, 4, 81}},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got.Values(), tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if !slices.Equal(a.Values(), []int{6, -2, 9}) {
+		t.Errorf("the operations modified their operand: %v", a)
+	}
+	if a.Sum() != 13 || a.Min() != -2 || a.Max() != 9 || New[int](0).Sum() != 0 {
+		t.Errorf("Sum, Min, Max = %d, %d, %d", a.Sum(), a.Min(), a.Max())
+	}
+	if m := a.Greater(b); !slices.Equal(m, Mask{true, false, true}) || m.Not().Count() != 1 || m.And(a.LessScalar(7)).Count() != 1 || m.Or(a.EqualScalar(-2)).Count() != 3 {
+		t.Errorf("Greater = %v", m)
+	}
+
+	mustPanic(t, "Add of the wrong size", "AddAssign: size mismatch 3 != 2", func() { a.Add(Of(1, 2)) })
+	mustPanic(t, "MulAssign of the wrong size", "MulAssign: size mismatch 3 != 4", func() { a.MulAssign(seq(4)) })
+	mustPanic(t, "Assign of the wrong size", "Assign: size mismatch 3 != 0", func() { a.Assign(New[int](0)) })
+	mustPanic(t, "Less of the wrong size", "Less: size mismatch 3 != 1", func() { a.Less(Of(1)) })
+	mustPanic(t, "Mod of the wrong size", "Mod: size mismatch 3 != 2", func() { Mod(a, Of(1, 2)) })
+	mustPanic(t, "Mask.And of the wrong size", "Mask.And: size mismatch 3 != 2", func() { a.EqualScalar(0).And(Mask{true, true}) })
+	mustPanic(t, "Min of an empty array", "Min: index 0 out of range [0, 0)", func() { New[int](0).Min() })
+	mustPanic(t, "At out of range", "At: index 3 out of range [0, 3)", func() { a.At(3) })
+	mustPanic(t, "New negative", "negative size -1", func() { New[int](-1) })
+}
+
+func TestMath(t *testing.T) {
+	in := []float64{0.25, 0.5, 0.75}
+	a := From(in)
+	tests := []struct {
+		name string
+		got  *Valarray[float64]
+		f    func(float64) float64
+	}{
+		{"Exp", Exp(a), math.Exp},
+		{"Log", Log(a), math.Log},
+		{"Log10", Log10(a), math.Log10},
+		{"Sqrt", Sqrt(a), math.Sqrt},
+		{"Sin", Sin(a), math.Sin},
+		{"Cos", Cos(a), math.Cos},
+		{"Tan", Tan(a), math.Tan},
+		{"Asin", Asin(a), math.Asin},
+		{"Acos", Acos(a), math.Acos},
+		{"Atan", Atan(a), math.Atan},
+		{"Sinh", Sinh(a), math.Sinh},
+		{"Cosh", Cosh(a), math.Cosh},
+		{"Tanh", Tanh(a), math.Tanh},
+		{"Abs", Abs(a.Neg()), math.Abs},
+		{"PowScalar", PowScalar(a, 3), func(x float64) float64 { return math.Pow(x, 3) }},
+		{"Pow", Pow(a, a), func(x float64) float64 { return math.Pow(x, x) }},
+		{"Atan2", Atan2(a, a.Neg()), func(x float64) float64 { return math.Atan2(x, -x) }},
+	}
+	for _, tt := range tests {
+		for i, x := range in {
+			if got, want := tt.got.At(i), tt.f(x); got != want {
+				t.Errorf("%s(%v) = %v, want %v", tt.name, x, got, want)
+			}
+		}
+	}
+
+	// The functions of float32 arrays are computed in float64 and rounded.
+	if got := Sqrt(Of[float32](4, 2)).Values(); got[0] != 2 || got[1] != float32(math.Sqrt2) {
+		t.Errorf("Sqrt of float32 = %v", got)
+	}
+	if got := Log(Of(-1.0)).At(0); !math.IsNaN(got) {
+		t.Errorf("Log(-1) = %v, want NaN", got)
+	}
+	mustPanic(t, "Pow of the wrong size", "Pow: size mismatch 3 != 1", func() { Pow(a, Of(1.0)) })
+	mustPanic(t, "Atan2 of the wrong size", "Atan2: size mismatch 3 != 4", func() { Atan2(a, New[float64](4)) })
+}